@@ -0,0 +1,105 @@
+package adapters
+
+import (
+	"bytes"
+
+	"github.com/studyzy/codei18n/adapters/cpp"
+	"github.com/studyzy/codei18n/adapters/csharp"
+	"github.com/studyzy/codei18n/adapters/dart"
+	"github.com/studyzy/codei18n/adapters/golang"
+	"github.com/studyzy/codei18n/adapters/graphql"
+	"github.com/studyzy/codei18n/adapters/groovy"
+	"github.com/studyzy/codei18n/adapters/hashcomment"
+	"github.com/studyzy/codei18n/adapters/java"
+	"github.com/studyzy/codei18n/adapters/kotlin"
+	"github.com/studyzy/codei18n/adapters/lua"
+	"github.com/studyzy/codei18n/adapters/php"
+	"github.com/studyzy/codei18n/adapters/protobuf"
+	"github.com/studyzy/codei18n/adapters/python"
+	"github.com/studyzy/codei18n/adapters/ruby"
+	"github.com/studyzy/codei18n/adapters/rust"
+	"github.com/studyzy/codei18n/adapters/scala"
+	"github.com/studyzy/codei18n/adapters/sfc"
+	"github.com/studyzy/codei18n/adapters/sql"
+	"github.com/studyzy/codei18n/adapters/swift"
+	"github.com/studyzy/codei18n/adapters/typescript"
+	"github.com/studyzy/codei18n/core"
+)
+
+func init() {
+	registerBuiltins()
+}
+
+// registerBuiltins registers the adapters shipped with codei18n
+func registerBuiltins() {
+	RegisterFactory("go", func() core.LanguageAdapter { return golang.NewAdapter() }, ".go")
+	RegisterFactory("rust", func() core.LanguageAdapter { return rust.NewRustAdapter() }, ".rs")
+	RegisterFactory(typescript.JavaScript, func() core.LanguageAdapter {
+		return typescript.NewDialectAdapter(typescript.JavaScript)
+	}, ".js", ".jsx", ".mjs", ".cjs")
+	RegisterFactory(typescript.TypeScript, func() core.LanguageAdapter {
+		return typescript.NewDialectAdapter(typescript.TypeScript)
+	}, ".ts", ".mts", ".cts")
+	RegisterFactory(typescript.TSX, func() core.LanguageAdapter {
+		return typescript.NewDialectAdapter(typescript.TSX)
+	}, ".tsx")
+	for _, lang := range []string{"vue", "svelte", "html"} {
+		lang := lang
+		RegisterFactory(lang, func() core.LanguageAdapter { return sfc.NewAdapter(lang) }, "."+lang)
+	}
+	RegisterFactory("html", func() core.LanguageAdapter { return sfc.NewAdapter("html") }, ".htm")
+	RegisterFactory("python", func() core.LanguageAdapter { return python.NewAdapter() }, ".py", ".pyi")
+	RegisterFactory("c", func() core.LanguageAdapter { return cpp.NewAdapter("c") }, ".c", ".h")
+	RegisterFactory("cpp", func() core.LanguageAdapter { return cpp.NewAdapter("cpp") }, ".cc", ".cpp", ".hpp")
+	RegisterFactory("csharp", func() core.LanguageAdapter { return csharp.NewAdapter() }, ".cs")
+	RegisterFactory("java", func() core.LanguageAdapter { return java.NewAdapter("java") }, ".java")
+	RegisterFactory("kotlin", func() core.LanguageAdapter { return kotlin.NewAdapter() }, ".kt")
+	RegisterFactory("groovy", func() core.LanguageAdapter { return groovy.NewAdapter() }, ".groovy")
+	RegisterFactory("scala", func() core.LanguageAdapter { return scala.NewAdapter() }, ".scala")
+	RegisterFactory("ruby", func() core.LanguageAdapter { return ruby.NewAdapter() }, ".rb", ".rake", ".gemspec")
+	RegisterFactory("php", func() core.LanguageAdapter { return php.NewAdapter() }, ".php")
+	RegisterFactory("lua", func() core.LanguageAdapter { return lua.NewAdapter() }, ".lua")
+	RegisterFactory("swift", func() core.LanguageAdapter { return swift.NewAdapter() }, ".swift")
+	RegisterFactory("dart", func() core.LanguageAdapter { return dart.NewAdapter() }, ".dart")
+	RegisterFactory("protobuf", func() core.LanguageAdapter { return protobuf.NewAdapter() }, ".proto")
+	RegisterFactory("graphql", func() core.LanguageAdapter { return graphql.NewAdapter() },
+		".graphql", ".graphqls", ".gql")
+	RegisterFactory("sql", func() core.LanguageAdapter {
+		return sql.NewAdapter(settings().SQLCommentClauses)
+	}, ".sql")
+
+	hashLanguages := map[string][]string{
+		hashcomment.Shell:      {".sh", ".bash"},
+		hashcomment.YAML:       {".yaml", ".yml"},
+		hashcomment.TOML:       {".toml"},
+		hashcomment.Dockerfile: {".dockerfile"},
+		hashcomment.Makefile:   {".mk"},
+		hashcomment.Dotenv:     nil,
+	}
+	for lang, exts := range hashLanguages {
+		lang := lang
+		RegisterFactory(lang, func() core.LanguageAdapter { return hashcomment.NewAdapter(lang) }, exts...)
+	}
+
+	// Files identified by name rather than extension
+	RegisterFileName("ruby", "gemfile", "rakefile")
+	RegisterFileName(hashcomment.Dockerfile, "dockerfile", "dockerfile.*")
+	RegisterFileName(hashcomment.Makefile, "makefile", "gnumakefile")
+	RegisterFileName(hashcomment.Dotenv, ".env", ".env.*")
+
+	// Extensionless scripts
+	RegisterInterpreter(hashcomment.Shell, "sh", "bash", "zsh", "ksh", "dash")
+	RegisterInterpreter("python", "python", "pypy")
+	RegisterInterpreter("ruby", "ruby")
+	RegisterInterpreter("php", "php")
+	RegisterInterpreter("lua", "lua", "luajit")
+	RegisterInterpreter(typescript.JavaScript, "node", "bun")
+	RegisterInterpreter(typescript.TypeScript, "deno", "ts-node")
+	RegisterInterpreter(hashcomment.Makefile, "make")
+	RegisterSniffer(func(head []byte) string {
+		if bytes.HasPrefix(bytes.TrimSpace(head), []byte("<?php")) {
+			return "php"
+		}
+		return ""
+	})
+}
@@ -0,0 +1,157 @@
+package cpp
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/c"
+	"github.com/smacker/go-tree-sitter/cpp"
+
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// Adapter implements the LanguageAdapter interface for C and C++.
+// It uses Tree-sitter to extract comments and splits Doxygen comments into
+// translatable prose, leaving commands such as @param or \brief untouched.
+type Adapter struct {
+	parser   *sitter.Parser
+	grammar  *sitter.Language
+	language string
+}
+
+// NewAdapter creates a new C/C++ adapter instance.
+// The optional lang selects the grammar: "c" uses the C grammar, anything else the C++ grammar.
+func NewAdapter(lang ...string) *Adapter {
+	l := "cpp"
+	if len(lang) > 0 {
+		l = lang[0]
+	}
+
+	grammar := cpp.GetLanguage()
+	if l == "c" {
+		grammar = c.GetLanguage()
+	}
+
+	p := sitter.NewParser()
+	p.SetLanguage(grammar)
+	return &Adapter{parser: p, grammar: grammar, language: l}
+}
+
+// Language returns the language identifier ("c" or "cpp")
+func (a *Adapter) Language() string {
+	return a.language
+}
+
+// Parse parses the provided C/C++ source code and extracts comments.
+// If src is nil, the source code is read from the file path.
+func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	if src == nil {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src = data
+	}
+
+	tree, err := a.parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	return a.extractComments(tree.RootNode(), src, file)
+}
+
+// extractComments walks the query matches and builds domain comments.
+// Plain comments are emitted as a whole, Doxygen comments produce one comment
+// per line of prose whose range excludes markers and commands.
+func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string) ([]*domain.Comment, error) {
+	q, err := sitter.NewQuery([]byte(cppCommentQuery), a.grammar)
+	if err != nil {
+		return nil, err
+	}
+	defer q.Close()
+
+	qc := sitter.NewQueryCursor()
+	defer qc.Close()
+
+	qc.Exec(q, root)
+
+	var comments []*domain.Comment
+	inCode := false
+
+	for {
+		m, ok := qc.NextMatch()
+		if !ok {
+			break
+		}
+
+		for _, c := range m.Captures {
+			node := c.Node
+			text := node.Content(src)
+			symbol := ResolveSymbolPath(FindOwnerNode(node, text), src)
+
+			startLine := int(node.StartPoint().Row) + 1
+			startCol := int(node.StartPoint().Column) + 1
+
+			if IsDoxygenComment(text) {
+				var fragments []docFragment
+				fragments, inCode = extractDoxygenText(text, inCode)
+				for _, f := range fragments {
+					col := f.start + 1
+					if f.line == 0 {
+						col = startCol + f.start
+					}
+					comments = append(comments, a.newComment(file, symbol, f.text, domain.CommentTypeDoc, domain.TextRange{
+						StartLine: startLine + f.line,
+						StartCol:  col,
+						EndLine:   startLine + f.line,
+						EndCol:    col + f.end - f.start,
+					}))
+				}
+				continue
+			}
+
+			if isEmptyComment(text) {
+				continue
+			}
+			cType := domain.CommentTypeLine
+			if strings.HasPrefix(text, "/*") {
+				cType = domain.CommentTypeBlock
+			}
+			comments = append(comments, a.newComment(file, symbol, text, cType, domain.TextRange{
+				StartLine: startLine,
+				StartCol:  startCol,
+				EndLine:   int(node.EndPoint().Row) + 1,
+				EndCol:    int(node.EndPoint().Column) + 1,
+			}))
+		}
+	}
+
+	return comments, nil
+}
+
+func (a *Adapter) newComment(file, symbol, text string, cType domain.CommentType, r domain.TextRange) *domain.Comment {
+	comment := &domain.Comment{
+		File:       file,
+		Language:   a.language,
+		Symbol:     symbol,
+		Range:      r,
+		SourceText: text,
+		Type:       cType,
+	}
+	comment.ID = utils.GenerateCommentID(comment)
+	return comment
+}
+
+func isEmptyComment(text string) bool {
+	t := strings.TrimSpace(text)
+	if strings.HasPrefix(t, "/*") {
+		t = strings.TrimSuffix(strings.TrimPrefix(t, "/*"), "*/")
+		return strings.TrimSpace(strings.Trim(t, "*")) == ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(t, "//")) == ""
+}
@@ -0,0 +1,128 @@
+package cpp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestAdapter_Parse_Symbols(t *testing.T) {
+	src := `namespace net {
+namespace io {
+// 服务器
+template <typename T>
+class Server {
+public:
+    // 关闭连接
+    void close(int code);
+    int port; // 行尾注释
+};
+}
+}
+
+// 实现
+void net::io::Server::close(int code) {
+    // 内部逻辑
+}
+`
+	adapter := NewAdapter()
+	assert.Equal(t, "cpp", adapter.Language())
+
+	comments, err := adapter.Parse("server.hpp", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 5)
+
+	symbols := make(map[string]string)
+	for _, c := range comments {
+		assert.Equal(t, domain.CommentTypeLine, c.Type)
+		assert.NotEmpty(t, c.ID)
+		symbols[c.SourceText] = c.Symbol
+	}
+
+	assert.Equal(t, "net::io::Server", symbols["// 服务器"])
+	assert.Equal(t, "net::io::Server::close", symbols["// 关闭连接"])
+	assert.Equal(t, "net::io::Server", symbols["// 行尾注释"])
+	assert.Equal(t, "net::io::Server::close", symbols["// 实现"])
+	assert.Equal(t, "net::io::Server::close", symbols["// 内部逻辑"])
+}
+
+func TestAdapter_Parse_C(t *testing.T) {
+	src := `/* 点结构 */
+struct point {
+    int x; /**< 横坐标 */
+};
+
+// 加法
+int add(int a, int b);
+`
+	adapter := NewAdapter("c")
+	assert.Equal(t, "c", adapter.Language())
+
+	comments, err := adapter.Parse("point.h", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+
+	assert.Equal(t, "/* 点结构 */", comments[0].SourceText)
+	assert.Equal(t, "point", comments[0].Symbol)
+	assert.Equal(t, domain.CommentTypeBlock, comments[0].Type)
+
+	assert.Equal(t, "横坐标", comments[1].SourceText)
+	assert.Equal(t, "point::x", comments[1].Symbol)
+	assert.Equal(t, domain.CommentTypeDoc, comments[1].Type)
+
+	assert.Equal(t, "add", comments[2].Symbol)
+}
+
+func TestAdapter_Parse_Doxygen(t *testing.T) {
+	src := `/**
+ * \brief 计算两个数的和
+ *
+ * @param[in] a 第一个数
+ * @param b 第二个数
+ * @return 两数之和
+ * @code
+ * int r = add(1, 2);
+ * @endcode
+ */
+int add(int a, int b);
+
+/// @brief 减法
+//! 详见 \ref add
+int sub(int a, int b);
+`
+	adapter := NewAdapter()
+	comments, err := adapter.Parse("math.cpp", []byte(src))
+	require.NoError(t, err)
+
+	var texts []string
+	for _, c := range comments {
+		texts = append(texts, c.SourceText)
+		assert.Equal(t, domain.CommentTypeDoc, c.Type)
+	}
+	assert.Equal(t, []string{"计算两个数的和", "第一个数", "第二个数", "两数之和", "减法", `详见 \ref add`}, texts)
+
+	assert.Equal(t, "add", comments[0].Symbol)
+	assert.Equal(t, "sub", comments[4].Symbol)
+
+	// Ranges point at the prose only, so commands and markers are never replaced
+	lines := []string{"", " * \\brief 计算两个数的和", "", " * @param[in] a 第一个数"}
+	assert.Equal(t, 2, comments[0].Range.StartLine)
+	assert.Equal(t, comments[0].SourceText, lines[1][comments[0].Range.StartCol-1:comments[0].Range.EndCol-1])
+	assert.Equal(t, 4, comments[1].Range.StartLine)
+	assert.Equal(t, comments[1].SourceText, lines[3][comments[1].Range.StartCol-1:comments[1].Range.EndCol-1])
+}
+
+func TestIsDoxygenComment(t *testing.T) {
+	assert.True(t, IsDoxygenComment("/// doc"))
+	assert.True(t, IsDoxygenComment("//! doc"))
+	assert.True(t, IsDoxygenComment("/** doc */"))
+	assert.True(t, IsDoxygenComment("/*! doc */"))
+	assert.False(t, IsDoxygenComment("//// separator"))
+	assert.False(t, IsDoxygenComment("/**/"))
+	assert.False(t, IsDoxygenComment("// plain"))
+	assert.True(t, IsTrailingDoc("///< member"))
+	assert.False(t, IsTrailingDoc("/// member"))
+}
@@ -0,0 +1,161 @@
+package cpp
+
+import (
+	"regexp"
+	"strings"
+)
+
+// docFragment is one line of translatable Doxygen prose.
+// line is the 0-based line index inside the comment, start and end are
+// byte offsets relative to the beginning of that line.
+type docFragment struct {
+	text  string
+	line  int
+	start int
+	end   int
+}
+
+// commandPattern matches a Doxygen command at the start of the text, e.g. "@param" or "\brief"
+var commandPattern = regexp.MustCompile(`^[@\\]([A-Za-z]+|[{}])`)
+
+// argCommands take a single word argument that must not be translated
+var argCommands = map[string]bool{
+	"param": true, "tparam": true, "throw": true, "throws": true, "exception": true,
+	"retval": true, "see": true, "sa": true, "ref": true, "relates": true,
+	"memberof": true, "ingroup": true, "a": true, "p": true, "c": true,
+}
+
+// lineCommands protect the whole rest of the line (signatures, file names, group ids)
+var lineCommands = map[string]bool{
+	"file": true, "fn": true, "def": true, "class": true, "struct": true, "union": true,
+	"enum": true, "namespace": true, "typedef": true, "var": true, "property": true,
+	"defgroup": true, "addtogroup": true, "copydoc": true, "include": true,
+	"cond": true, "endcond": true, "dir": true, "page": true, "example": true,
+}
+
+// codeStartCommands open a block whose content is code
+var codeStartCommands = map[string]string{
+	"code": "endcode", "verbatim": "endverbatim", "dot": "enddot", "msc": "endmsc",
+}
+
+// IsDoxygenComment reports whether a comment uses Doxygen markers (///, //!, /** or /*!).
+func IsDoxygenComment(text string) bool {
+	if strings.HasPrefix(text, "////") || strings.HasPrefix(text, "/**/") {
+		return false
+	}
+	return strings.HasPrefix(text, "///") || strings.HasPrefix(text, "//!") ||
+		strings.HasPrefix(text, "/**") || strings.HasPrefix(text, "/*!")
+}
+
+// IsTrailingDoc reports whether a Doxygen comment documents the preceding member (///<, /**<).
+func IsTrailingDoc(text string) bool {
+	return len(text) > 3 && text[3] == '<' && IsDoxygenComment(text)
+}
+
+// extractDoxygenText splits a Doxygen comment into translatable prose fragments.
+// inCode carries @code/@endcode state across consecutive comments.
+func extractDoxygenText(text string, inCode bool) ([]docFragment, bool) {
+	var fragments []docFragment
+	lines := strings.Split(text, "\n")
+	block := strings.HasPrefix(text, "/*")
+	endCmd := "endcode"
+
+	for i, line := range lines {
+		body, offset := stripDecoration(line, i == 0, block && i == len(lines)-1, block)
+
+		if inCode {
+			if strings.Contains(body, "@"+endCmd) || strings.Contains(body, "\\"+endCmd) || strings.HasPrefix(strings.TrimSpace(body), "```") {
+				inCode = false
+			}
+			continue
+		}
+
+		start, end := 0, len(body)
+		for {
+			trimmed := strings.TrimLeft(body[start:end], " \t")
+			start = end - len(trimmed)
+			if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+				inCode, endCmd = true, "```"
+				start = end
+				break
+			}
+			loc := commandPattern.FindStringSubmatchIndex(trimmed)
+			if loc == nil {
+				break
+			}
+			name := trimmed[loc[2]:loc[3]]
+			if closing, ok := codeStartCommands[name]; ok {
+				inCode, endCmd = true, closing
+				start = end
+				break
+			}
+			if lineCommands[name] {
+				start = end
+				break
+			}
+			start += loc[1]
+			if argCommands[name] {
+				start = skipArgument(body, start, end)
+			}
+		}
+
+		prose := strings.TrimRight(body[start:end], " \t\r")
+		if strings.TrimSpace(prose) == "" {
+			continue
+		}
+		fragments = append(fragments, docFragment{
+			text:  prose,
+			line:  i,
+			start: offset + start,
+			end:   offset + start + len(prose),
+		})
+	}
+
+	return fragments, inCode
+}
+
+// stripDecoration removes comment markers and leading "*" decoration from a line.
+// It returns the remaining body and its byte offset within the line.
+func stripDecoration(line string, first, last, block bool) (string, int) {
+	offset := 0
+	body := line
+
+	if first {
+		marker := body[:3]
+		if len(body) > 3 && body[3] == '<' {
+			marker = body[:4]
+		}
+		body = body[len(marker):]
+		offset += len(marker)
+	} else if block {
+		trimmed := strings.TrimLeft(body, " \t")
+		offset += len(body) - len(trimmed)
+		body = trimmed
+		if strings.HasPrefix(body, "*") && !strings.HasPrefix(body, "*/") {
+			body = body[1:]
+			offset++
+		}
+	}
+
+	if last {
+		body = strings.TrimSuffix(strings.TrimRight(body, " \t\r"), "*/")
+		body = strings.TrimRight(body, "*")
+	}
+	return body, offset
+}
+
+// skipArgument skips an optional [in,out] direction and the following word
+func skipArgument(body string, start, end int) int {
+	rest := body[start:end]
+	trimmed := strings.TrimLeft(rest, " \t")
+	if strings.HasPrefix(trimmed, "[") {
+		if i := strings.Index(trimmed, "]"); i >= 0 {
+			trimmed = strings.TrimLeft(trimmed[i+1:], " \t")
+		}
+	}
+	word := strings.IndexAny(trimmed, " \t")
+	if word < 0 {
+		word = len(trimmed)
+	}
+	return end - len(trimmed) + word
+}
@@ -0,0 +1,7 @@
+package cpp
+
+// cppCommentQuery is the Tree-sitter query to extract comments.
+// Both the C and C++ grammars expose //, /* */ and Doxygen comments as (comment) nodes.
+const cppCommentQuery = `
+(comment) @comment
+`
@@ -0,0 +1,88 @@
+package cpp
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// FindOwnerNode finds the semantic owner node of the comment.
+// Trailing Doxygen comments (///<) and comments after code on the same line belong to
+// the preceding member, other comments belong to the next declaration (skipping comments),
+// falling back to the enclosing scope.
+func FindOwnerNode(commentNode *sitter.Node, text string) *sitter.Node {
+	if prev := commentNode.PrevNamedSibling(); prev != nil {
+		if IsTrailingDoc(text) {
+			return prev
+		}
+		if prev.EndPoint().Row == commentNode.StartPoint().Row {
+			return commentNode.Parent()
+		}
+	}
+
+	next := commentNode.NextNamedSibling()
+	for next != nil && next.Type() == "comment" {
+		next = next.NextNamedSibling()
+	}
+	if next != nil {
+		return next
+	}
+	return commentNode.Parent()
+}
+
+// ResolveSymbolPath resolves the semantic symbol path for a given node by traversing up the syntax tree.
+// It constructs a path string like "ns::Class::method".
+func ResolveSymbolPath(node *sitter.Node, src []byte) string {
+	// template <...> wraps the actual declaration
+	for node != nil && node.Type() == "template_declaration" && node.NamedChildCount() > 0 {
+		node = node.NamedChild(int(node.NamedChildCount()) - 1)
+	}
+
+	var parts []string
+	for curr := node; curr != nil; curr = curr.Parent() {
+		if name := declarationName(curr, src); name != "" {
+			parts = append([]string{name}, parts...)
+		}
+	}
+	return strings.Join(parts, "::")
+}
+
+// declarationName returns the name contributed by a declaration node to the symbol path
+func declarationName(node *sitter.Node, src []byte) string {
+	switch node.Type() {
+	case "namespace_definition", "class_specifier", "struct_specifier", "union_specifier",
+		"enum_specifier", "enumerator", "preproc_def", "preproc_function_def",
+		"alias_declaration", "concept_definition":
+		return getChildContent(node, "name", src)
+	case "function_definition", "declaration", "field_declaration", "type_definition":
+		if decl := node.ChildByFieldName("declarator"); decl != nil {
+			return declaratorName(decl, src)
+		}
+	}
+	return ""
+}
+
+// declaratorName unwraps pointer, reference and function declarators to find the declared name
+func declaratorName(node *sitter.Node, src []byte) string {
+	for node != nil {
+		switch node.Type() {
+		case "identifier", "field_identifier", "type_identifier", "qualified_identifier",
+			"destructor_name", "operator_name", "namespace_identifier":
+			return strings.Join(strings.Fields(node.Content(src)), "")
+		}
+		inner := node.ChildByFieldName("declarator")
+		if inner == nil {
+			return ""
+		}
+		node = inner
+	}
+	return ""
+}
+
+func getChildContent(node *sitter.Node, fieldName string, src []byte) string {
+	child := node.ChildByFieldName(fieldName)
+	if child != nil {
+		return child.Content(src)
+	}
+	return ""
+}
@@ -0,0 +1,145 @@
+package csharp
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/csharp"
+
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// Adapter implements the LanguageAdapter interface for C#.
+// It uses Tree-sitter to extract //, /* */ and /// XML doc comments.
+type Adapter struct {
+	parser *sitter.Parser
+}
+
+// NewAdapter creates a new C# adapter instance
+func NewAdapter() *Adapter {
+	p := sitter.NewParser()
+	p.SetLanguage(csharp.GetLanguage())
+	return &Adapter{parser: p}
+}
+
+// Language returns the language identifier ("csharp")
+func (a *Adapter) Language() string {
+	return "csharp"
+}
+
+// Parse parses the provided C# source code and extracts comments.
+// If src is nil, the source code is read from the file path.
+func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	if src == nil {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src = data
+	}
+
+	tree, err := a.parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	return a.extractComments(tree.RootNode(), src, file)
+}
+
+// extractComments walks the query matches and builds domain comments.
+// XML doc comments (///) only contribute their text content; surrounding
+// tags such as <summary> or <param name="x"> stay outside of the comment range.
+func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string) ([]*domain.Comment, error) {
+	q, err := sitter.NewQuery([]byte(csharpCommentQuery), csharp.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+	defer q.Close()
+
+	qc := sitter.NewQueryCursor()
+	defer qc.Close()
+
+	qc.Exec(q, root)
+
+	fileNamespace := fileScopedNamespace(root, src)
+	var comments []*domain.Comment
+	inCode := false
+
+	for {
+		m, ok := qc.NextMatch()
+		if !ok {
+			break
+		}
+
+		for _, c := range m.Captures {
+			node := c.Node
+			text := node.Content(src)
+			symbol := resolveSymbolPath(findOwnerNode(node), src, fileNamespace)
+
+			startLine := int(node.StartPoint().Row) + 1
+			startCol := int(node.StartPoint().Column) + 1
+
+			var comment *domain.Comment
+			switch {
+			case strings.HasPrefix(text, "///"):
+				var frag *xmlFragment
+				frag, inCode = extractXMLDocText(text, inCode)
+				if frag == nil {
+					continue
+				}
+				comment = &domain.Comment{
+					SourceText: frag.text,
+					Type:       domain.CommentTypeDoc,
+					Range: domain.TextRange{
+						StartLine: startLine,
+						StartCol:  startCol + frag.start,
+						EndLine:   startLine,
+						EndCol:    startCol + frag.end,
+					},
+				}
+			default:
+				if isEmptyComment(text) {
+					continue
+				}
+				cType := domain.CommentTypeLine
+				if strings.HasPrefix(text, "/**") {
+					cType = domain.CommentTypeDoc
+				} else if strings.HasPrefix(text, "/*") {
+					cType = domain.CommentTypeBlock
+				}
+				comment = &domain.Comment{
+					SourceText: text,
+					Type:       cType,
+					Range: domain.TextRange{
+						StartLine: startLine,
+						StartCol:  startCol,
+						EndLine:   int(node.EndPoint().Row) + 1,
+						EndCol:    int(node.EndPoint().Column) + 1,
+					},
+				}
+			}
+
+			comment.File = file
+			comment.Language = "csharp"
+			comment.Symbol = symbol
+			comment.ID = utils.GenerateCommentID(comment)
+
+			comments = append(comments, comment)
+		}
+	}
+
+	return comments, nil
+}
+
+func isEmptyComment(text string) bool {
+	t := strings.TrimSpace(text)
+	if strings.HasPrefix(t, "/*") {
+		t = strings.TrimSuffix(strings.TrimPrefix(t, "/*"), "*/")
+		return strings.TrimSpace(strings.Trim(t, "*")) == ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(t, "//")) == ""
+}
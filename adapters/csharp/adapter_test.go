@@ -0,0 +1,136 @@
+package csharp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestAdapter_Parse_Symbols(t *testing.T) {
+	src := `using System;
+
+namespace Acme.Billing
+{
+    // 账户类
+    [Serializable]
+    public class Account
+    {
+        /* 余额 */
+        private int balance; // 行尾注释
+
+        // 存款
+        public void Deposit(int amount)
+        {
+            // 校验金额
+            Validate(amount);
+        }
+
+        // 名称
+        public string Name { get; set; }
+    }
+}
+`
+	adapter := NewAdapter()
+	assert.Equal(t, "csharp", adapter.Language())
+
+	comments, err := adapter.Parse("Account.cs", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 6)
+
+	symbols := make(map[string]string)
+	for _, c := range comments {
+		symbols[c.SourceText] = c.Symbol
+		assert.NotEmpty(t, c.ID)
+	}
+
+	assert.Equal(t, "Acme.Billing.Account", symbols["// 账户类"])
+	assert.Equal(t, "Acme.Billing.Account.balance", symbols["/* 余额 */"])
+	assert.Equal(t, "Acme.Billing.Account", symbols["// 行尾注释"])
+	assert.Equal(t, "Acme.Billing.Account.Deposit", symbols["// 存款"])
+	assert.Equal(t, "Acme.Billing.Account.Deposit", symbols["// 校验金额"])
+	assert.Equal(t, "Acme.Billing.Account.Name", symbols["// 名称"])
+	assert.Equal(t, domain.CommentTypeBlock, comments[1].Type)
+}
+
+func TestAdapter_Parse_FileScopedNamespace(t *testing.T) {
+	src := `namespace Acme.Core;
+
+public interface IStore
+{
+    // 保存
+    void Save();
+}
+`
+	adapter := NewAdapter()
+	comments, err := adapter.Parse("IStore.cs", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "Acme.Core.IStore.Save", comments[0].Symbol)
+}
+
+func TestAdapter_Parse_XMLDoc(t *testing.T) {
+	src := `namespace Acme
+{
+    public class Calc
+    {
+        /// <summary>
+        /// 计算 <see cref="Total"/> 的值
+        /// </summary>
+        /// <param name="x">输入值</param>
+        /// <returns>结果</returns>
+        /// <example>
+        /// <code>
+        /// var r = calc.Add(1);
+        /// </code>
+        /// </example>
+        public int Add(int x) { return x; }
+    }
+}
+`
+	adapter := NewAdapter()
+	comments, err := adapter.Parse("Calc.cs", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+
+	for _, c := range comments {
+		assert.Equal(t, domain.CommentTypeDoc, c.Type)
+		assert.Equal(t, "Acme.Calc.Add", c.Symbol)
+	}
+
+	assert.Equal(t, `计算 <see cref="Total"/> 的值`, comments[0].SourceText)
+	assert.Equal(t, "输入值", comments[1].SourceText)
+	assert.Equal(t, "结果", comments[2].SourceText)
+
+	// The range must cover only the text content so tags stay untouched on replacement
+	lines := []string{
+		`        /// 计算 <see cref="Total"/> 的值`,
+		`        /// <param name="x">输入值</param>`,
+		`        /// <returns>结果</returns>`,
+	}
+	for i, c := range comments {
+		assert.Equal(t, c.Range.StartLine, c.Range.EndLine)
+		assert.Equal(t, c.SourceText, lines[i][c.Range.StartCol-1:c.Range.EndCol-1])
+	}
+}
+
+func TestExtractXMLDocText(t *testing.T) {
+	frag, inCode := extractXMLDocText("/// <summary>", false)
+	assert.Nil(t, frag)
+	assert.False(t, inCode)
+
+	frag, inCode = extractXMLDocText("/// <code>", false)
+	assert.Nil(t, frag)
+	assert.True(t, inCode)
+
+	frag, inCode = extractXMLDocText("/// x = 1;", inCode)
+	assert.Nil(t, frag)
+	assert.True(t, inCode)
+
+	frag, inCode = extractXMLDocText("/// </code> 示例结束", inCode)
+	require.NotNil(t, frag)
+	assert.False(t, inCode)
+	assert.Equal(t, "示例结束", frag.text)
+}
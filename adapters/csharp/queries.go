@@ -0,0 +1,7 @@
+package csharp
+
+// csharpCommentQuery is the Tree-sitter query to extract comments.
+// The C# grammar exposes //, /* */ and /// comments as a single (comment) node type.
+const csharpCommentQuery = `
+(comment) @comment
+`
@@ -0,0 +1,101 @@
+package csharp
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// findOwnerNode finds the node a comment belongs to.
+// Trailing comments bind to the enclosing scope, other comments bind to the
+// next declaration (skipping comments), falling back to the enclosing scope.
+func findOwnerNode(node *sitter.Node) *sitter.Node {
+	if prev := node.PrevNamedSibling(); prev != nil && prev.EndPoint().Row == node.StartPoint().Row {
+		return node.Parent()
+	}
+
+	next := node.NextNamedSibling()
+	for next != nil && next.Type() == "comment" {
+		next = next.NextNamedSibling()
+	}
+	if next != nil {
+		return next
+	}
+	return node.Parent()
+}
+
+// resolveSymbolPath builds a path like "Namespace.Class.Method" by walking up the tree.
+// fileNamespace is the name of a file-scoped namespace declaration, if any.
+func resolveSymbolPath(node *sitter.Node, src []byte, fileNamespace string) string {
+	var parts []string
+	for curr := node; curr != nil; curr = curr.Parent() {
+		if name := declarationName(curr, src); name != "" {
+			parts = append([]string{name}, parts...)
+		}
+	}
+
+	if fileNamespace != "" {
+		parts = append([]string{fileNamespace}, parts...)
+	}
+	return strings.Join(parts, ".")
+}
+
+// declarationName returns the name contributed by a declaration node to the symbol path
+func declarationName(node *sitter.Node, src []byte) string {
+	switch node.Type() {
+	case "namespace_declaration",
+		"class_declaration", "struct_declaration", "interface_declaration",
+		"enum_declaration", "record_declaration", "record_struct_declaration",
+		"delegate_declaration",
+		"method_declaration", "constructor_declaration", "destructor_declaration",
+		"property_declaration", "enum_member_declaration", "local_function_statement":
+		return getChildContent(node, "name", src)
+	case "indexer_declaration":
+		return "this[]"
+	case "operator_declaration":
+		if op := node.ChildByFieldName("operator"); op != nil {
+			return "operator" + op.Content(src)
+		}
+		return "operator"
+	case "field_declaration", "event_field_declaration":
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			child := node.NamedChild(i)
+			if child.Type() == "variable_declaration" {
+				return firstDeclaratorName(child, src)
+			}
+		}
+	case "event_declaration":
+		return getChildContent(node, "name", src)
+	}
+	return ""
+}
+
+// firstDeclaratorName returns the name of the first variable declarator
+func firstDeclaratorName(node *sitter.Node, src []byte) string {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if child.Type() == "variable_declarator" {
+			return getChildContent(child, "name", src)
+		}
+	}
+	return ""
+}
+
+// fileScopedNamespace returns the name of a file-scoped namespace (namespace Foo.Bar;)
+func fileScopedNamespace(root *sitter.Node, src []byte) string {
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		child := root.NamedChild(i)
+		if child.Type() == "file_scoped_namespace_declaration" {
+			return getChildContent(child, "name", src)
+		}
+	}
+	return ""
+}
+
+func getChildContent(node *sitter.Node, fieldName string, src []byte) string {
+	child := node.ChildByFieldName(fieldName)
+	if child != nil {
+		return child.Content(src)
+	}
+	return ""
+}
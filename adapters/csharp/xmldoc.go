@@ -0,0 +1,81 @@
+package csharp
+
+import (
+	"regexp"
+	"strings"
+)
+
+// xmlTagPattern matches a single XML tag such as <summary>, </param> or <see cref="T:Foo"/>
+var xmlTagPattern = regexp.MustCompile(`<[^<>]*>`)
+
+// xmlFragment is the translatable text of a /// line.
+// start and end are byte offsets relative to the beginning of the comment.
+type xmlFragment struct {
+	text  string
+	start int
+	end   int
+}
+
+// extractXMLDocText returns the translatable text of a single /// line.
+// Leading and trailing tags (and their attributes) are excluded from the fragment,
+// inline tags between two pieces of text are kept inside it.
+// Lines inside <code> blocks are never translated; inCode carries that state across lines.
+func extractXMLDocText(line string, inCode bool) (*xmlFragment, bool) {
+	body := strings.TrimPrefix(line, "///")
+	offset := len(line) - len(body)
+
+	// Compute the byte ranges that are not covered by tags
+	tags := xmlTagPattern.FindAllStringIndex(body, -1)
+	type span struct{ start, end int }
+	var texts []span
+	pos := 0
+	for _, t := range tags {
+		name := tagName(body[t[0]:t[1]])
+		if !inCode && t[0] > pos {
+			texts = append(texts, span{pos, t[0]})
+		}
+		switch name {
+		case "code":
+			inCode = true
+		case "/code":
+			inCode = false
+		}
+		pos = t[1]
+	}
+	if !inCode && pos < len(body) {
+		texts = append(texts, span{pos, len(body)})
+	}
+
+	// Trim whitespace-only spans at both ends
+	for len(texts) > 0 && strings.TrimSpace(body[texts[0].start:texts[0].end]) == "" {
+		texts = texts[1:]
+	}
+	for len(texts) > 0 && strings.TrimSpace(body[texts[len(texts)-1].start:texts[len(texts)-1].end]) == "" {
+		texts = texts[:len(texts)-1]
+	}
+	if len(texts) == 0 {
+		return nil, inCode
+	}
+
+	start := texts[0].start
+	end := texts[len(texts)-1].end
+	raw := body[start:end]
+	start += len(raw) - len(strings.TrimLeft(raw, " \t"))
+	end -= len(raw) - len(strings.TrimRight(raw, " \t\r"))
+
+	return &xmlFragment{
+		text:  body[start:end],
+		start: offset + start,
+		end:   offset + end,
+	}, inCode
+}
+
+// tagName returns the lower-cased element name of a tag, keeping a leading "/" for closing tags
+func tagName(tag string) string {
+	t := strings.TrimSuffix(strings.TrimPrefix(tag, "<"), ">")
+	t = strings.TrimSuffix(t, "/")
+	if i := strings.IndexAny(t, " \t"); i >= 0 {
+		t = t[:i]
+	}
+	return strings.ToLower(t)
+}
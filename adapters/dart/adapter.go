@@ -0,0 +1,79 @@
+package dart
+
+import (
+	"os"
+	"strings"
+
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// Adapter implements the LanguageAdapter interface for Dart.
+// /// and /** */ are dartdoc comments; // TODO: comments expose only their description.
+type Adapter struct{}
+
+// NewAdapter creates a new Dart adapter instance
+func NewAdapter() *Adapter {
+	return &Adapter{}
+}
+
+// Language returns the language identifier ("dart")
+func (a *Adapter) Language() string {
+	return "dart"
+}
+
+// Parse tokenizes the provided Dart source and extracts comments.
+// If src is nil, the source code is read from the file path.
+func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	if src == nil {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src = data
+	}
+
+	found, symbols := bind(tokenize(string(src)))
+
+	var comments []*domain.Comment
+	for i, c := range found {
+		t := c.tok
+		text := t.text
+		col := t.col
+
+		cType := domain.CommentTypeLine
+		switch {
+		case strings.HasPrefix(text, "///") || (strings.HasPrefix(text, "/**") && text != "/**/"):
+			cType = domain.CommentTypeDoc
+		case strings.HasPrefix(text, "/*"):
+			cType = domain.CommentTypeBlock
+		default:
+			if prefix := utils.TaskMarkerPrefix(text); prefix != "" {
+				text = text[len(prefix):]
+				col += len(prefix)
+			}
+		}
+		if strings.TrimSpace(strings.Trim(text, "/*")) == "" {
+			continue
+		}
+
+		comment := &domain.Comment{
+			File:     file,
+			Language: "dart",
+			Symbol:   symbols[i],
+			Range: domain.TextRange{
+				StartLine: t.row + 1,
+				StartCol:  col + 1,
+				EndLine:   t.endRow + 1,
+				EndCol:    t.endCol + 1,
+			},
+			SourceText: text,
+			Type:       cType,
+		}
+		comment.ID = utils.GenerateCommentID(comment)
+
+		comments = append(comments, comment)
+	}
+
+	return comments, nil
+}
@@ -0,0 +1,116 @@
+package dart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+const counterDart = `import 'package:flutter/widgets.dart';
+
+/// 计数器
+class Counter extends ChangeNotifier {
+  /// 当前值
+  int _value = 0; // 私有字段
+
+  Counter.named(this._value);
+
+  /// 当前值的读取器
+  int get value => _value;
+
+  /// 加一
+  /// 参见 [reset]
+  void increment() {
+    // TODO(alice): 限制最大值
+    _value++;
+    notifyListeners();
+  }
+
+  @override
+  String toString() => 'Counter(${'$_value' /* 不是注释 */})';
+}
+
+/* 外层 /* 嵌套 */ 注释 */
+extension CounterX on Counter {
+  // 重置
+  void reset() {}
+}
+
+enum Color {
+  /// 红
+  red, // 行尾
+  green
+}
+
+/// 入口
+Future<void> main() async {}
+`
+
+func TestAdapter_Parse(t *testing.T) {
+	adapter := NewAdapter()
+	assert.Equal(t, "dart", adapter.Language())
+
+	comments, err := adapter.Parse("lib/counter.dart", []byte(counterDart))
+	require.NoError(t, err)
+
+	var texts, symbols []string
+	for _, c := range comments {
+		texts = append(texts, c.SourceText)
+		symbols = append(symbols, c.Symbol)
+	}
+	assert.Equal(t, []string{
+		"/// 计数器",
+		"/// 当前值",
+		"// 私有字段",
+		"/// 当前值的读取器",
+		"/// 加一",
+		"/// 参见 [reset]",
+		"限制最大值",
+		"/* 外层 /* 嵌套 */ 注释 */",
+		"// 重置",
+		"/// 红",
+		"// 行尾",
+		"/// 入口",
+	}, texts)
+	assert.Equal(t, []string{
+		"Counter",
+		"Counter._value",
+		"Counter._value",
+		"Counter.value",
+		"Counter.increment",
+		"Counter.increment",
+		"Counter.increment",
+		"Counter",
+		"Counter.reset",
+		"Color.red",
+		"Color.red",
+		"main",
+	}, symbols)
+
+	assert.Equal(t, domain.CommentTypeDoc, comments[0].Type)
+	assert.Equal(t, domain.CommentTypeLine, comments[2].Type)
+	assert.Equal(t, domain.CommentTypeBlock, comments[7].Type)
+
+	// The TODO marker stays out of the translatable range
+	assert.Equal(t, domain.CommentTypeLine, comments[6].Type)
+	assert.Equal(t, 16, comments[6].Range.StartLine)
+	assert.Equal(t, 21, comments[6].Range.StartCol)
+}
+
+func TestAdapter_Parse_Markers(t *testing.T) {
+	src := "// MARK: - 数据源\n// TODO(alice): 限制最大值\n// FIXME: 修复崩溃\n"
+	comments, err := NewAdapter().Parse("lib/markers.dart", []byte(src))
+	require.NoError(t, err)
+
+	var texts []string
+	var cols []int
+	for _, c := range comments {
+		texts = append(texts, c.SourceText)
+		cols = append(cols, c.Range.StartCol)
+	}
+	assert.Equal(t, []string{"数据源", "限制最大值", "修复崩溃"}, texts)
+	assert.Equal(t, []int{12, 17, 11}, cols)
+}
@@ -0,0 +1,223 @@
+package dart
+
+import "strings"
+
+// tokenKind classifies lexical tokens of a Dart library
+type tokenKind int
+
+const (
+	tokenName tokenKind = iota
+	tokenString
+	tokenComment
+	tokenPunct
+	tokenValue
+)
+
+// token is a lexical token with 0-based row and byte column positions
+type token struct {
+	kind     tokenKind
+	text     string
+	row, col int
+	endRow   int
+	endCol   int
+}
+
+// byteOrderMark is ignored like whitespace
+const byteOrderMark = "\uFEFF"
+
+// multiPuncts are the multi-character operators the binder needs to tell apart from = and .
+var multiPuncts = []string{"=>", "==", "!=", "<=", ">=", "...", ".."}
+
+// lexer splits Dart source into tokens.
+// There is no Dart Tree-sitter grammar available; locating comments only requires
+// getting strings (including ${} interpolation) and nested block comments right.
+type lexer struct {
+	src       string
+	pos       int
+	row       int
+	lineStart int
+}
+
+// tokenize returns all significant tokens, comments included
+func tokenize(src string) []token {
+	l := &lexer{src: src}
+	var tokens []token
+	for {
+		l.skipSpace()
+		if l.pos >= len(l.src) {
+			return tokens
+		}
+		tokens = append(tokens, l.next())
+	}
+}
+
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case '\n':
+			l.newline(l.pos)
+		case ' ', '\t', '\r':
+		default:
+			if strings.HasPrefix(l.src[l.pos:], byteOrderMark) {
+				l.pos += len(byteOrderMark)
+				continue
+			}
+			return
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) newline(at int) {
+	l.row++
+	l.lineStart = at + 1
+}
+
+// advance moves one byte forward, keeping track of lines
+func (l *lexer) advance() {
+	if l.src[l.pos] == '\n' {
+		l.newline(l.pos)
+	}
+	l.pos++
+}
+
+// next scans one token starting at the current position
+func (l *lexer) next() token {
+	start := l.pos
+	t := token{row: l.row, col: start - l.lineStart}
+	rest := l.src[start:]
+
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(rest, "//"):
+		end := strings.IndexByte(rest, '\n')
+		if end < 0 {
+			end = len(rest)
+		}
+		l.pos = start + end
+		t.kind = tokenComment
+	case strings.HasPrefix(rest, "/*"):
+		l.blockComment()
+		t.kind = tokenComment
+	case c == '"' || c == '\'':
+		l.str(false)
+		t.kind = tokenString
+	case (c == 'r' || c == 'R') && len(rest) > 1 && (rest[1] == '"' || rest[1] == '\''):
+		l.pos++
+		l.str(true)
+		t.kind = tokenString
+	case isNameStart(c):
+		for l.pos < len(l.src) && isNameContinue(l.src[l.pos]) {
+			l.pos++
+		}
+		t.kind = tokenName
+	case c >= '0' && c <= '9':
+		for l.pos < len(l.src) && (isNameContinue(l.src[l.pos]) || l.src[l.pos] == '.') {
+			l.pos++
+		}
+		t.kind = tokenValue
+	default:
+		l.pos++
+		for _, p := range multiPuncts {
+			if strings.HasPrefix(rest, p) {
+				l.pos = start + len(p)
+				break
+			}
+		}
+		t.kind = tokenPunct
+	}
+
+	t.text = strings.TrimRight(l.src[start:l.pos], " \t\r")
+	t.endRow = l.row
+	t.endCol = start + len(t.text) - l.lineStart
+	if i := strings.LastIndex(t.text, "\n"); i >= 0 {
+		t.endCol = len(t.text) - i - 1
+	}
+	return t
+}
+
+// blockComment skips a /* */ comment; Dart block comments nest
+func (l *lexer) blockComment() {
+	depth := 0
+	for l.pos < len(l.src) {
+		switch {
+		case strings.HasPrefix(l.src[l.pos:], "/*"):
+			depth++
+			l.pos += 2
+		case strings.HasPrefix(l.src[l.pos:], "*/"):
+			depth--
+			l.pos += 2
+			if depth == 0 {
+				return
+			}
+		default:
+			l.advance()
+		}
+	}
+}
+
+// str skips a string literal starting at the current quote.
+// Non-raw strings may contain escapes and ${} interpolations with nested strings.
+func (l *lexer) str(raw bool) {
+	q := l.src[l.pos : l.pos+1]
+	if strings.HasPrefix(l.src[l.pos:], q+q+q) {
+		q = q + q + q
+	}
+	l.pos += len(q)
+
+	for l.pos < len(l.src) {
+		rest := l.src[l.pos:]
+		switch {
+		case strings.HasPrefix(rest, q):
+			l.pos += len(q)
+			return
+		case len(q) == 1 && rest[0] == '\n':
+			// Unterminated single-line string
+			return
+		case !raw && rest[0] == '\\':
+			l.pos++
+			if l.pos < len(l.src) {
+				l.advance()
+			}
+		case !raw && strings.HasPrefix(rest, "${"):
+			l.pos += 2
+			l.interpolation()
+		default:
+			l.advance()
+		}
+	}
+}
+
+// interpolation skips the expression of a ${} interpolation up to its closing brace
+func (l *lexer) interpolation() {
+	depth := 1
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '"' || c == '\'':
+			l.str(false)
+			continue
+		case (c == 'r' || c == 'R') && l.pos+1 < len(l.src) && (l.src[l.pos+1] == '"' || l.src[l.pos+1] == '\''):
+			l.pos++
+			l.str(true)
+			continue
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				l.pos++
+				return
+			}
+		}
+		l.advance()
+	}
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameContinue(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
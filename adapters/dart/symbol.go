@@ -0,0 +1,348 @@
+package dart
+
+// Frame kinds of the binder's scope stack
+const (
+	frameTop   = "top"   // library level
+	frameType  = "type"  // class, mixin or extension body
+	frameEnum  = "enum"  // enum body
+	frameGroup = "group" // ( ), [ ] or { } that declares nothing: bodies, parameter lists, literals
+)
+
+// typeKeywords start a type declaration whose body opens with the next {
+var typeKeywords = map[string]bool{"class": true, "mixin": true, "enum": true, "extension": true}
+
+// directiveKeywords start library directives, which declare no symbol
+var directiveKeywords = map[string]bool{"import": true, "export": true, "library": true, "part": true}
+
+// frame is an open group together with the symbol it belongs to.
+// Declaration frames (top, type, enum) collect the tokens of the current member declaration.
+type frame struct {
+	kind   string
+	symbol string
+
+	stmt       []token // depth-0 tokens of the current declaration; "(" marks a parameter list
+	declared   bool    // the current declaration already produced its symbol
+	enumValues bool    // still inside the value list of an enum
+	endsStmt   bool    // closing this body ends the parent's declaration
+}
+
+// declaration is a named element: a type, member, enum value or top-level function or variable
+type declaration struct {
+	row    int
+	symbol string
+	scope  string
+}
+
+// pendingComment is a comment waiting for the declaration it documents
+type pendingComment struct {
+	tok      token
+	scope    string
+	trailing bool
+	next     int // index of the first declaration after the comment
+}
+
+// binder assigns symbols to comments by following the declaration structure.
+// Symbols are type names ("Counter"), members ("Counter.increment", "Counter.named"),
+// enum values ("Color.red") and top-level names ("main"). Members of an extension
+// are reported under the extended type, so "extension X on String" yields "String.m".
+type binder struct {
+	frames  []*frame
+	decls   []declaration
+	pending []pendingComment
+}
+
+// bind walks the tokens and returns the comments with their symbols
+func bind(tokens []token) ([]pendingComment, []string) {
+	b := &binder{frames: []*frame{{kind: frameTop}}}
+
+	var prev *token
+	for i := range tokens {
+		t := tokens[i]
+		if t.kind == tokenComment {
+			b.pending = append(b.pending, pendingComment{
+				tok:      t,
+				scope:    b.top().symbol,
+				trailing: prev != nil && prev.endRow == t.row,
+				next:     len(b.decls),
+			})
+			continue
+		}
+		b.step(t)
+		prev = &tokens[i]
+	}
+
+	symbols := make([]string, len(b.pending))
+	for i, c := range b.pending {
+		symbols[i] = b.resolve(c)
+	}
+	return b.pending, symbols
+}
+
+// resolve returns the symbol of the declaration a comment documents
+func (b *binder) resolve(c pendingComment) string {
+	if c.trailing {
+		if c.next > 0 && b.decls[c.next-1].row == c.tok.row {
+			return b.decls[c.next-1].symbol
+		}
+		return c.scope
+	}
+	if c.next < len(b.decls) && b.decls[c.next].scope == c.scope {
+		return b.decls[c.next].symbol
+	}
+	return c.scope
+}
+
+func (b *binder) top() *frame {
+	return b.frames[len(b.frames)-1]
+}
+
+func (b *binder) push(f *frame) {
+	b.frames = append(b.frames, f)
+}
+
+func (b *binder) declare(row int, symbol string) {
+	b.decls = append(b.decls, declaration{row: row, symbol: symbol, scope: b.top().symbol})
+}
+
+// member joins a scope and a member name
+func member(scope, name string) string {
+	if scope == "" || name == "" {
+		return scope + name
+	}
+	return scope + "." + name
+}
+
+// step processes a single non-comment token
+func (b *binder) step(t token) {
+	f := b.top()
+	if f.kind == frameGroup {
+		switch t.text {
+		case "(", "[", "{":
+			b.push(&frame{kind: frameGroup, symbol: f.symbol})
+		case ")", "]", "}":
+			b.pop()
+		}
+		return
+	}
+
+	if t.kind != tokenPunct {
+		f.stmt = append(f.stmt, t)
+		return
+	}
+
+	switch t.text {
+	case "(", "[":
+		f.stmt = append(f.stmt, t)
+		b.push(&frame{kind: frameGroup, symbol: f.symbol})
+	case "{":
+		b.openBody(f, t)
+	case "}":
+		if f.enumValues {
+			b.enumValue(f)
+		}
+		b.pop()
+	case "=>":
+		if !f.declared && !f.enumValues {
+			b.declare(t.row, member(f.symbol, memberName(f.stmt)))
+			f.declared = true
+		}
+	case ";":
+		switch {
+		case f.enumValues:
+			b.enumValue(f)
+			f.enumValues = false
+		case !f.declared:
+			b.endDeclaration(f, t.row)
+		}
+		f.reset()
+	case ",":
+		if f.enumValues {
+			b.enumValue(f)
+			f.reset()
+			return
+		}
+		f.stmt = append(f.stmt, t)
+	default:
+		f.stmt = append(f.stmt, t)
+	}
+}
+
+// openBody handles a { in a declaration frame: a type body, a member body or a literal
+func (b *binder) openBody(f *frame, t token) {
+	if !f.declared && !f.enumValues {
+		if kind, name, ok := typeDeclaration(f.stmt); ok {
+			b.declare(t.row, member(f.symbol, name))
+			f.reset()
+			b.push(&frame{kind: kind, symbol: member(f.symbol, name), enumValues: kind == frameEnum})
+			return
+		}
+		b.declare(t.row, member(f.symbol, memberName(f.stmt)))
+		f.declared = true
+	}
+
+	// A function body ends its declaration; a closure or literal after = does not
+	sym := f.symbol
+	if f.declared && len(b.decls) > 0 {
+		sym = b.decls[len(b.decls)-1].symbol
+	}
+	b.push(&frame{kind: frameGroup, symbol: sym, endsStmt: !hasAssignment(f.stmt)})
+}
+
+// endDeclaration declares the member or type ending with ; (fields, abstract methods, typedefs)
+func (b *binder) endDeclaration(f *frame, row int) {
+	if len(f.stmt) == 0 || directiveKeywords[f.stmt[0].text] {
+		return
+	}
+	if _, name, ok := typeDeclaration(f.stmt); ok {
+		b.declare(row, member(f.symbol, name))
+		return
+	}
+	b.declare(row, member(f.symbol, memberName(f.stmt)))
+}
+
+// enumValue declares the enum value collected in the current segment
+func (b *binder) enumValue(f *frame) {
+	stmt := skipAnnotations(f.stmt)
+	if len(stmt) == 0 || stmt[0].kind != tokenName {
+		return
+	}
+	last := f.stmt[len(f.stmt)-1]
+	b.declare(last.endRow, member(f.symbol, stmt[0].text))
+}
+
+// pop closes the innermost frame
+func (b *binder) pop() {
+	if len(b.frames) == 1 {
+		return
+	}
+	closed := b.top()
+	b.frames = b.frames[:len(b.frames)-1]
+	if closed.endsStmt || closed.kind != frameGroup {
+		if parent := b.top(); parent.kind != frameGroup {
+			parent.reset()
+		}
+	}
+}
+
+func (f *frame) reset() {
+	f.stmt = nil
+	f.declared = false
+}
+
+// typeDeclaration recognizes "class Name", "mixin Name", "enum Name", "extension X on Type"
+// and "extension type Name(...)", returning the frame kind and name of the body
+func typeDeclaration(stmt []token) (string, string, bool) {
+	for i, t := range stmt {
+		if t.kind != tokenName || !typeKeywords[t.text] || (i > 0 && stmt[i-1].text == ".") {
+			continue
+		}
+		rest := stmt[i+1:]
+		if t.text == "mixin" && len(rest) > 0 && rest[0].text == "class" {
+			rest = rest[1:]
+		}
+		if t.text == "extension" {
+			for j, r := range rest {
+				if r.text == "on" && j+1 < len(rest) {
+					return frameType, rest[j+1].text, true
+				}
+			}
+			if len(rest) > 0 && rest[0].text == "type" {
+				rest = rest[1:]
+			}
+		}
+		if len(rest) == 0 || rest[0].kind != tokenName {
+			return "", "", false
+		}
+		if t.text == "enum" {
+			return frameEnum, rest[0].text, true
+		}
+		return frameType, rest[0].text, true
+	}
+	return "", "", false
+}
+
+// memberName returns the name a member declaration introduces:
+// the name before the parameter list for methods and constructors, the name after
+// get/set for getters, and the first declared variable for fields.
+func memberName(stmt []token) string {
+	stmt = skipAnnotations(stmt)
+
+	paren := -1
+	for i, t := range stmt {
+		if t.text == "(" {
+			paren = i
+			break
+		}
+		if t.text == "=" {
+			// Field with an initializer: the name precedes =
+			return nameBefore(stmt, i)
+		}
+	}
+	if paren >= 0 {
+		i := paren - 1
+		if i >= 0 && stmt[i].text == ">" {
+			// Generic method: skip the type parameters
+			depth := 0
+			for ; i >= 0; i-- {
+				if stmt[i].text == ">" {
+					depth++
+				} else if stmt[i].text == "<" {
+					depth--
+					if depth == 0 {
+						i--
+						break
+					}
+				}
+			}
+		}
+		if i >= 1 && stmt[i].kind == tokenPunct && stmt[i-1].text == "operator" {
+			return "operator " + stmt[i].text
+		}
+		if i >= 0 && stmt[i].kind == tokenName {
+			return stmt[i].text
+		}
+		return ""
+	}
+
+	for i, t := range stmt {
+		if (t.text == "get" || t.text == "set") && i+1 < len(stmt) && stmt[i+1].kind == tokenName {
+			return stmt[i+1].text
+		}
+		if t.text == "," {
+			return nameBefore(stmt, i)
+		}
+	}
+	return nameBefore(stmt, len(stmt))
+}
+
+// nameBefore returns the name token directly before index i
+func nameBefore(stmt []token, i int) string {
+	if i > 0 && stmt[i-1].kind == tokenName {
+		return stmt[i-1].text
+	}
+	return ""
+}
+
+// hasAssignment reports whether the declaration has an initializer
+func hasAssignment(stmt []token) bool {
+	for _, t := range stmt {
+		if t.text == "=" {
+			return true
+		}
+	}
+	return false
+}
+
+// skipAnnotations drops leading metadata such as @override or @Deprecated('...')
+func skipAnnotations(stmt []token) []token {
+	for len(stmt) > 1 && stmt[0].text == "@" {
+		stmt = stmt[2:]
+		for len(stmt) > 1 && stmt[0].text == "." {
+			stmt = stmt[2:]
+		}
+		if len(stmt) > 0 && stmt[0].text == "(" {
+			stmt = stmt[1:]
+		}
+	}
+	return stmt
+}
@@ -0,0 +1,159 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// Adapter implements the LanguageAdapter interface for a language defined by a Descriptor.
+// Comments are the nodes captured as @comment or @doc by the descriptor's query.
+type Adapter struct {
+	desc     *Descriptor
+	language *sitter.Language
+}
+
+// NewAdapter creates an adapter for the descriptor, validating its query against the grammar
+func NewAdapter(d *Descriptor) (*Adapter, error) {
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+	lang := grammars[d.Grammar]()
+
+	q, err := sitter.NewQuery([]byte(d.Query), lang)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query for %s: %w", d.Language, err)
+	}
+	q.Close()
+
+	return &Adapter{desc: d, language: lang}, nil
+}
+
+// Language returns the language identifier from the descriptor
+func (a *Adapter) Language() string {
+	return a.desc.Language
+}
+
+// Descriptor returns the descriptor the adapter was built from
+func (a *Adapter) Descriptor() *Descriptor {
+	return a.desc
+}
+
+// Parse parses the provided source code and extracts comments.
+// If src is nil, the source code is read from the file path.
+func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	if src == nil {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src = data
+	}
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(a.language)
+	tree, err := parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	return a.extractComments(tree.RootNode(), src, file)
+}
+
+// captured is a comment node together with its capture name
+type captured struct {
+	node *sitter.Node
+	doc  bool
+}
+
+// extractComments runs the descriptor query and builds domain comments
+func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string) ([]*domain.Comment, error) {
+	q, err := sitter.NewQuery([]byte(a.desc.Query), a.language)
+	if err != nil {
+		return nil, err
+	}
+	defer q.Close()
+
+	qc := sitter.NewQueryCursor()
+	defer qc.Close()
+	qc.Exec(q, root)
+
+	var nodes []captured
+	seen := make(map[[2]uint32]bool)
+	commentTypes := make(map[string]bool)
+	for _, t := range a.desc.CommentNodes {
+		commentTypes[t] = true
+	}
+	for {
+		m, ok := qc.NextMatch()
+		if !ok {
+			break
+		}
+		for _, c := range m.Captures {
+			name := q.CaptureNameForId(c.Index)
+			if name != "comment" && name != "doc" {
+				continue
+			}
+			key := [2]uint32{c.Node.StartByte(), c.Node.EndByte()}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			commentTypes[c.Node.Type()] = true
+			nodes = append(nodes, captured{node: c.Node, doc: name == "doc"})
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].node.StartByte() < nodes[j].node.StartByte()
+	})
+
+	var comments []*domain.Comment
+	for _, c := range nodes {
+		text := strings.TrimRight(c.node.Content(src), " \t\r\n")
+		cType := a.desc.Markers.Classify(text)
+		if c.doc {
+			cType = domain.CommentTypeDoc
+		}
+		if strings.TrimSpace(a.desc.Markers.Strip(text)) == "" {
+			continue
+		}
+
+		start := c.node.StartPoint()
+		endCol := int(start.Column) + len(text)
+		if i := strings.LastIndex(text, "\n"); i >= 0 {
+			endCol = len(text) - i - 1
+		}
+
+		comment := &domain.Comment{
+			File:     file,
+			Language: a.desc.Language,
+			Symbol:   a.resolveSymbol(c.node, src, commentTypes),
+			Range: domain.TextRange{
+				StartLine: int(start.Row) + 1,
+				StartCol:  int(start.Column) + 1,
+				EndLine:   int(start.Row) + strings.Count(text, "\n") + 1,
+				EndCol:    endCol + 1,
+			},
+			SourceText: text,
+			Type:       cType,
+		}
+		comment.ID = utils.GenerateCommentID(comment)
+
+		comments = append(comments, comment)
+	}
+
+	return comments, nil
+}
+
+// FormatComment wraps translated text in the markers of the original comment
+func (a *Adapter) FormatComment(c *domain.Comment, text string) (string, bool) {
+	return a.desc.Markers.Format(c, text), true
+}
@@ -0,0 +1,126 @@
+package declarative
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+const terraformDescriptor = `{
+  "grammar": "hcl",
+  "extensions": [".tf", ".hcl"],
+  "symbols": [
+    {"node": "block", "nameChildren": ["identifier", "string_lit"]},
+    {"node": "attribute", "nameChildren": ["identifier"]}
+  ],
+  "markers": {
+    "line": ["#", "//"],
+    "block": [{"start": "/*", "end": "*/"}]
+  }
+}`
+
+const terraformQuery = `(comment) @comment
+`
+
+const mainTF = `# Provider config
+provider "aws" {
+  region = "us-east-1" # trailing
+}
+
+/* The bucket */
+resource "aws_s3_bucket" "logs" {
+  // Bucket name
+  bucket = "logs"
+}
+#
+`
+
+func writeLanguage(t *testing.T, dir, name, descriptor, query string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".json"), []byte(descriptor), 0644))
+	if query != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+".scm"), []byte(query), 0644))
+	}
+}
+
+func TestAdapter_Parse(t *testing.T) {
+	dir := t.TempDir()
+	writeLanguage(t, dir, "terraform", terraformDescriptor, terraformQuery)
+
+	descriptors, err := LoadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, descriptors, 1)
+	assert.Equal(t, "terraform", descriptors[0].Language)
+
+	adapter, err := NewAdapter(descriptors[0])
+	require.NoError(t, err)
+	assert.Equal(t, "terraform", adapter.Language())
+
+	comments, err := adapter.Parse("main.tf", []byte(mainTF))
+	require.NoError(t, err)
+	require.Len(t, comments, 4)
+
+	assert.Equal(t, "# Provider config", comments[0].SourceText)
+	assert.Equal(t, "provider.aws", comments[0].Symbol)
+	assert.Equal(t, domain.CommentTypeLine, comments[0].Type)
+
+	assert.Equal(t, "# trailing", comments[1].SourceText)
+	assert.Equal(t, "provider.aws.region", comments[1].Symbol)
+
+	assert.Equal(t, "/* The bucket */", comments[2].SourceText)
+	assert.Equal(t, "resource.aws_s3_bucket.logs", comments[2].Symbol)
+	assert.Equal(t, domain.CommentTypeBlock, comments[2].Type)
+
+	assert.Equal(t, "resource.aws_s3_bucket.logs.bucket", comments[3].Symbol)
+	assert.Equal(t, 8, comments[3].Range.StartLine)
+	assert.Equal(t, 3, comments[3].Range.StartCol)
+	assert.NotEmpty(t, comments[3].ID)
+}
+
+func TestAdapter_DocCaptureAndFormat(t *testing.T) {
+	d := &Descriptor{
+		Language:     "elixir",
+		Grammar:      "elixir",
+		CommentNodes: []string{"comment"},
+		Markers:      Markers{Line: []string{"#"}, Doc: []string{"##"}},
+	}
+	adapter, err := NewAdapter(d)
+	require.NoError(t, err)
+
+	comments, err := adapter.Parse("lib/a.ex", []byte("## Module docs\n# helper\ndefmodule A do\nend\n"))
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, domain.CommentTypeDoc, comments[0].Type)
+	assert.Equal(t, domain.CommentTypeLine, comments[1].Type)
+
+	formatted, ok := adapter.FormatComment(comments[0], "模块文档")
+	assert.True(t, ok)
+	assert.Equal(t, "## 模块文档", formatted)
+	assert.Equal(t, "# 第一行\n# 第二行", d.Markers.Format(comments[1], "第一行\n第二行"))
+	assert.Equal(t, "# 已有标记", d.Markers.Format(comments[1], "# 已有标记"))
+}
+
+func TestLoadDir_Errors(t *testing.T) {
+	dir := t.TempDir()
+	writeLanguage(t, dir, "bad", `{"grammar": "brainfuck", "commentNodes": ["comment"]}`, "")
+	writeLanguage(t, dir, "noquery", `{"grammar": "hcl"}`, "")
+	writeLanguage(t, dir, "terraform", terraformDescriptor, terraformQuery)
+
+	descriptors, err := LoadDir(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown grammar "brainfuck"`)
+	assert.Contains(t, err.Error(), "commentNodes is required")
+	require.Len(t, descriptors, 1)
+
+	_, err = NewAdapter(&Descriptor{Language: "x", Grammar: "hcl", Query: "(no_such_node) @comment"})
+	assert.ErrorContains(t, err, "invalid query")
+
+	missing, err := LoadDir(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
@@ -0,0 +1,132 @@
+package declarative
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Descriptor configures a query-driven language. It is read from
+// .codei18n/languages/<lang>.json, with the comment query in <lang>.scm next to it.
+type Descriptor struct {
+	// Language is the language identifier; defaults to the descriptor file name
+	Language string `json:"language"`
+	// Grammar names a compiled-in Tree-sitter grammar, see Grammars
+	Grammar    string   `json:"grammar"`
+	Extensions []string `json:"extensions"`
+	// FileNames are case-insensitive base name globs such as "jenkinsfile"
+	FileNames []string `json:"fileNames,omitempty"`
+	// CommentNodes are the comment node types, used to build the query when there is no .scm file
+	CommentNodes []string `json:"commentNodes,omitempty"`
+	// Symbols lists the node types that contribute a segment to comment symbols
+	Symbols []SymbolRule `json:"symbols,omitempty"`
+	// Separator joins symbol segments; defaults to "."
+	Separator string  `json:"separator,omitempty"`
+	Markers   Markers `json:"markers"`
+
+	// Query is the Tree-sitter query capturing @comment (and optionally @doc) nodes
+	Query string `json:"-"`
+}
+
+// SymbolRule names the declarations of one node type. The name is taken from
+// the NameField child, else from the concatenated NameChildren node types, else Name.
+type SymbolRule struct {
+	Node         string   `json:"node"`
+	NameField    string   `json:"nameField,omitempty"`
+	NameChildren []string `json:"nameChildren,omitempty"`
+	Name         string   `json:"name,omitempty"`
+}
+
+// Markers describes the comment syntax of the language
+type Markers struct {
+	Line  []string      `json:"line,omitempty"`  // e.g. "#", "//"
+	Doc   []string      `json:"doc,omitempty"`   // e.g. "///", "##"
+	Block []BlockMarker `json:"block,omitempty"` // e.g. {"start": "/*", "end": "*/"}
+}
+
+// BlockMarker is a pair of block comment delimiters
+type BlockMarker struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// LoadDir reads every <lang>.json descriptor in dir together with its optional <lang>.scm query.
+// A missing directory yields no descriptors.
+func LoadDir(dir string) ([]*Descriptor, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var descriptors []*Descriptor
+	var errs []error
+	for _, file := range files {
+		d, err := LoadDescriptor(file)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		descriptors = append(descriptors, d)
+	}
+	return descriptors, errors.Join(errs...)
+}
+
+// LoadDescriptor reads a single descriptor file and the .scm query with the same base name
+func LoadDescriptor(path string) (*Descriptor, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var d Descriptor
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	if d.Language == "" {
+		d.Language = filepath.Base(base)
+	}
+	query, err := os.ReadFile(base + ".scm")
+	switch {
+	case err == nil:
+		d.Query = string(query)
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+
+	if err := d.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &d, nil
+}
+
+// validate checks the descriptor and fills in defaults
+func (d *Descriptor) validate() error {
+	if _, ok := grammars[d.Grammar]; !ok {
+		return fmt.Errorf("unknown grammar %q (available: %s)", d.Grammar, strings.Join(Grammars(), ", "))
+	}
+	if d.Query == "" {
+		if len(d.CommentNodes) == 0 {
+			return errors.New("either a .scm query or commentNodes is required")
+		}
+		var b strings.Builder
+		for _, node := range d.CommentNodes {
+			fmt.Fprintf(&b, "(%s) @comment\n", node)
+		}
+		d.Query = b.String()
+	}
+	if d.Separator == "" {
+		d.Separator = "."
+	}
+	for _, r := range d.Symbols {
+		if r.Node == "" {
+			return errors.New("symbol rule without node type")
+		}
+	}
+	return nil
+}
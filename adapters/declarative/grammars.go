@@ -0,0 +1,77 @@
+package declarative
+
+import (
+	"sort"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/bash"
+	"github.com/smacker/go-tree-sitter/c"
+	"github.com/smacker/go-tree-sitter/cpp"
+	"github.com/smacker/go-tree-sitter/csharp"
+	"github.com/smacker/go-tree-sitter/css"
+	"github.com/smacker/go-tree-sitter/dockerfile"
+	"github.com/smacker/go-tree-sitter/elixir"
+	"github.com/smacker/go-tree-sitter/golang"
+	"github.com/smacker/go-tree-sitter/groovy"
+	"github.com/smacker/go-tree-sitter/hcl"
+	"github.com/smacker/go-tree-sitter/html"
+	"github.com/smacker/go-tree-sitter/java"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/kotlin"
+	"github.com/smacker/go-tree-sitter/lua"
+	"github.com/smacker/go-tree-sitter/php"
+	"github.com/smacker/go-tree-sitter/protobuf"
+	"github.com/smacker/go-tree-sitter/python"
+	"github.com/smacker/go-tree-sitter/ruby"
+	"github.com/smacker/go-tree-sitter/rust"
+	"github.com/smacker/go-tree-sitter/scala"
+	"github.com/smacker/go-tree-sitter/sql"
+	"github.com/smacker/go-tree-sitter/svelte"
+	"github.com/smacker/go-tree-sitter/swift"
+	"github.com/smacker/go-tree-sitter/toml"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+	"github.com/smacker/go-tree-sitter/yaml"
+)
+
+// grammars lists the Tree-sitter grammars compiled into codei18n that descriptors may reference
+var grammars = map[string]func() *sitter.Language{
+	"bash":       bash.GetLanguage,
+	"c":          c.GetLanguage,
+	"cpp":        cpp.GetLanguage,
+	"csharp":     csharp.GetLanguage,
+	"css":        css.GetLanguage,
+	"dockerfile": dockerfile.GetLanguage,
+	"elixir":     elixir.GetLanguage,
+	"go":         golang.GetLanguage,
+	"groovy":     groovy.GetLanguage,
+	"hcl":        hcl.GetLanguage,
+	"html":       html.GetLanguage,
+	"java":       java.GetLanguage,
+	"javascript": javascript.GetLanguage,
+	"kotlin":     kotlin.GetLanguage,
+	"lua":        lua.GetLanguage,
+	"php":        php.GetLanguage,
+	"protobuf":   protobuf.GetLanguage,
+	"python":     python.GetLanguage,
+	"ruby":       ruby.GetLanguage,
+	"rust":       rust.GetLanguage,
+	"scala":      scala.GetLanguage,
+	"sql":        sql.GetLanguage,
+	"svelte":     svelte.GetLanguage,
+	"swift":      swift.GetLanguage,
+	"toml":       toml.GetLanguage,
+	"tsx":        tsx.GetLanguage,
+	"typescript": typescript.GetLanguage,
+	"yaml":       yaml.GetLanguage,
+}
+
+// Grammars returns the names of the grammars available to descriptors, sorted
+func Grammars() []string {
+	names := make([]string, 0, len(grammars))
+	for name := range grammars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,70 @@
+package declarative
+
+import (
+	"strings"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+// Classify determines the comment type of text: doc markers first, then block markers, else line
+func (m Markers) Classify(text string) domain.CommentType {
+	if longestPrefix(text, m.Doc) != "" {
+		return domain.CommentTypeDoc
+	}
+	for _, b := range m.Block {
+		if strings.HasPrefix(text, b.Start) {
+			return domain.CommentTypeBlock
+		}
+	}
+	return domain.CommentTypeLine
+}
+
+// Strip removes the comment delimiters from text
+func (m Markers) Strip(text string) string {
+	for _, b := range m.Block {
+		if strings.HasPrefix(text, b.Start) {
+			return strings.TrimSuffix(strings.TrimPrefix(text, b.Start), b.End)
+		}
+	}
+	return strings.TrimPrefix(text, longestPrefix(text, m.lineMarkers()))
+}
+
+// Format wraps translated text in the markers of the original comment c.
+// Text that already carries the marker is returned unchanged.
+func (m Markers) Format(c *domain.Comment, text string) string {
+	for _, b := range m.Block {
+		if strings.HasPrefix(c.SourceText, b.Start) {
+			if strings.HasPrefix(text, b.Start) {
+				return text
+			}
+			return b.Start + " " + strings.ReplaceAll(text, b.End, "") + " " + b.End
+		}
+	}
+
+	marker := longestPrefix(c.SourceText, m.lineMarkers())
+	if marker == "" || strings.HasPrefix(text, marker) {
+		return text
+	}
+	// Each line of a multi-line translation needs its own marker
+	lines := strings.Split(text, "\n")
+	for i, l := range lines {
+		lines[i] = marker + " " + l
+	}
+	return strings.Join(lines, "\n")
+}
+
+// lineMarkers returns the doc and line markers together
+func (m Markers) lineMarkers() []string {
+	return append(append([]string{}, m.Doc...), m.Line...)
+}
+
+// longestPrefix returns the longest marker text starts with, or ""
+func longestPrefix(text string, markers []string) string {
+	best := ""
+	for _, m := range markers {
+		if m != "" && strings.HasPrefix(text, m) && len(m) > len(best) {
+			best = m
+		}
+	}
+	return best
+}
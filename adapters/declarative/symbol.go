@@ -0,0 +1,99 @@
+package declarative
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// resolveSymbol returns the symbol a comment documents, joining the names of
+// the declarations enclosing its owner node
+func (a *Adapter) resolveSymbol(node *sitter.Node, src []byte, commentTypes map[string]bool) string {
+	owner := findOwnerNode(node, commentTypes)
+	if owner == nil {
+		return ""
+	}
+
+	var parts []string
+	for n := owner; n != nil; n = n.Parent() {
+		if name := a.declarationName(n, src); name != "" {
+			parts = append([]string{name}, parts...)
+		}
+	}
+	return strings.Join(parts, a.desc.Separator)
+}
+
+// findOwnerNode finds the innermost node a comment documents.
+// Trailing comments bind to the code on the same line, leading comments to the next
+// non-comment sibling, and comments at the end of a block to the enclosing node.
+// Grammars often wrap declarations in container nodes, so the owner descends into
+// the children that share the comment's anchor line.
+func findOwnerNode(node *sitter.Node, commentTypes map[string]bool) *sitter.Node {
+	row := node.StartPoint().Row
+
+	if prev := node.PrevNamedSibling(); prev != nil && !commentTypes[prev.Type()] && prev.EndPoint().Row == row {
+		// Descend along the last children that are still on the comment's line
+		n := prev
+		for n.NamedChildCount() > 0 {
+			child := n.NamedChild(int(n.NamedChildCount()) - 1)
+			if child.StartPoint().Row != row && child.EndPoint().Row != row {
+				break
+			}
+			n = child
+		}
+		return n
+	}
+
+	next := node.NextNamedSibling()
+	for next != nil && commentTypes[next.Type()] {
+		next = next.NextNamedSibling()
+	}
+	if next == nil {
+		return node.Parent()
+	}
+	// Descend along the first children that start where the sibling starts
+	n := next
+	for n.NamedChildCount() > 0 && n.NamedChild(0).StartPoint() == n.StartPoint() {
+		n = n.NamedChild(0)
+	}
+	return n
+}
+
+// declarationName returns the name n declares according to the symbol rules, or ""
+func (a *Adapter) declarationName(n *sitter.Node, src []byte) string {
+	for _, r := range a.desc.Symbols {
+		if r.Node != n.Type() {
+			continue
+		}
+		if r.NameField != "" {
+			if name := n.ChildByFieldName(r.NameField); name != nil {
+				return unquote(name.Content(src))
+			}
+		}
+		if len(r.NameChildren) > 0 {
+			var parts []string
+			for i := 0; i < int(n.NamedChildCount()); i++ {
+				child := n.NamedChild(i)
+				for _, t := range r.NameChildren {
+					if child.Type() == t {
+						parts = append(parts, unquote(child.Content(src)))
+						break
+					}
+				}
+			}
+			if len(parts) > 0 {
+				return strings.Join(parts, a.desc.Separator)
+			}
+		}
+		return r.Name
+	}
+	return ""
+}
+
+// unquote strips the quotes of string literal names such as "aws_s3_bucket"
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'' || s[0] == '`') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+package adapters
+
+import (
+	"github.com/studyzy/codei18n/core"
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// withDirectives wraps adapter so that the tool directives it reports, such as
+// "// eslint-disable-next-line" or "# noqa", are classified as directives. Directives
+// specific to one language are classified by its adapter.
+func withDirectives(adapter core.LanguageAdapter) core.LanguageAdapter {
+	return &directiveAdapter{LanguageAdapter: adapter}
+}
+
+// directiveAdapter classifies the directives among the comments of the wrapped adapter
+type directiveAdapter struct {
+	core.LanguageAdapter
+}
+
+func (d *directiveAdapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	comments, err := d.LanguageAdapter.Parse(file, src)
+	for _, c := range comments {
+		if utils.IsDirective(c.SourceText) {
+			c.Type = domain.CommentTypeDirective
+		}
+	}
+	return comments, err
+}
+
+// FormatComment forwards to the wrapped adapter so that convert keeps its markers
+func (d *directiveAdapter) FormatComment(c *domain.Comment, text string) (string, bool) {
+	if f, ok := d.LanguageAdapter.(core.CommentFormatter); ok {
+		return f.FormatComment(c, text)
+	}
+	return "", false
+}
@@ -0,0 +1,41 @@
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core"
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestDirectivesClassifiedForEveryLanguage(t *testing.T) {
+	sources := map[string]string{
+		"p.go":   "package p\n\n//go:generate stringer\n// Adds numbers\nfunc Add() int {\n\treturn 1 //nolint:gosec\n}\n",
+		"a.py":   "# -*- coding: utf-8 -*-\n# Adds numbers\nimport os  # noqa: F401\nx = []  # type: List[int]\n",
+		"a.rb":   "# frozen_string_literal: true\n# encoding: utf-8\n# Adds numbers\nX = 1\n",
+		"a.ts":   "// Adds numbers\n// eslint-disable-next-line no-console\nconsole.log(1)\n",
+		"a.cpp":  "// Adds numbers\nint x; // NOLINT(bugprone-branch-clone)\n",
+		"a.yaml": "# Adds numbers\n# yaml-language-server: $schema=./schema.json\nkey: value\n",
+	}
+	for file, src := range sources {
+		adapter, err := GetAdapter(file)
+		require.NoError(t, err, file)
+		_, ok := adapter.(core.CommentFormatter)
+		assert.True(t, ok, "the directive wrapper must keep the formatter hook")
+
+		comments, err := adapter.Parse(file, []byte(src))
+		require.NoError(t, err, file)
+		var prose, directives int
+		for _, c := range comments {
+			if c.Type == domain.CommentTypeDirective {
+				directives++
+			} else {
+				prose++
+			}
+		}
+		assert.Equal(t, 1, prose, file)
+		assert.GreaterOrEqual(t, directives, 1, file)
+	}
+}
@@ -0,0 +1,235 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/studyzy/codei18n/core/config"
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// defaultTimeout bounds a single invocation of the adapter executable
+const defaultTimeout = 30 * time.Second
+
+// Adapter implements the LanguageAdapter interface by running an external executable.
+// The language and extensions come from the configuration, so registering the adapter
+// never runs it; the handshake runs once, on the first parse, and fixes the protocol
+// version and capabilities.
+type Adapter struct {
+	cfg config.ExternalAdapter
+
+	once      sync.Once
+	handshake *HandshakeResponse
+	err       error
+}
+
+// NewAdapter creates an adapter for the configured executable
+func NewAdapter(cfg config.ExternalAdapter) (*Adapter, error) {
+	if cfg.Command == "" {
+		return nil, errors.New("external adapter: command is required")
+	}
+	if cfg.Language == "" || len(cfg.Extensions) == 0 {
+		return nil, fmt.Errorf("external adapter %s: language and extensions are required", cfg.Command)
+	}
+	return &Adapter{cfg: cfg}, nil
+}
+
+// Handshake negotiates the protocol version and capabilities with the executable
+func (a *Adapter) Handshake() (*HandshakeResponse, error) {
+	a.once.Do(func() {
+		a.handshake, a.err = a.doHandshake()
+	})
+	return a.handshake, a.err
+}
+
+func (a *Adapter) doHandshake() (*HandshakeResponse, error) {
+	var resp HandshakeResponse
+	req := Request{ProtocolVersion: ProtocolVersion, Method: MethodHandshake, Capabilities: hostCapabilities}
+	if err := a.call(req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, a.errorf("handshake rejected: %s", resp.Error)
+	}
+	if resp.ProtocolVersion < MinProtocolVersion || resp.ProtocolVersion > ProtocolVersion {
+		return nil, a.errorf("unsupported protocol version %d (supported %d-%d)",
+			resp.ProtocolVersion, MinProtocolVersion, ProtocolVersion)
+	}
+
+	// Keep only capabilities both sides support
+	var negotiated []string
+	for _, c := range resp.Capabilities {
+		if slices.Contains(hostCapabilities, c) {
+			negotiated = append(negotiated, c)
+		}
+	}
+	resp.Capabilities = negotiated
+	if !slices.Contains(negotiated, CapabilityParse) {
+		return nil, a.errorf("adapter does not support %q", CapabilityParse)
+	}
+	if !slices.Contains(negotiated, CapabilityMarkers) {
+		resp.Markers = nil
+	}
+	return &resp, nil
+}
+
+// Language returns the configured language
+func (a *Adapter) Language() string {
+	return a.cfg.Language
+}
+
+// Extensions returns the configured file extensions
+func (a *Adapter) Extensions() []string {
+	return a.cfg.Extensions
+}
+
+// Parse sends the file to the executable and validates the returned comments.
+// If src is nil, the source code is read from the file path.
+func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	if src == nil {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src = data
+	}
+	hs, err := a.Handshake()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ParseResponse
+	req := Request{ProtocolVersion: hs.ProtocolVersion, Method: MethodParse, File: file, Source: string(src)}
+	if err := a.call(req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, a.errorf("parse %s: %s", file, resp.Error)
+	}
+
+	language := a.Language()
+	starts := lineStarts(src)
+	for _, c := range resp.Comments {
+		if c == nil {
+			return nil, a.errorf("parse %s: null comment", file)
+		}
+		text, err := rangeText(src, starts, c.Range)
+		if err != nil {
+			return nil, a.errorf("parse %s: comment %q: %v", file, c.SourceText, err)
+		}
+		if text != c.SourceText {
+			return nil, a.errorf("parse %s: comment %q: range covers %q", file, c.SourceText, text)
+		}
+		c.File = file
+		c.Language = language
+		if c.Type == "" {
+			c.Type = domain.CommentTypeLine
+		}
+		c.ID = utils.GenerateCommentID(c)
+	}
+	return resp.Comments, nil
+}
+
+// FormatComment wraps translated text using the markers reported in the handshake.
+// Adapters without the markers capability fall back to the built-in rules.
+func (a *Adapter) FormatComment(c *domain.Comment, text string) (string, bool) {
+	hs, err := a.Handshake()
+	if err != nil || hs.Markers == nil {
+		return "", false
+	}
+	return hs.Markers.Format(c, text), true
+}
+
+// call runs the executable with req on stdin and decodes its stdout into resp
+func (a *Adapter) call(req Request, resp interface{}) error {
+	timeout := defaultTimeout
+	if a.cfg.Timeout > 0 {
+		timeout = time.Duration(a.cfg.Timeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	input, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, a.cfg.Command, a.cfg.Args...)
+	cmd.Env = append(os.Environ(), fmt.Sprintf("CODEI18N_PROTOCOL_VERSION=%d", ProtocolVersion))
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return a.errorf("%s timed out after %s", req.Method, timeout)
+		}
+		return a.errorf("%s failed: %v: %s", req.Method, err, strings.TrimSpace(stderr.String()))
+	}
+	if err := json.Unmarshal(stdout.Bytes(), resp); err != nil {
+		return a.errorf("%s returned invalid JSON: %v", req.Method, err)
+	}
+	return nil
+}
+
+func (a *Adapter) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("external adapter %s: %s", a.cfg.Command, fmt.Sprintf(format, args...))
+}
+
+// lineStarts returns the byte offset at which each line of src starts
+func lineStarts(src []byte) []int {
+	starts := []int{0}
+	for i, b := range src {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+// rangeText checks that a range returned by the adapter is 1-based, ordered and within
+// src, and returns the text it covers. Columns are byte offsets and EndCol is exclusive,
+// as convert splices the translation in at these offsets.
+func rangeText(src []byte, starts []int, r domain.TextRange) (string, error) {
+	if r.StartLine < 1 || r.StartCol < 1 || r.EndLine < 1 || r.EndCol < 1 {
+		return "", errors.New("range must be 1-based")
+	}
+	if r.EndLine < r.StartLine || (r.EndLine == r.StartLine && r.EndCol < r.StartCol) {
+		return "", errors.New("range ends before it starts")
+	}
+	if r.EndLine > len(starts) {
+		return "", fmt.Errorf("range ends on line %d, past the last line %d", r.EndLine, len(starts))
+	}
+
+	offset := func(line, col int) (int, error) {
+		lineEnd := len(src)
+		if line < len(starts) {
+			lineEnd = starts[line] - 1
+		}
+		at := starts[line-1] + col - 1
+		if at > lineEnd {
+			return 0, fmt.Errorf("column %d is past the end of line %d", col, line)
+		}
+		return at, nil
+	}
+	start, err := offset(r.StartLine, r.StartCol)
+	if err != nil {
+		return "", err
+	}
+	end, err := offset(r.EndLine, r.EndCol)
+	if err != nil {
+		return "", err
+	}
+	return string(src[start:end]), nil
+}
@@ -0,0 +1,162 @@
+package external
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/adapters/declarative"
+	"github.com/studyzy/codei18n/core/config"
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+// helperModeEnv selects the behaviour of the fake adapter process
+const helperModeEnv = "CODEI18N_TEST_HELPER_MODE"
+
+// TestHelperProcess is not a real test: it is the fake adapter executable run by the tests below
+func TestHelperProcess(t *testing.T) {
+	mode := os.Getenv(helperModeEnv)
+	if mode == "" {
+		return
+	}
+	defer os.Exit(0)
+
+	var req Request
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+		os.Exit(2)
+	}
+	out := json.NewEncoder(os.Stdout)
+
+	if req.Method == MethodHandshake {
+		resp := HandshakeResponse{
+			ProtocolVersion: 1,
+			Name:            "fake",
+			Capabilities:    []string{CapabilityParse, CapabilityMarkers, "semanticTokens"},
+		}
+		resp.Markers = &declarative.Markers{Line: []string{";"}}
+		switch mode {
+		case "future":
+			resp.ProtocolVersion = ProtocolVersion + 1
+		case "noparse":
+			resp.Capabilities = []string{CapabilityMarkers}
+		}
+		out.Encode(resp)
+		return
+	}
+
+	switch mode {
+	case "crash":
+		os.Stderr.WriteString("boom")
+		os.Exit(3)
+	case "badrange":
+		out.Encode(ParseResponse{Comments: []*domain.Comment{{SourceText: "; x"}}})
+		return
+	case "pasteof":
+		out.Encode(ParseResponse{Comments: []*domain.Comment{{SourceText: "; x",
+			Range: domain.TextRange{StartLine: 3, StartCol: 1, EndLine: 3, EndCol: 4}}}})
+		return
+	case "pastline":
+		out.Encode(ParseResponse{Comments: []*domain.Comment{{SourceText: "; x",
+			Range: domain.TextRange{StartLine: 1, StartCol: 1, EndLine: 1, EndCol: 9}}}})
+		return
+	case "wrongtext":
+		out.Encode(ParseResponse{Comments: []*domain.Comment{{SourceText: "; y",
+			Range: domain.TextRange{StartLine: 1, StartCol: 1, EndLine: 1, EndCol: 4}}}})
+		return
+	}
+
+	// One comment per line starting with ";"
+	var comments []*domain.Comment
+	for i, line := range strings.Split(req.Source, "\n") {
+		if strings.HasPrefix(line, ";") {
+			comments = append(comments, &domain.Comment{
+				Symbol:     "top",
+				SourceText: line,
+				Range:      domain.TextRange{StartLine: i + 1, StartCol: 1, EndLine: i + 1, EndCol: len(line) + 1},
+			})
+		}
+	}
+	out.Encode(ParseResponse{Comments: comments})
+}
+
+func helperAdapter(t *testing.T, mode string) *Adapter {
+	t.Setenv(helperModeEnv, mode)
+	adapter, err := NewAdapter(config.ExternalAdapter{
+		Command:    os.Args[0],
+		Args:       []string{"-test.run=^TestHelperProcess$"},
+		Language:   "fakelang",
+		Extensions: []string{".fake"},
+	})
+	require.NoError(t, err)
+	return adapter
+}
+
+func TestAdapter_HandshakeAndParse(t *testing.T) {
+	adapter := helperAdapter(t, "ok")
+
+	hs, err := adapter.Handshake()
+	require.NoError(t, err)
+	assert.Equal(t, 1, hs.ProtocolVersion)
+	assert.Equal(t, []string{CapabilityParse, CapabilityMarkers}, hs.Capabilities, "unknown capabilities are dropped")
+
+	comments, err := adapter.Parse("src/a.fake", []byte("(define x 1)\n; Adds one\n"))
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	c := comments[0]
+	assert.Equal(t, "src/a.fake", c.File)
+	assert.Equal(t, "fakelang", c.Language)
+	assert.Equal(t, domain.CommentTypeLine, c.Type)
+	assert.Equal(t, 2, c.Range.StartLine)
+	assert.NotEmpty(t, c.ID)
+
+	formatted, ok := adapter.FormatComment(c, "加一")
+	assert.True(t, ok)
+	assert.Equal(t, "; 加一", formatted)
+}
+
+func TestNewAdapter_RequiresLanguageAndExtensions(t *testing.T) {
+	for _, cfg := range []config.ExternalAdapter{
+		{Language: "scheme", Extensions: []string{".scm"}},
+		{Command: "scheme-adapter", Extensions: []string{".scm"}},
+		{Command: "scheme-adapter", Language: "scheme"},
+	} {
+		_, err := NewAdapter(cfg)
+		assert.Error(t, err, "%+v", cfg)
+	}
+
+	// The executable does not exist: creating the adapter must not run it
+	adapter, err := NewAdapter(config.ExternalAdapter{Command: "/nonexistent/codei18n-adapter", Language: "scheme", Extensions: []string{".scm"}})
+	require.NoError(t, err)
+	assert.Equal(t, "scheme", adapter.Language())
+	assert.Equal(t, []string{".scm"}, adapter.Extensions())
+	_, err = adapter.Handshake()
+	assert.ErrorContains(t, err, "handshake failed")
+}
+
+func TestAdapter_Errors(t *testing.T) {
+	_, err := helperAdapter(t, "future").Handshake()
+	assert.ErrorContains(t, err, "unsupported protocol version 2")
+
+	_, err = helperAdapter(t, "noparse").Handshake()
+	assert.ErrorContains(t, err, `does not support "parse"`)
+
+	_, err = helperAdapter(t, "crash").Parse("a.fake", []byte("; x"))
+	assert.ErrorContains(t, err, "boom")
+
+	_, err = helperAdapter(t, "badrange").Parse("a.fake", []byte("; x"))
+	assert.ErrorContains(t, err, "1-based")
+
+	// Ranges must lie within the source and cover the reported text
+	_, err = helperAdapter(t, "pasteof").Parse("a.fake", []byte("; x\n"))
+	assert.ErrorContains(t, err, "past the last line 2")
+
+	_, err = helperAdapter(t, "pastline").Parse("a.fake", []byte("; x\n(foo)\n"))
+	assert.ErrorContains(t, err, "column 9 is past the end of line 1")
+
+	_, err = helperAdapter(t, "wrongtext").Parse("a.fake", []byte("; x\n"))
+	assert.ErrorContains(t, err, `range covers "; x"`)
+}
@@ -0,0 +1,57 @@
+package external
+
+import (
+	"github.com/studyzy/codei18n/adapters/declarative"
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+// Protocol versions spoken by codei18n. The adapter picks a version in this range during the handshake.
+const (
+	ProtocolVersion    = 1
+	MinProtocolVersion = 1
+)
+
+// Methods of the protocol. Every invocation of the executable handles exactly one request:
+// a single JSON document on stdin, answered by a single JSON document on stdout.
+const (
+	MethodHandshake = "handshake"
+	MethodParse     = "parse"
+)
+
+// Capabilities negotiated during the handshake
+const (
+	// CapabilityParse is required: the adapter extracts comments from a file
+	CapabilityParse = "parse"
+	// CapabilityMarkers means the handshake reports comment markers used by convert to re-wrap translations
+	CapabilityMarkers = "markers"
+)
+
+// hostCapabilities are the capabilities codei18n offers in the handshake
+var hostCapabilities = []string{CapabilityParse, CapabilityMarkers}
+
+// Request is sent to the adapter on stdin
+type Request struct {
+	ProtocolVersion int    `json:"protocolVersion"`
+	Method          string `json:"method"`
+	// Capabilities offered by codei18n (handshake only)
+	Capabilities []string `json:"capabilities,omitempty"`
+	// File and Source describe the file to parse (parse only); Source must be UTF-8
+	File   string `json:"file,omitempty"`
+	Source string `json:"source,omitempty"`
+}
+
+// HandshakeResponse describes the adapter and the protocol version and capabilities it accepts
+type HandshakeResponse struct {
+	ProtocolVersion int                  `json:"protocolVersion"`
+	Name            string               `json:"name,omitempty"`
+	Capabilities    []string             `json:"capabilities"`
+	Markers         *declarative.Markers `json:"markers,omitempty"`
+	Error           string               `json:"error,omitempty"`
+}
+
+// ParseResponse carries the comments found in a file.
+// File, Language and ID of each comment are filled in by codei18n.
+type ParseResponse struct {
+	Comments []*domain.Comment `json:"comments"`
+	Error    string            `json:"error,omitempty"`
+}
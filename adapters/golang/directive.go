@@ -0,0 +1,47 @@
+package golang
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// goDirectivePrefixes are the Go-only comment forms the toolchain interprets. Like the
+// toolchain, they are only recognized without a space after the slashes, except for the
+// legacy "// +build" constraint.
+var goDirectivePrefixes = []string{"//go:", "//line ", "//export ", "//extern ", "// +build "}
+
+// isGoDirective reports whether the text of a single comment is a directive for the Go
+// toolchain. Directives shared with other languages, such as linter controls, are
+// classified for every adapter by the registry.
+func isGoDirective(text string) bool {
+	for _, prefix := range goDirectivePrefixes {
+		if strings.HasPrefix(text, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// cgoPreambles returns the comment groups immediately preceding import "C".
+// They hold C source compiled by cgo and must be left untouched.
+func cgoPreambles(f *ast.File) map[*ast.CommentGroup]bool {
+	preambles := make(map[*ast.CommentGroup]bool)
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			imp, ok := spec.(*ast.ImportSpec)
+			if !ok || imp.Path.Value != `"C"` {
+				continue
+			}
+			if imp.Doc != nil {
+				preambles[imp.Doc] = true
+			} else if gen.Doc != nil && !gen.Lparen.IsValid() {
+				preambles[gen.Doc] = true
+			}
+		}
+	}
+	return preambles
+}
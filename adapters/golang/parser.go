@@ -39,60 +39,22 @@ func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
 func (a *Adapter) parseWithScopeTracking(fset *token.FileSet, f *ast.File, filePath string) ([]*domain.Comment, error) {
 	var comments []*domain.Comment
 
-	// Let's use ast.NewCommentMap to find attached comments.
-	cmap := ast.NewCommentMap(fset, f, f.Comments)
-
-	// Helper to determine symbol name from a node
-	getSymbol := func(n ast.Node) string {
-		switch t := n.(type) {
-		case *ast.File:
-			return "package." + t.Name.Name
-		case *ast.FuncDecl:
-			return "func." + t.Name.Name
-		case *ast.GenDecl:
-			// import, const, type, var
-			return "decl." + t.Tok.String()
-		case *ast.TypeSpec:
-			return "type." + t.Name.Name
-		case *ast.ValueSpec:
-			if len(t.Names) > 0 {
-				return "var." + t.Names[0].Name
-			}
-		}
-		return "unknown"
-	}
-
-	// We need to map *ast.Comment -> Symbol
-	commentSymbols := make(map[*ast.Comment]string)
-
-	for node, groups := range cmap {
-		symbol := getSymbol(node)
-		if symbol == "unknown" {
-			continue // keep default or try parent
-		}
-		for _, cg := range groups {
-			for _, c := range cg.List {
-				// Only set if not already set (inner nodes might be more specific)
-				// But CommentMap maps to the "best" node usually.
-				commentSymbols[c] = symbol
-			}
-		}
-	}
+	symbols := resolveSymbols(fset, f)
+	preambles := cgoPreambles(f)
 
-	// Now iterate in order
+	// Iterate comment groups in source order
 	for _, cg := range f.Comments {
 		if len(cg.List) == 0 {
 			continue
 		}
 
-		// Use the symbol of the first comment in the group
-		symbol := commentSymbols[cg.List[0]]
-		if symbol == "" {
-			symbol = "package." + f.Name.Name // Default to package level
-		}
-
 		// Split group into subgroups and create comments
-		subComments := a.processCommentGroup(fset, cg, filePath, symbol)
+		subComments := a.processCommentGroup(fset, cg, filePath, symbols[cg])
+		if preambles[cg] {
+			for _, c := range subComments {
+				c.Type = domain.CommentTypeDirective
+			}
+		}
 		comments = append(comments, subComments...)
 	}
 
@@ -124,6 +86,9 @@ func (a *Adapter) createSingleComment(fset *token.FileSet, c *ast.Comment, file,
 	if strings.HasPrefix(c.Text, "/*") {
 		cType = domain.CommentTypeBlock
 	}
+	if isGoDirective(c.Text) {
+		cType = domain.CommentTypeDirective
+	}
 
 	return &domain.Comment{
 		File:     file,
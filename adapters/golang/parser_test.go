@@ -0,0 +1,185 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+const serverGo = `// Package server serves requests.
+package server
+
+import (
+	// for logging
+	"log"
+)
+
+// Status codes
+const (
+	// StatusOK means success
+	StatusOK = iota
+	StatusErr // failure
+)
+
+// Config holds settings.
+type Config struct {
+	// Addr to listen on
+	Addr string
+	TLS  struct {
+		Cert string // certificate path
+	}
+}
+
+// Handler handles things.
+type Handler interface {
+	// Handle a request
+	Handle() error
+}
+
+// Server is the server.
+type Server struct{}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	// flush first
+	for i := 0; i < 3; i++ {
+		if i > 1 {
+			// give up
+			break
+		} else {
+			/* retry */
+		}
+	}
+	go func() {
+		// background
+		log.Println()
+	}()
+	return nil
+}
+
+// Close releases the config.
+func (c Config) Close() {} // no-op
+
+var handler = func() {
+	// inline handler
+}
+`
+
+func TestAdapter_Parse_Symbols(t *testing.T) {
+	adapter := NewAdapter()
+	comments, err := adapter.Parse("server.go", []byte(serverGo))
+	require.NoError(t, err)
+
+	symbols := make(map[string]string)
+	for _, c := range comments {
+		symbols[c.SourceText] = c.Symbol
+	}
+
+	expected := map[string]string{
+		"// Package server serves requests.": "server",
+		"// for logging":                     "server.import",
+		"// Status codes":                    "server.const(StatusOK)",
+		"// StatusOK means success":          "server.StatusOK",
+		"// failure":                         "server.StatusErr",
+		"// Config holds settings.":          "server.Config",
+		"// Addr to listen on":               "server.Config.Addr",
+		"// certificate path":                "server.Config.TLS.Cert",
+		"// Handler handles things.":         "server.Handler",
+		"// Handle a request":                "server.Handler.Handle",
+		"// Server is the server.":           "server.Server",
+		"// Close stops the server.":         "server.(*Server).Close",
+		"// flush first":                     "server.(*Server).Close",
+		"// give up":                         "server.(*Server).Close/for1/if1",
+		"/* retry */":                        "server.(*Server).Close/for1/if1/else",
+		"// background":                      "server.(*Server).Close/func1",
+		"// Close releases the config.":      "server.Config.Close",
+		"// no-op":                           "server.Config.Close",
+		"// inline handler":                  "server.handler/func1",
+	}
+	assert.Equal(t, expected, symbols)
+
+	for _, c := range comments {
+		if c.SourceText == "/* retry */" {
+			assert.Equal(t, domain.CommentTypeBlock, c.Type)
+		}
+	}
+}
+
+func TestAdapter_Parse_SwitchAndSelect(t *testing.T) {
+	src := `package p
+
+func Run(ch chan int, v interface{}) {
+	switch v.(type) {
+	case int:
+		// an int
+	default:
+		// anything else
+	}
+	select {
+	case <-ch:
+		// received
+	}
+}
+`
+	comments, err := NewAdapter().Parse("p.go", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+	assert.Equal(t, "p.Run/switch1/case1", comments[0].Symbol)
+	assert.Equal(t, "p.Run/switch1/default", comments[1].Symbol)
+	assert.Equal(t, "p.Run/select1/case1", comments[2].Symbol)
+}
+
+func TestAdapter_Parse_Directives(t *testing.T) {
+	src := `//go:build linux
+
+package p
+
+/*
+#include <stdlib.h>
+// free releases memory
+*/
+import "C"
+
+import _ "embed"
+
+//go:generate stringer -type=Kind
+//line gen.go:1
+// Kind is a kind.
+type Kind int
+
+// go: with a space is prose
+//
+//go:embed banner.txt
+var banner string
+
+//export Add
+func Add(a, b C.int) C.int {
+	return a + b //nolint:gosec
+}
+`
+	comments, err := NewAdapter().Parse("p.go", []byte(src))
+	require.NoError(t, err)
+
+	types := make(map[string]domain.CommentType)
+	for _, c := range comments {
+		types[c.SourceText] = c.Type
+	}
+
+	for _, text := range []string{
+		"//go:build linux",
+		"/*\n#include <stdlib.h>\n// free releases memory\n*/",
+		"//go:generate stringer -type=Kind",
+		"//line gen.go:1",
+		"//go:embed banner.txt",
+		"//export Add",
+	} {
+		assert.Equal(t, domain.CommentTypeDirective, types[text], text)
+	}
+	assert.Equal(t, domain.CommentTypeLine, types["// Kind is a kind."])
+	assert.Equal(t, domain.CommentTypeLine, types["// go: with a space is prose"])
+	// Linter controls shared with other languages are classified by the registry
+	assert.Equal(t, domain.CommentTypeLine, types["//nolint:gosec"])
+}
@@ -0,0 +1,343 @@
+package golang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+)
+
+// region is a source range whose unattached comments belong to symbol
+type region struct {
+	pos, end token.Pos
+	symbol   string
+}
+
+// symbolResolver assigns fully qualified symbols to the comment groups of a file.
+// Symbols look like "pkg.Func", "pkg.(*Server).Close", "pkg.Config.Field",
+// "pkg.Reader.Read", "pkg.const(StatusOK)" for parenthesized groups and
+// "pkg.Func/if1/for1" for comments inside nested blocks of a function body.
+type symbolResolver struct {
+	fset    *token.FileSet
+	pkg     string
+	groups  map[*ast.CommentGroup]string
+	regions []region
+	// decls records the end line of top-level declarations for trailing comments
+	decls []region
+}
+
+// resolveSymbols returns the symbol of every comment group in f
+func resolveSymbols(fset *token.FileSet, f *ast.File) map[*ast.CommentGroup]string {
+	r := &symbolResolver{
+		fset:   fset,
+		pkg:    f.Name.Name,
+		groups: make(map[*ast.CommentGroup]string),
+	}
+	r.attach(f.Doc, r.pkg)
+
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			r.funcDecl(d)
+		case *ast.GenDecl:
+			r.genDecl(d)
+		}
+	}
+
+	for _, cg := range f.Comments {
+		if _, ok := r.groups[cg]; !ok {
+			r.groups[cg] = r.locate(cg)
+		}
+	}
+	return r.groups
+}
+
+// attach binds a doc or line comment group to a symbol
+func (r *symbolResolver) attach(cg *ast.CommentGroup, symbol string) {
+	if cg != nil {
+		r.groups[cg] = symbol
+	}
+}
+
+// scope records the region of a node
+func (r *symbolResolver) scope(n ast.Node, symbol string) {
+	r.regions = append(r.regions, region{pos: n.Pos(), end: n.End(), symbol: symbol})
+}
+
+// locate finds the symbol of an unattached comment: the innermost enclosing region,
+// else the top-level declaration ending on the same line, else the package
+func (r *symbolResolver) locate(cg *ast.CommentGroup) string {
+	var best *region
+	for i := range r.regions {
+		reg := &r.regions[i]
+		if cg.Pos() >= reg.pos && cg.End() <= reg.end && (best == nil || reg.end-reg.pos < best.end-best.pos) {
+			best = reg
+		}
+	}
+	if best != nil {
+		return best.symbol
+	}
+
+	line := r.fset.Position(cg.Pos()).Line
+	for _, d := range r.decls {
+		if r.fset.Position(d.end).Line == line && d.end <= cg.Pos() {
+			return d.symbol
+		}
+	}
+	return r.pkg
+}
+
+func (r *symbolResolver) funcDecl(d *ast.FuncDecl) {
+	symbol := r.pkg + "." + d.Name.Name
+	if d.Recv != nil && len(d.Recv.List) > 0 {
+		symbol = r.pkg + "." + receiverName(d.Recv.List[0].Type) + "." + d.Name.Name
+	}
+	r.attach(d.Doc, symbol)
+	r.scope(d, symbol)
+	r.decls = append(r.decls, region{pos: d.Pos(), end: d.End(), symbol: symbol})
+	if d.Body != nil {
+		r.block(d.Body.List, symbol)
+	}
+}
+
+func (r *symbolResolver) genDecl(d *ast.GenDecl) {
+	if d.Tok == token.IMPORT {
+		symbol := r.pkg + ".import"
+		r.attach(d.Doc, symbol)
+		r.scope(d, symbol)
+		return
+	}
+
+	grouped := d.Lparen.IsValid()
+	if grouped {
+		symbol := fmt.Sprintf("%s.%s(%s)", r.pkg, d.Tok, firstSpecName(d))
+		r.attach(d.Doc, symbol)
+		r.scope(d, symbol)
+	}
+
+	for _, spec := range d.Specs {
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			symbol := r.pkg + "." + s.Name.Name
+			if !grouped {
+				r.attach(d.Doc, symbol)
+			}
+			r.attach(s.Doc, symbol)
+			r.attach(s.Comment, symbol)
+			r.scope(s, symbol)
+			r.decls = append(r.decls, region{pos: s.Pos(), end: s.End(), symbol: symbol})
+			r.typeExpr(s.Type, symbol)
+		case *ast.ValueSpec:
+			if len(s.Names) == 0 {
+				continue
+			}
+			symbol := r.pkg + "." + s.Names[0].Name
+			if !grouped {
+				r.attach(d.Doc, symbol)
+			}
+			r.attach(s.Doc, symbol)
+			r.attach(s.Comment, symbol)
+			r.decls = append(r.decls, region{pos: s.Pos(), end: s.End(), symbol: symbol})
+			for _, v := range s.Values {
+				r.funcLits(v, symbol, map[string]int{})
+			}
+		}
+	}
+}
+
+// typeExpr binds struct fields and interface methods: "pkg.Config.Field", "pkg.Reader.Read"
+func (r *symbolResolver) typeExpr(expr ast.Expr, symbol string) {
+	var fields *ast.FieldList
+	switch t := expr.(type) {
+	case *ast.StructType:
+		fields = t.Fields
+	case *ast.InterfaceType:
+		fields = t.Methods
+	case *ast.StarExpr:
+		r.typeExpr(t.X, symbol)
+		return
+	default:
+		return
+	}
+	if fields == nil {
+		return
+	}
+	r.scope(fields, symbol)
+
+	for _, field := range fields.List {
+		name := fieldName(field)
+		if name == "" {
+			continue
+		}
+		fieldSymbol := symbol + "." + name
+		r.attach(field.Doc, fieldSymbol)
+		r.attach(field.Comment, fieldSymbol)
+		r.typeExpr(field.Type, fieldSymbol)
+	}
+}
+
+// block assigns paths to the nested blocks of a statement list.
+// Each block adds a segment made of its kind and its 1-based index among
+// siblings of the same kind, e.g. "pkg.Func/if2/for1".
+func (r *symbolResolver) block(stmts []ast.Stmt, symbol string) {
+	counts := make(map[string]int)
+	for _, stmt := range stmts {
+		r.stmt(stmt, symbol, counts)
+	}
+}
+
+// segment returns the path of the next block of the given kind
+func segment(symbol, kind string, counts map[string]int) string {
+	counts[kind]++
+	return fmt.Sprintf("%s/%s%d", symbol, kind, counts[kind])
+}
+
+func (r *symbolResolver) stmt(stmt ast.Stmt, symbol string, counts map[string]int) {
+	switch s := stmt.(type) {
+	case *ast.LabeledStmt:
+		r.stmt(s.Stmt, symbol, counts)
+	case *ast.BlockStmt:
+		path := segment(symbol, "block", counts)
+		r.scope(s, path)
+		r.block(s.List, path)
+	case *ast.IfStmt:
+		r.ifStmt(s, segment(symbol, "if", counts))
+	case *ast.ForStmt:
+		path := segment(symbol, "for", counts)
+		r.scope(s, path)
+		r.block(s.Body.List, path)
+	case *ast.RangeStmt:
+		path := segment(symbol, "for", counts)
+		r.scope(s, path)
+		r.block(s.Body.List, path)
+	case *ast.SwitchStmt:
+		path := segment(symbol, "switch", counts)
+		r.scope(s, path)
+		r.clauses(s.Body, path)
+	case *ast.TypeSwitchStmt:
+		path := segment(symbol, "switch", counts)
+		r.scope(s, path)
+		r.clauses(s.Body, path)
+	case *ast.SelectStmt:
+		path := segment(symbol, "select", counts)
+		r.scope(s, path)
+		r.clauses(s.Body, path)
+	default:
+		r.funcLits(stmt, symbol, counts)
+	}
+}
+
+// ifStmt handles an if statement and its else branch ("pkg.F/if1/else")
+func (r *symbolResolver) ifStmt(s *ast.IfStmt, path string) {
+	r.regions = append(r.regions, region{pos: s.Pos(), end: s.Body.End(), symbol: path})
+	r.block(s.Body.List, path)
+
+	switch e := s.Else.(type) {
+	case *ast.BlockStmt:
+		r.scope(e, path+"/else")
+		r.block(e.List, path+"/else")
+	case *ast.IfStmt:
+		r.ifStmt(e, path+"/else")
+	}
+}
+
+// clauses handles the case clauses of switch and select statements ("case1", "default").
+// A clause extends to the next clause so comments in empty clauses stay inside it.
+func (r *symbolResolver) clauses(body *ast.BlockStmt, symbol string) {
+	counts := make(map[string]int)
+	for i, stmt := range body.List {
+		var clauseBody []ast.Stmt
+		isDefault := false
+		switch c := stmt.(type) {
+		case *ast.CaseClause:
+			clauseBody, isDefault = c.Body, c.List == nil
+		case *ast.CommClause:
+			clauseBody, isDefault = c.Body, c.Comm == nil
+		default:
+			continue
+		}
+		path := symbol + "/default"
+		if !isDefault {
+			path = segment(symbol, "case", counts)
+		}
+		end := body.Rbrace
+		if i+1 < len(body.List) {
+			end = body.List[i+1].Pos()
+		}
+		r.regions = append(r.regions, region{pos: stmt.Pos(), end: end, symbol: path})
+		r.block(clauseBody, path)
+	}
+}
+
+// funcLits assigns paths to function literals inside a simple statement or expression ("func1")
+func (r *symbolResolver) funcLits(n ast.Node, symbol string, counts map[string]int) {
+	ast.Inspect(n, func(node ast.Node) bool {
+		lit, ok := node.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		path := segment(symbol, "func", counts)
+		r.scope(lit, path)
+		r.block(lit.Body.List, path)
+		return false
+	})
+}
+
+// receiverName formats a method receiver: "Server" or "(*Server)", without type parameters
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		return "(*" + receiverName(star.X) + ")"
+	}
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.IndexExpr:
+		return receiverName(t.X)
+	case *ast.IndexListExpr:
+		return receiverName(t.X)
+	case *ast.ParenExpr:
+		return receiverName(t.X)
+	}
+	return "?"
+}
+
+// fieldName returns the name of a struct field or interface method; embedded fields use their type name
+func fieldName(field *ast.Field) string {
+	if len(field.Names) > 0 {
+		return field.Names[0].Name
+	}
+	expr := field.Type
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+			continue
+		case *ast.SelectorExpr:
+			return t.Sel.Name
+		case *ast.Ident:
+			return t.Name
+		case *ast.IndexExpr:
+			expr = t.X
+			continue
+		case *ast.IndexListExpr:
+			expr = t.X
+			continue
+		}
+		return ""
+	}
+}
+
+// firstSpecName names a parenthesized declaration group after its first spec
+func firstSpecName(d *ast.GenDecl) string {
+	if len(d.Specs) == 0 {
+		return ""
+	}
+	switch s := d.Specs[0].(type) {
+	case *ast.TypeSpec:
+		return s.Name.Name
+	case *ast.ValueSpec:
+		if len(s.Names) > 0 {
+			return s.Names[0].Name
+		}
+	}
+	return ""
+}
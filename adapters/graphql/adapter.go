@@ -0,0 +1,97 @@
+package graphql
+
+import (
+	"os"
+	"strings"
+
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// Adapter implements the LanguageAdapter interface for GraphQL schemas and documents.
+// It extracts # comments and "..." / """...""" descriptions and binds them to
+// types, fields, arguments, enum values, directive definitions and operations.
+type Adapter struct{}
+
+// NewAdapter creates a new GraphQL adapter instance
+func NewAdapter() *Adapter {
+	return &Adapter{}
+}
+
+// Language returns the language identifier ("graphql")
+func (a *Adapter) Language() string {
+	return "graphql"
+}
+
+// Parse tokenizes the provided GraphQL source and extracts comments and descriptions.
+// If src is nil, the source code is read from the file path.
+func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	if src == nil {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src = data
+	}
+
+	found, symbols := bind(tokenize(string(src)))
+
+	var comments []*domain.Comment
+	for i, c := range found {
+		t := c.tok
+
+		cType := domain.CommentTypeDoc
+		if t.kind == tokenComment {
+			if strings.TrimSpace(strings.TrimLeft(t.text, "#")) == "" {
+				continue
+			}
+			cType = domain.CommentTypeLine
+		}
+
+		comment := &domain.Comment{
+			File:     file,
+			Language: "graphql",
+			Symbol:   symbols[i],
+			Range: domain.TextRange{
+				StartLine: t.row + 1,
+				StartCol:  t.col + 1,
+				EndLine:   t.endRow + 1,
+				EndCol:    t.endCol + 1,
+			},
+			SourceText: t.text,
+			Type:       cType,
+		}
+		comment.ID = utils.GenerateCommentID(comment)
+
+		comments = append(comments, comment)
+	}
+
+	return comments, nil
+}
+
+// descriptionEscaper escapes text for a single-line GraphQL string
+var descriptionEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ")
+
+// FormatComment wraps translated text in the markers of the original comment: # for
+// comments, and the quotes of the original description, escaped so that the translation
+// stays a valid GraphQL string
+func (a *Adapter) FormatComment(c *domain.Comment, text string) (string, bool) {
+	switch {
+	case strings.HasPrefix(c.SourceText, "#"):
+		if strings.HasPrefix(text, "#") {
+			return text, true
+		}
+		return "# " + text, true
+	case strings.HasPrefix(c.SourceText, `"""`):
+		if strings.HasPrefix(text, `"""`) {
+			return text, true
+		}
+		return `"""` + strings.ReplaceAll(text, `"""`, `\"""`) + `"""`, true
+	case strings.HasPrefix(c.SourceText, `"`):
+		if strings.HasPrefix(text, `"`) {
+			return text, true
+		}
+		return `"` + descriptionEscaper.Replace(text) + `"`, true
+	}
+	return "", false
+}
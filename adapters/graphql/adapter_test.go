@@ -0,0 +1,123 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestAdapter_Parse_Schema(t *testing.T) {
+	src := `# 用户相关类型
+"""
+用户实体
+"""
+type User implements Node & Entity {
+  "用户名"
+  name: String! # 必填
+  # 好友列表
+  friends(
+    # 分页大小
+    first: Int = 10
+    after: String @deprecated(reason: "use cursor")
+  ): [User!]!
+  # 末尾注释
+}
+
+union SearchResult = User | Post
+
+# 查询入口
+type Query {
+  search(term: String = "a#b"): [SearchResult]
+}
+`
+	adapter := NewAdapter()
+	assert.Equal(t, "graphql", adapter.Language())
+
+	comments, err := adapter.Parse("schema.graphql", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 8)
+
+	assert.Equal(t, "# 用户相关类型", comments[0].SourceText)
+	assert.Equal(t, "User", comments[0].Symbol)
+	assert.Equal(t, domain.CommentTypeLine, comments[0].Type)
+
+	assert.Equal(t, "\"\"\"\n用户实体\n\"\"\"", comments[1].SourceText)
+	assert.Equal(t, "User", comments[1].Symbol)
+	assert.Equal(t, domain.CommentTypeDoc, comments[1].Type)
+	assert.Equal(t, 2, comments[1].Range.StartLine)
+	assert.Equal(t, 4, comments[1].Range.EndLine)
+	assert.Equal(t, 4, comments[1].Range.EndCol)
+
+	assert.Equal(t, `"用户名"`, comments[2].SourceText)
+	assert.Equal(t, "User.name", comments[2].Symbol)
+
+	assert.Equal(t, "# 必填", comments[3].SourceText)
+	assert.Equal(t, "User.name", comments[3].Symbol)
+
+	assert.Equal(t, "User.friends", comments[4].Symbol)
+	assert.Equal(t, "User.friends.first", comments[5].Symbol)
+	assert.Equal(t, "User", comments[6].Symbol)
+	assert.Equal(t, "Query", comments[7].Symbol)
+}
+
+func TestAdapter_Parse_EnumsAndDirectives(t *testing.T) {
+	src := `enum Status {
+  # 启用
+  ACTIVE
+  INACTIVE @deprecated # 已废弃
+}
+
+"鉴权指令"
+directive @auth(
+  # 所需角色
+  requires: Role = ADMIN
+) on FIELD_DEFINITION
+
+# 查询当前用户
+query CurrentUser($id: ID!) {
+  # 基本信息
+  user(id: $id) { name }
+}
+`
+	adapter := NewAdapter()
+	comments, err := adapter.Parse("ops.graphql", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 6)
+
+	assert.Equal(t, "Status.ACTIVE", comments[0].Symbol)
+	assert.Equal(t, "Status.INACTIVE", comments[1].Symbol)
+	assert.Equal(t, "@auth", comments[2].Symbol)
+	assert.Equal(t, "@auth.requires", comments[3].Symbol)
+	assert.Equal(t, "CurrentUser", comments[4].Symbol)
+	assert.Equal(t, "CurrentUser", comments[5].Symbol)
+}
+
+func TestAdapter_FormatComment(t *testing.T) {
+	adapter := NewAdapter()
+	comments, err := adapter.Parse("schema.graphql", []byte(`# 内部排名
+"""
+用户实体
+"""
+type User {
+  "用户名"
+  name: String!
+}
+`))
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+
+	formatted, ok := adapter.FormatComment(comments[0], "Internal ranking")
+	assert.True(t, ok)
+	assert.Equal(t, "# Internal ranking", formatted)
+
+	formatted, ok = adapter.FormatComment(comments[1], `User entity, see """docs"""`)
+	assert.True(t, ok)
+	assert.Equal(t, `"""User entity, see \"""docs\""""""`, formatted)
+
+	formatted, ok = adapter.FormatComment(comments[2], `The "login" name`)
+	assert.True(t, ok)
+	assert.Equal(t, `"The \"login\" name"`, formatted)
+}
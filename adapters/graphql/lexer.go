@@ -0,0 +1,145 @@
+package graphql
+
+import "strings"
+
+// tokenKind classifies lexical tokens of a GraphQL document
+type tokenKind int
+
+const (
+	tokenName tokenKind = iota
+	tokenString
+	tokenComment
+	tokenPunct
+	tokenValue
+)
+
+// token is a lexical token with 0-based row and byte column positions
+type token struct {
+	kind     tokenKind
+	text     string
+	row, col int
+	endRow   int
+	endCol   int
+}
+
+// byteOrderMark is ignored like whitespace
+const byteOrderMark = "\uFEFF"
+
+// lexer splits a GraphQL document into tokens.
+// There is no GraphQL Tree-sitter grammar available, and the language is small
+// enough that a hand-written tokenizer is sufficient to locate comments and names.
+type lexer struct {
+	src       string
+	pos       int
+	row       int
+	lineStart int
+}
+
+// tokenize returns all significant tokens, comments included.
+// Commas and whitespace are insignificant in GraphQL and are dropped.
+func tokenize(src string) []token {
+	l := &lexer{src: src}
+	var tokens []token
+	for {
+		l.skipIgnored()
+		if l.pos >= len(l.src) {
+			return tokens
+		}
+		tokens = append(tokens, l.next())
+	}
+}
+
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case '\n':
+			l.newline(l.pos)
+		case ' ', '\t', '\r', ',':
+		default:
+			if strings.HasPrefix(l.src[l.pos:], byteOrderMark) {
+				l.pos += len(byteOrderMark)
+				continue
+			}
+			return
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) newline(at int) {
+	l.row++
+	l.lineStart = at + 1
+}
+
+// next scans one token starting at the current position
+func (l *lexer) next() token {
+	start := l.pos
+	t := token{row: l.row, col: start - l.lineStart}
+
+	c := l.src[l.pos]
+	switch {
+	case c == '#':
+		end := strings.IndexByte(l.src[start:], '\n')
+		if end < 0 {
+			end = len(l.src) - start
+		}
+		l.pos = start + end
+		t.kind = tokenComment
+	case strings.HasPrefix(l.src[start:], `"""`):
+		l.pos = start + 3
+		for l.pos < len(l.src) && !strings.HasPrefix(l.src[l.pos:], `"""`) {
+			if strings.HasPrefix(l.src[l.pos:], `\"""`) {
+				l.pos += 4
+				continue
+			}
+			if l.src[l.pos] == '\n' {
+				l.newline(l.pos)
+			}
+			l.pos++
+		}
+		l.pos = min(l.pos+3, len(l.src))
+		t.kind = tokenString
+	case c == '"':
+		l.pos++
+		for l.pos < len(l.src) && l.src[l.pos] != '"' && l.src[l.pos] != '\n' {
+			if l.src[l.pos] == '\\' {
+				l.pos++
+			}
+			l.pos++
+		}
+		if l.pos < len(l.src) && l.src[l.pos] == '"' {
+			l.pos++
+		}
+		t.kind = tokenString
+	case isNameStart(c):
+		for l.pos < len(l.src) && isNameContinue(l.src[l.pos]) {
+			l.pos++
+		}
+		t.kind = tokenName
+	case c == '-' || (c >= '0' && c <= '9'):
+		l.pos++
+		for l.pos < len(l.src) && strings.IndexByte("0123456789.eE+-", l.src[l.pos]) >= 0 {
+			l.pos++
+		}
+		t.kind = tokenValue
+	case strings.HasPrefix(l.src[start:], "..."):
+		l.pos += 3
+		t.kind = tokenPunct
+	default:
+		l.pos++
+		t.kind = tokenPunct
+	}
+
+	t.text = strings.TrimRight(l.src[start:l.pos], " \t\r")
+	t.endRow = l.row
+	t.endCol = start + len(t.text) - l.lineStart
+	return t
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameContinue(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
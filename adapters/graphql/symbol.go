@@ -0,0 +1,277 @@
+package graphql
+
+// Frame kinds of the binder's scope stack
+const (
+	frameTop       = "top"
+	frameFields    = "fields"
+	frameEnum      = "enum"
+	frameArgs      = "args"
+	frameSelection = "selection"
+	frameValue     = "value"
+)
+
+// frame is an open { } or ( ) group together with the symbol it belongs to
+type frame struct {
+	kind   string
+	symbol string
+}
+
+// declaration is a named schema element: a type, field, argument, enum value,
+// directive definition or operation.
+type declaration struct {
+	row    int
+	symbol string
+	scope  string
+}
+
+// pendingComment is a comment or description waiting for the declaration it documents
+type pendingComment struct {
+	tok      token
+	scope    string
+	trailing bool
+	next     int // index of the first declaration after the comment
+}
+
+// binder assigns symbols to comments and descriptions by following the document structure.
+// Symbols are type names ("User"), members ("User.name", "Status.ACTIVE"), arguments
+// ("Query.users.first"), directive definitions ("@auth") and operation names.
+type binder struct {
+	tokens  []token
+	frames  []frame
+	decls   []declaration
+	pending []pendingComment
+
+	expect      string // body kind of the definition whose name comes next
+	pendingKind string // body kind opened by the next {
+	pendingSym  string
+	pendingArgs string // directive definition whose arguments open with the next (
+	directive   bool   // a directive definition name follows the next @
+}
+
+// definitionKinds maps definition keywords to the kind of body they declare
+var definitionKinds = map[string]string{
+	"type":         frameFields,
+	"interface":    frameFields,
+	"input":        frameFields,
+	"enum":         frameEnum,
+	"union":        "",
+	"scalar":       "",
+	"query":        frameSelection,
+	"mutation":     frameSelection,
+	"subscription": frameSelection,
+	"fragment":     frameSelection,
+}
+
+// bind walks the tokens and returns the comments and descriptions with their symbols
+func bind(tokens []token) ([]pendingComment, []string) {
+	b := &binder{tokens: tokens, frames: []frame{{kind: frameTop}}}
+
+	prev := -1
+	for i, t := range tokens {
+		if t.kind == tokenComment {
+			b.pending = append(b.pending, pendingComment{
+				tok:      t,
+				scope:    b.top().symbol,
+				trailing: prev >= 0 && tokens[prev].endRow == t.row,
+				next:     len(b.decls),
+			})
+			continue
+		}
+		b.step(i, prev)
+		prev = i
+	}
+
+	symbols := make([]string, len(b.pending))
+	for i, c := range b.pending {
+		symbols[i] = b.resolve(c)
+	}
+	return b.pending, symbols
+}
+
+// resolve returns the symbol of the declaration a comment documents
+func (b *binder) resolve(c pendingComment) string {
+	if c.tok.kind == tokenString {
+		if c.next < len(b.decls) {
+			return b.decls[c.next].symbol
+		}
+		return c.scope
+	}
+	if c.trailing {
+		if c.next > 0 && b.decls[c.next-1].row == c.tok.row {
+			return b.decls[c.next-1].symbol
+		}
+		return c.scope
+	}
+	if c.next < len(b.decls) && b.decls[c.next].scope == c.scope {
+		return b.decls[c.next].symbol
+	}
+	return c.scope
+}
+
+func (b *binder) top() frame {
+	return b.frames[len(b.frames)-1]
+}
+
+func (b *binder) push(kind, symbol string) {
+	b.frames = append(b.frames, frame{kind: kind, symbol: symbol})
+}
+
+func (b *binder) declare(t token, symbol string) {
+	b.decls = append(b.decls, declaration{row: t.row, symbol: symbol, scope: b.top().symbol})
+}
+
+// member joins a scope and a member name
+func member(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// peek returns the next non-comment token after index i
+func (b *binder) peek(i int) *token {
+	for j := i + 1; j < len(b.tokens); j++ {
+		if b.tokens[j].kind != tokenComment {
+			return &b.tokens[j]
+		}
+	}
+	return nil
+}
+
+// step processes a single non-comment token
+func (b *binder) step(i, prevIdx int) {
+	t := b.tokens[i]
+	prev := ""
+	if prevIdx >= 0 {
+		prev = b.tokens[prevIdx].text
+	}
+	next := b.peek(i)
+	f := b.top()
+
+	switch t.kind {
+	case tokenString:
+		// A string in declaration position, directly before a name, is a description;
+		// strings after : or = are values
+		if f.kind != frameValue && f.kind != frameSelection && prev != ":" && prev != "=" &&
+			next != nil && next.kind == tokenName {
+			b.pending = append(b.pending, pendingComment{tok: t, scope: f.symbol, next: len(b.decls)})
+		}
+		return
+	case tokenPunct:
+		b.punct(t.text)
+		return
+	case tokenName:
+	default:
+		return
+	}
+
+	// Names of applied directives are never declarations
+	if prev == "@" && !b.directive {
+		return
+	}
+
+	switch f.kind {
+	case frameTop:
+		b.topLevelName(t, prev, next)
+	case frameFields:
+		if next != nil && (next.text == "(" || next.text == ":") {
+			symbol := member(f.symbol, t.text)
+			b.declare(t, symbol)
+			if next.text == "(" {
+				b.pendingArgs = symbol
+			}
+		}
+	case frameEnum:
+		b.declare(t, member(f.symbol, t.text))
+	case frameArgs:
+		if next != nil && next.text == ":" {
+			b.declare(t, member(f.symbol, t.text))
+		}
+	}
+}
+
+// topLevelName handles definition keywords and definition names outside any body
+func (b *binder) topLevelName(t token, prev string, next *token) {
+	if b.directive {
+		b.directive = false
+		b.declare(t, "@"+t.text)
+		b.pendingArgs = "@" + t.text
+		return
+	}
+	if b.expect != "" {
+		b.declare(t, t.text)
+		b.pendingKind, b.pendingSym = b.expect, t.text
+		b.expect = ""
+		return
+	}
+	if isTypeReference(prev) {
+		return
+	}
+
+	b.pendingArgs = ""
+	switch t.text {
+	case "schema":
+		b.declare(t, t.text)
+		b.pendingKind, b.pendingSym = frameFields, t.text
+	case "directive":
+		b.directive = true
+	default:
+		kind, ok := definitionKinds[t.text]
+		if !ok {
+			return
+		}
+		if kind == frameSelection && (next == nil || next.kind != tokenName) {
+			// Anonymous operation, e.g. "query { ... }"
+			b.declare(t, t.text)
+			b.pendingKind, b.pendingSym = kind, t.text
+			return
+		}
+		if kind == "" {
+			kind = "none"
+		}
+		b.expect = kind
+	}
+}
+
+// isTypeReference reports whether a name following prev refers to an existing type
+// (interfaces, union members, fragment targets) rather than starting a definition.
+func isTypeReference(prev string) bool {
+	switch prev {
+	case "=", "|", "&", ":", "on", "implements":
+		return true
+	}
+	return false
+}
+
+// punct opens and closes groups
+func (b *binder) punct(p string) {
+	f := b.top()
+	switch p {
+	case "{":
+		switch {
+		case f.kind == frameTop && b.pendingKind != "" && b.pendingKind != "none":
+			b.push(b.pendingKind, b.pendingSym)
+		case f.kind == frameTop && b.pendingKind == "":
+			// Query shorthand: a bare selection set
+			b.push(frameSelection, "")
+		case f.kind == frameSelection:
+			b.push(frameSelection, f.symbol)
+		default:
+			b.push(frameValue, f.symbol)
+		}
+		if f.kind == frameTop {
+			b.pendingKind, b.pendingSym = "", ""
+		}
+	case "(":
+		if b.pendingArgs != "" && (f.kind == frameTop || f.kind == frameFields) {
+			b.push(frameArgs, b.pendingArgs)
+		} else {
+			b.push(frameValue, f.symbol)
+		}
+		b.pendingArgs = ""
+	case "}", ")":
+		if len(b.frames) > 1 {
+			b.frames = b.frames[:len(b.frames)-1]
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package groovy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestAdapter_Parse_Symbols(t *testing.T) {
+	src := `package com.example
+
+import java.util.List
+
+/** 服务 */
+class Service {
+    // 计数器
+    int count = 0
+
+    /**
+     * 入口
+     * @param args 参数
+     */
+    static void main(String[] args) {
+        // 打印
+        println('hi')
+    }
+}
+
+// 脚本函数
+def helper() { 1 }
+`
+	adapter := NewAdapter()
+	assert.Equal(t, "groovy", adapter.Language())
+
+	comments, err := adapter.Parse("Service.groovy", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 5)
+
+	symbols := make(map[string]string)
+	for _, c := range comments {
+		assert.NotEmpty(t, c.ID)
+		symbols[c.SourceText] = c.Symbol
+	}
+
+	assert.Equal(t, "com.example.Service", symbols["/** 服务 */"])
+	assert.Equal(t, "com.example.Service.count", symbols["// 计数器"])
+	assert.Equal(t, "com.example.Service.main", symbols["/**\n     * 入口\n     * @param args 参数\n     */"])
+	assert.Equal(t, "com.example.Service.main", symbols["// 打印"])
+	assert.Equal(t, "com.example.helper", symbols["// 脚本函数"])
+
+	assert.Equal(t, domain.CommentTypeDoc, comments[0].Type)
+	assert.Equal(t, domain.CommentTypeLine, comments[1].Type)
+}
+
+func TestMaskGroovyDoc(t *testing.T) {
+	src := []byte("/** doc */ /**/ /* block */")
+	masked := maskGroovyDoc(src)
+	assert.Equal(t, "/*  doc */ /**/ /* block */", string(masked))
+	assert.Equal(t, "/** doc */ /**/ /* block */", string(src), "original source must not change")
+}
@@ -0,0 +1,8 @@
+package groovy
+
+// groovyCommentQuery is the Tree-sitter query to extract comments.
+// GroovyDoc openers are masked before parsing (see maskGroovyDoc), so every
+// comment, including /** */, surfaces as a (comment) node.
+const groovyCommentQuery = `
+(comment) @comment
+`
@@ -0,0 +1,84 @@
+package groovy
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// findOwnerNode finds the node a comment belongs to.
+// Trailing comments bind to the enclosing scope, other comments bind to the
+// next declaration (skipping comments), falling back to the enclosing scope.
+func findOwnerNode(node *sitter.Node) *sitter.Node {
+	if prev := node.PrevNamedSibling(); prev != nil && prev.EndPoint().Row == node.StartPoint().Row {
+		return node.Parent()
+	}
+
+	next := node.NextNamedSibling()
+	for next != nil && next.Type() == "comment" {
+		next = next.NextNamedSibling()
+	}
+	if next != nil {
+		return next
+	}
+	return node.Parent()
+}
+
+// resolveSymbolPath builds a path like "com.example.Service.main" by walking up the tree
+func resolveSymbolPath(node *sitter.Node, src []byte, packageName string) string {
+	var parts []string
+	for curr := node; curr != nil; curr = curr.Parent() {
+		if name := declarationName(curr, src); name != "" {
+			parts = append([]string{name}, parts...)
+		}
+	}
+
+	if packageName != "" {
+		parts = append([]string{packageName}, parts...)
+	}
+	return strings.Join(parts, ".")
+}
+
+// declarationName returns the name contributed by a declaration node to the symbol path
+func declarationName(node *sitter.Node, src []byte) string {
+	switch node.Type() {
+	case "class_definition":
+		return getChildContent(node, "name", src)
+	case "function_definition", "function_declaration":
+		return getChildContent(node, "function", src)
+	case "declaration":
+		// Only class members count, local variables inside methods don't
+		if isClassBody(node.Parent()) {
+			return getChildContent(node, "name", src)
+		}
+	}
+	return ""
+}
+
+// isClassBody reports whether the closure is the body of a class definition
+func isClassBody(node *sitter.Node) bool {
+	if node == nil || node.Type() != "closure" {
+		return false
+	}
+	parent := node.Parent()
+	return parent != nil && parent.Type() == "class_definition"
+}
+
+// extractPackageName returns the package declared at the top of the file
+func extractPackageName(root *sitter.Node, src []byte) string {
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		child := root.NamedChild(i)
+		if child.Type() == "groovy_package" && child.NamedChildCount() > 0 {
+			return child.NamedChild(0).Content(src)
+		}
+	}
+	return ""
+}
+
+func getChildContent(node *sitter.Node, fieldName string, src []byte) string {
+	child := node.ChildByFieldName(fieldName)
+	if child != nil {
+		return child.Content(src)
+	}
+	return ""
+}
@@ -0,0 +1,145 @@
+package adapters
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/studyzy/codei18n/core"
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// Comment grouping modes, selected by the commentGrouping config
+const (
+	// GroupingNone reports every comment line on its own (the default)
+	GroupingNone = "none"
+	// GroupingParagraph merges adjacent line comments into one paragraph
+	GroupingParagraph = "paragraph"
+)
+
+// structuredLine matches comment bodies that carry layout a paragraph would destroy:
+// list items, Markdown headings and quotes, tables, fences and doc tags such as @param.
+var structuredLine = regexp.MustCompile("^(?:[-*+] |\\d+[.)] |#|>|\\||```|@)")
+
+// withGrouping wraps adapter according to the commentGrouping config
+func withGrouping(adapter core.LanguageAdapter) core.LanguageAdapter {
+	if settings().CommentGrouping != GroupingParagraph {
+		return adapter
+	}
+	return &groupingAdapter{LanguageAdapter: adapter}
+}
+
+// groupingAdapter merges the line comments reported by the wrapped adapter into paragraphs
+type groupingAdapter struct {
+	core.LanguageAdapter
+}
+
+func (g *groupingAdapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	comments, err := g.LanguageAdapter.Parse(file, src)
+	if err != nil || src == nil {
+		return comments, err
+	}
+	return groupLineComments(comments, src), nil
+}
+
+// FormatComment forwards to the wrapped adapter so that convert keeps its markers
+func (g *groupingAdapter) FormatComment(c *domain.Comment, text string) (string, bool) {
+	if f, ok := g.LanguageAdapter.(core.CommentFormatter); ok {
+		return f.FormatComment(c, text)
+	}
+	return "", false
+}
+
+// groupLineComments merges runs of line comments that sit on consecutive lines at the same
+// column, with the same type and marker, into one comment. The merged comment keeps the
+// first line's marker, its text is the paragraph of all line bodies joined by
+// utils.JoinParagraph, and its range spans every line. A single-line SourceText over a
+// multi-line range is what tells convert to re-flow the translation.
+func groupLineComments(comments []*domain.Comment, src []byte) []*domain.Comment {
+	lines := strings.Split(string(src), "\n")
+
+	var result, group []*domain.Comment
+	flush := func() {
+		switch len(group) {
+		case 0:
+		case 1:
+			result = append(result, group[0])
+		default:
+			result = append(result, mergeLineComments(group))
+		}
+		group = nil
+	}
+
+	for _, c := range comments {
+		if !groupable(c, lines) {
+			flush()
+			result = append(result, c)
+			continue
+		}
+		if len(group) > 0 && !continues(group[len(group)-1], c) {
+			flush()
+		}
+		group = append(group, c)
+	}
+	flush()
+
+	return result
+}
+
+// groupable reports whether c is a plain line comment that occupies its line on its own
+func groupable(c *domain.Comment, lines []string) bool {
+	if c.Type != domain.CommentTypeLine && c.Type != domain.CommentTypeDoc {
+		return false
+	}
+	if c.Range.StartLine != c.Range.EndLine || c.Range.StartLine > len(lines) {
+		return false
+	}
+	marker := utils.LineCommentMarker(c.SourceText)
+	if marker == "" {
+		return false
+	}
+
+	// Only raw comments qualify: fragments (e.g. the text of "// MARK: - x") and trailing
+	// comments after code are left alone
+	line := strings.TrimRight(lines[c.Range.StartLine-1], "\r")
+	col := c.Range.StartCol - 1
+	if col > len(line) || strings.TrimSpace(line[:col]) != "" || strings.TrimSpace(line[col:]) != strings.TrimSpace(c.SourceText) {
+		return false
+	}
+
+	rest := strings.TrimPrefix(c.SourceText, marker)
+	body := strings.TrimSpace(rest)
+	if body == "" || strings.HasPrefix(rest, "  ") || strings.HasPrefix(rest, "\t") {
+		// Blank lines separate paragraphs; indented lines are code
+		return false
+	}
+	return !structuredLine.MatchString(body)
+}
+
+// continues reports whether c directly follows prev in the same paragraph
+func continues(prev, c *domain.Comment) bool {
+	return c.Range.StartLine == prev.Range.EndLine+1 &&
+		c.Range.StartCol == prev.Range.StartCol &&
+		c.Type == prev.Type &&
+		c.Language == prev.Language &&
+		utils.LineCommentMarker(c.SourceText) == utils.LineCommentMarker(prev.SourceText)
+}
+
+// mergeLineComments combines a run of line comments into one
+func mergeLineComments(group []*domain.Comment) *domain.Comment {
+	marker := utils.LineCommentMarker(group[0].SourceText)
+	bodies := make([]string, len(group))
+	for i, c := range group {
+		bodies[i] = strings.TrimPrefix(c.SourceText, marker)
+	}
+
+	last := group[len(group)-1]
+	merged := *group[0]
+	merged.SourceText = marker + " " + utils.JoinParagraph(bodies)
+	merged.Range.EndLine = last.Range.EndLine
+	merged.Range.EndCol = last.Range.EndCol
+	if merged.ID != "" {
+		merged.ID = utils.GenerateCommentID(&merged)
+	}
+	return &merged
+}
@@ -0,0 +1,74 @@
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core"
+	"github.com/studyzy/codei18n/core/config"
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+const groupingSrc = `package p
+
+// Run starts the worker pool and blocks
+// until every job has been processed or
+// the context is cancelled.
+//
+// Steps:
+// - drain the queue
+// - stop workers
+func Run() {
+	x := 1 // trailing comment
+	// first note
+	// second note
+	//go:noinline
+	// after directive
+	_ = x
+}
+`
+
+func TestGroupLineComments(t *testing.T) {
+	Configure(&config.Config{CommentGrouping: GroupingParagraph})
+	defer Configure(&config.Config{})
+
+	adapter, err := GetAdapter("p.go")
+	require.NoError(t, err)
+	_, ok := adapter.(core.CommentFormatter)
+	assert.True(t, ok, "the grouping wrapper must keep the formatter hook")
+
+	comments, err := adapter.Parse("p.go", []byte(groupingSrc))
+	require.NoError(t, err)
+
+	var texts []string
+	for _, c := range comments {
+		texts = append(texts, c.SourceText)
+	}
+	assert.Equal(t, []string{
+		"// Run starts the worker pool and blocks until every job has been processed or the context is cancelled.",
+		"//",
+		"// Steps:",
+		"// - drain the queue",
+		"// - stop workers",
+		"// trailing comment",
+		"// first note second note",
+		"//go:noinline",
+		"// after directive",
+	}, texts)
+
+	paragraph := comments[0]
+	assert.Equal(t, domain.TextRange{StartLine: 3, StartCol: 1, EndLine: 5, EndCol: 29}, paragraph.Range)
+	assert.Equal(t, "p.Run", paragraph.Symbol)
+	assert.Equal(t, domain.TextRange{StartLine: 12, StartCol: 2, EndLine: 13, EndCol: 16}, comments[6].Range)
+}
+
+func TestGroupLineComments_DisabledByDefault(t *testing.T) {
+	adapter, err := GetAdapter("p.go")
+	require.NoError(t, err)
+
+	comments, err := adapter.Parse("p.go", []byte(groupingSrc))
+	require.NoError(t, err)
+	assert.Len(t, comments, 12)
+}
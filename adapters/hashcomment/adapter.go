@@ -0,0 +1,165 @@
+package hashcomment
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/bash"
+	"github.com/smacker/go-tree-sitter/dockerfile"
+	"github.com/smacker/go-tree-sitter/toml"
+	"github.com/smacker/go-tree-sitter/yaml"
+
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// Supported language identifiers
+const (
+	Shell      = "shell"
+	YAML       = "yaml"
+	TOML       = "toml"
+	Dockerfile = "dockerfile"
+	Makefile   = "makefile"
+	Dotenv     = "dotenv"
+)
+
+// Adapter implements the LanguageAdapter interface for the family of languages
+// that use # line comments: shell scripts, YAML, TOML, Dockerfile, Makefile and .env files.
+// Languages with a Tree-sitter grammar are parsed with it; Makefile and .env files
+// are handled by a line scanner.
+type Adapter struct {
+	language string
+	grammar  *sitter.Language
+}
+
+// NewAdapter creates a new adapter for the given language identifier
+func NewAdapter(lang string) *Adapter {
+	return &Adapter{
+		language: lang,
+		grammar:  grammarFor(lang),
+	}
+}
+
+// grammarFor returns the Tree-sitter grammar for a language, or nil if the language
+// is handled by the line scanner.
+func grammarFor(lang string) *sitter.Language {
+	switch lang {
+	case Shell:
+		return bash.GetLanguage()
+	case YAML:
+		return yaml.GetLanguage()
+	case TOML:
+		return toml.GetLanguage()
+	case Dockerfile:
+		return dockerfile.GetLanguage()
+	default:
+		return nil
+	}
+}
+
+// Language returns the language identifier
+func (a *Adapter) Language() string {
+	return a.language
+}
+
+// Parse parses the provided source code and extracts # comments.
+// If src is nil, the source code is read from the file path.
+func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	if src == nil {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src = data
+	}
+
+	if a.grammar == nil {
+		return a.scanComments(src, file), nil
+	}
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(a.grammar)
+
+	tree, err := parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	return a.extractComments(tree.RootNode(), src, file)
+}
+
+// extractComments walks the query matches and builds domain comments
+func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string) ([]*domain.Comment, error) {
+	q, err := sitter.NewQuery([]byte(commentQuery), a.grammar)
+	if err != nil {
+		return nil, err
+	}
+	defer q.Close()
+
+	qc := sitter.NewQueryCursor()
+	defer qc.Close()
+
+	qc.Exec(q, root)
+
+	lines := strings.Split(string(src), "\n")
+	var comments []*domain.Comment
+
+	for {
+		m, ok := qc.NextMatch()
+		if !ok {
+			break
+		}
+
+		for _, c := range m.Captures {
+			node := c.Node
+			text := strings.TrimRight(node.Content(src), " \t\r")
+			if isEmptyComment(text) || isShebang(node, text) {
+				continue
+			}
+
+			start := node.StartPoint()
+			symbol := a.resolveSymbol(root, src, lines, int(start.Row), int(start.Column))
+			comments = append(comments, a.newComment(file, symbol, int(start.Row), int(start.Column), text))
+		}
+	}
+
+	return comments, nil
+}
+
+// newComment builds a single-line comment starting at the given 0-based row and byte column
+func (a *Adapter) newComment(file, symbol string, row, col int, text string) *domain.Comment {
+	comment := &domain.Comment{
+		File:     file,
+		Language: a.language,
+		Symbol:   symbol,
+		Range: domain.TextRange{
+			StartLine: row + 1,
+			StartCol:  col + 1,
+			EndLine:   row + 1,
+			EndCol:    col + len(text) + 1,
+		},
+		SourceText: text,
+		Type:       domain.CommentTypeLine,
+	}
+	if a.language == Dockerfile && parserDirective.MatchString(text) {
+		comment.Type = domain.CommentTypeDirective
+	}
+	comment.ID = utils.GenerateCommentID(comment)
+	return comment
+}
+
+// parserDirective matches Dockerfile parser directives such as "# syntax=docker/dockerfile:1"
+var parserDirective = regexp.MustCompile(`^#\s*(?:syntax|escape|check)\s*=`)
+
+// isShebang reports whether the comment is the interpreter line of a script
+func isShebang(node *sitter.Node, text string) bool {
+	return node.StartPoint().Row == 0 && strings.HasPrefix(text, "#!")
+}
+
+func isEmptyComment(text string) bool {
+	return strings.TrimSpace(strings.TrimLeft(text, "#")) == ""
+}
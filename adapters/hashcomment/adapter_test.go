@@ -0,0 +1,148 @@
+package hashcomment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestAdapter_Parse_Shell(t *testing.T) {
+	src := `#!/bin/bash
+# 部署脚本
+set -e
+
+deploy() {
+  # 推送镜像
+  docker push "$IMAGE" # 行尾注释
+}
+`
+	adapter := NewAdapter(Shell)
+	assert.Equal(t, "shell", adapter.Language())
+
+	comments, err := adapter.Parse("scripts/deploy.sh", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+
+	assert.Equal(t, "# 部署脚本", comments[0].SourceText)
+	assert.Equal(t, "", comments[0].Symbol)
+	assert.Equal(t, domain.CommentTypeLine, comments[0].Type)
+
+	assert.Equal(t, "# 推送镜像", comments[1].SourceText)
+	assert.Equal(t, "deploy", comments[1].Symbol)
+	assert.Equal(t, 6, comments[1].Range.StartLine)
+	assert.Equal(t, 3, comments[1].Range.StartCol)
+
+	assert.Equal(t, "# 行尾注释", comments[2].SourceText)
+	assert.Equal(t, "deploy", comments[2].Symbol)
+	assert.NotEmpty(t, comments[2].ID)
+}
+
+func TestAdapter_Parse_YAML(t *testing.T) {
+	src := `# CI 配置
+jobs:
+  build:
+    # 构建步骤
+    steps:
+      - run: make # 编译
+  "deploy-prod":
+    # 仅主干
+    if: main
+`
+	adapter := NewAdapter(YAML)
+	comments, err := adapter.Parse(".github/workflows/ci.yml", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 4)
+
+	assert.Equal(t, "jobs", comments[0].Symbol)
+	assert.Equal(t, "jobs.build.steps", comments[1].Symbol)
+	assert.Equal(t, "jobs.build.steps.run", comments[2].Symbol)
+	assert.Equal(t, "# 编译", comments[2].SourceText)
+	assert.Equal(t, "jobs.deploy-prod.if", comments[3].Symbol)
+}
+
+func TestAdapter_Parse_TOML(t *testing.T) {
+	src := `# 标题
+title = "demo"
+
+[server]
+# 监听端口
+port = 8080 # 默认端口
+
+[[db.replicas]]
+# 副本地址
+host = "10.0.0.1"
+`
+	adapter := NewAdapter(TOML)
+	comments, err := adapter.Parse("config.toml", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 4)
+
+	assert.Equal(t, "title", comments[0].Symbol)
+	assert.Equal(t, "server.port", comments[1].Symbol)
+	assert.Equal(t, "server.port", comments[2].Symbol)
+	assert.Equal(t, "db.replicas.host", comments[3].Symbol)
+}
+
+func TestAdapter_Parse_Dockerfile(t *testing.T) {
+	src := `# 编译阶段
+FROM golang:1.22 AS build
+# 编译二进制
+RUN go build ./...
+
+FROM alpine
+# 拷贝产物
+COPY --from=build /app /app
+`
+	adapter := NewAdapter(Dockerfile)
+	comments, err := adapter.Parse("Dockerfile", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+
+	assert.Equal(t, "build.FROM", comments[0].Symbol)
+	assert.Equal(t, "build.RUN", comments[1].Symbol)
+	assert.Equal(t, "alpine.COPY", comments[2].Symbol)
+}
+
+func TestAdapter_Parse_Makefile(t *testing.T) {
+	src := "# 编译器\nCC := gcc # 默认编译器\n\n# 构建目标\nbuild: deps\n\t# 生成二进制\n\techo '#not a comment'\n\n" +
+		"url = http://example.com/\\#anchor\n"
+	adapter := NewAdapter(Makefile)
+	comments, err := adapter.Parse("Makefile", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 4)
+
+	assert.Equal(t, "# 编译器", comments[0].SourceText)
+	assert.Equal(t, "CC", comments[0].Symbol)
+
+	assert.Equal(t, "# 默认编译器", comments[1].SourceText)
+	assert.Equal(t, "CC", comments[1].Symbol)
+	assert.Equal(t, 11, comments[1].Range.StartCol)
+
+	assert.Equal(t, "build", comments[2].Symbol)
+
+	assert.Equal(t, "# 生成二进制", comments[3].SourceText)
+	assert.Equal(t, "build", comments[3].Symbol)
+	assert.Equal(t, 2, comments[3].Range.StartCol)
+}
+
+func TestAdapter_Parse_Dotenv(t *testing.T) {
+	src := `# 数据库地址
+DB_HOST=localhost # 本地开发
+DB_PASS="p#ss # not a comment"
+COLOR=#fff
+export API_KEY= # 必填
+#
+`
+	adapter := NewAdapter(Dotenv)
+	comments, err := adapter.Parse(".env.example", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+
+	assert.Equal(t, "DB_HOST", comments[0].Symbol)
+	assert.Equal(t, "# 本地开发", comments[1].SourceText)
+	assert.Equal(t, "DB_HOST", comments[1].Symbol)
+	assert.Equal(t, "API_KEY", comments[2].Symbol)
+}
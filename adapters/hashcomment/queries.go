@@ -0,0 +1,7 @@
+package hashcomment
+
+// commentQuery is the Tree-sitter query to extract comments.
+// The bash, YAML, TOML and Dockerfile grammars all expose # comments as (comment) nodes.
+const commentQuery = `
+(comment) @comment
+`
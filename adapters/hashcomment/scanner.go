@@ -0,0 +1,137 @@
+package hashcomment
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+var (
+	// makeVariable matches variable assignments such as "CC := gcc" or "export GOFLAGS ?= -mod=mod"
+	makeVariable = regexp.MustCompile(`^\s*(?:export\s+|override\s+)?([A-Za-z_][\w.-]*)\s*(?:::=|:=|\?=|\+=|!=|=)`)
+	// makeRule matches rule lines such as "build test: deps" and captures the first target
+	makeRule = regexp.MustCompile(`^([^\s:=#][^:=#]*?)\s*::?(?:[^=]|$)`)
+	// envVariable matches assignments such as "export DB_HOST=localhost"
+	envVariable = regexp.MustCompile(`^\s*(?:export\s+)?([A-Za-z_][\w.]*)\s*=`)
+)
+
+// scanComments extracts # comments from languages without a Tree-sitter grammar
+// (Makefile and .env files) by scanning the source line by line.
+func (a *Adapter) scanComments(src []byte, file string) []*domain.Comment {
+	lines := strings.Split(string(src), "\n")
+	var comments []*domain.Comment
+
+	target := ""
+	for row, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		var col int
+		var symbol string
+		switch a.language {
+		case Makefile:
+			if name := makeEntity(line); name != "" && makeRule.MatchString(line) && !makeVariable.MatchString(line) {
+				target = name
+			}
+			col = makeCommentStart(line, target != "")
+			symbol = target
+			if col >= 0 && !strings.HasPrefix(line, "\t") {
+				symbol = a.scanSymbol(lines, row, col)
+			}
+		default:
+			col = envCommentStart(line)
+			if col >= 0 {
+				symbol = a.scanSymbol(lines, row, col)
+			}
+		}
+		if col < 0 {
+			continue
+		}
+
+		text := strings.TrimRight(line[col:], " \t")
+		if isEmptyComment(text) {
+			continue
+		}
+		comments = append(comments, a.newComment(file, symbol, row, col, text))
+	}
+
+	return comments
+}
+
+// scanSymbol returns the variable or target name of the line a comment documents
+func (a *Adapter) scanSymbol(lines []string, row, col int) string {
+	anchor := anchorRow(lines, row, col)
+	if anchor < 0 {
+		return ""
+	}
+	line := strings.TrimRight(lines[anchor], "\r")
+	if a.language == Makefile {
+		return makeEntity(line)
+	}
+	if m := envVariable.FindStringSubmatch(line); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// makeEntity returns the variable name or first target defined on a Makefile line
+func makeEntity(line string) string {
+	if strings.HasPrefix(line, "\t") {
+		return ""
+	}
+	if m := makeVariable.FindStringSubmatch(line); m != nil {
+		return m[1]
+	}
+	if m := makeRule.FindStringSubmatch(line); m != nil {
+		return strings.Fields(m[1])[0]
+	}
+	return ""
+}
+
+// makeCommentStart returns the byte offset of the comment on a Makefile line, or -1.
+// Recipe lines are passed to the shell, so only whole-line comments are recognized there;
+// elsewhere make treats any unescaped # as the start of a comment.
+func makeCommentStart(line string, inRule bool) int {
+	if inRule && strings.HasPrefix(line, "\t") {
+		if strings.HasPrefix(strings.TrimLeft(line, " \t"), "#") {
+			return indentOf(line)
+		}
+		return -1
+	}
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] != '\\') {
+			return i
+		}
+	}
+	return -1
+}
+
+// envCommentStart returns the byte offset of the comment on a .env line, or -1.
+// A # inside a quoted value, or not preceded by whitespace, is part of the value.
+func envCommentStart(line string) int {
+	if strings.HasPrefix(strings.TrimLeft(line, " \t"), "#") {
+		return indentOf(line)
+	}
+
+	eq := strings.Index(line, "=")
+	if eq < 0 {
+		return -1
+	}
+	i := eq + 1
+	for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+		i++
+	}
+	if i < len(line) && (line[i] == '"' || line[i] == '\'') {
+		end := strings.IndexByte(line[i+1:], line[i])
+		if end < 0 {
+			return -1
+		}
+		i += end + 2
+	}
+	for ; i < len(line); i++ {
+		if line[i] == '#' && i > 0 && (line[i-1] == ' ' || line[i-1] == '\t') {
+			return i
+		}
+	}
+	return -1
+}
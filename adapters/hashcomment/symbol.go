@@ -0,0 +1,161 @@
+package hashcomment
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// anchorRow returns the 0-based row of the code line a comment documents.
+// Trailing comments document their own line; leading comments document the next
+// line that is neither blank nor a comment. It returns -1 if there is no such line.
+func anchorRow(lines []string, row, col int) int {
+	if row < len(lines) && col <= len(lines[row]) && strings.TrimSpace(lines[row][:col]) != "" {
+		return row
+	}
+	for r := row + 1; r < len(lines); r++ {
+		t := strings.TrimSpace(lines[r])
+		if t != "" && !strings.HasPrefix(t, "#") {
+			return r
+		}
+	}
+	return -1
+}
+
+// indentOf returns the byte offset of the first non-blank character of a line
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// resolveSymbol computes the symbol path for a comment at the given position.
+// Comments in these languages are attached inconsistently by the grammars (YAML in
+// particular places them between keys and values), so the owner is located by position:
+// the innermost node at the anchor position, then its ancestors.
+func (a *Adapter) resolveSymbol(root *sitter.Node, src []byte, lines []string, row, col int) string {
+	anchor := anchorRow(lines, row, col)
+	if anchor < 0 {
+		return ""
+	}
+
+	// Trailing comments resolve from the last code character before them,
+	// leading comments from the first code character of the next line.
+	column := indentOf(lines[anchor])
+	if anchor == row {
+		column = len(strings.TrimRight(lines[row][:col], " \t")) - 1
+	}
+	p := sitter.Point{Row: uint32(anchor), Column: uint32(column)}
+	node := root.NamedDescendantForPointRange(p, p)
+	if node == nil {
+		return ""
+	}
+
+	switch a.language {
+	case Shell:
+		return shellSymbol(node, src)
+	case YAML:
+		return yamlSymbol(node, src)
+	case TOML:
+		return tomlSymbol(node, src)
+	case Dockerfile:
+		return dockerfileSymbol(node, src)
+	}
+	return ""
+}
+
+// shellSymbol returns the enclosing function names, e.g. "deploy.cleanup"
+func shellSymbol(node *sitter.Node, src []byte) string {
+	var parts []string
+	for n := node; n != nil; n = n.Parent() {
+		if n.Type() != "function_definition" {
+			continue
+		}
+		if name := n.ChildByFieldName("name"); name != nil {
+			parts = append([]string{name.Content(src)}, parts...)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// yamlSymbol returns the key path of the mapping entry, e.g. "jobs.build.steps"
+func yamlSymbol(node *sitter.Node, src []byte) string {
+	var parts []string
+	for n := node; n != nil; n = n.Parent() {
+		if n.Type() != "block_mapping_pair" && n.Type() != "flow_pair" {
+			continue
+		}
+		if key := n.ChildByFieldName("key"); key != nil {
+			parts = append([]string{unquote(key.Content(src))}, parts...)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// tomlSymbol returns the table path plus key, e.g. "server.port"
+func tomlSymbol(node *sitter.Node, src []byte) string {
+	var parts []string
+	for n := node; n != nil; n = n.Parent() {
+		switch n.Type() {
+		case "pair", "table", "table_array_element":
+			if key := n.NamedChild(0); key != nil {
+				parts = append([]string{tomlKey(key, src)}, parts...)
+			}
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// tomlKey normalizes a bare, quoted or dotted key
+func tomlKey(key *sitter.Node, src []byte) string {
+	if key.Type() != "dotted_key" {
+		return unquote(key.Content(src))
+	}
+	var parts []string
+	for i := 0; i < int(key.NamedChildCount()); i++ {
+		parts = append(parts, tomlKey(key.NamedChild(i), src))
+	}
+	return strings.Join(parts, ".")
+}
+
+// dockerfileSymbol returns the build stage and instruction keyword, e.g. "build.RUN".
+// The stage is the alias of the governing FROM instruction, or its image name.
+func dockerfileSymbol(node *sitter.Node, src []byte) string {
+	inst := node
+	for inst != nil && !strings.HasSuffix(inst.Type(), "_instruction") {
+		inst = inst.Parent()
+	}
+	if inst == nil {
+		return ""
+	}
+
+	keyword := strings.ToUpper(strings.TrimSuffix(inst.Type(), "_instruction"))
+
+	from := inst
+	for from != nil && from.Type() != "from_instruction" {
+		from = from.PrevNamedSibling()
+	}
+	if from == nil {
+		return keyword
+	}
+	return stageName(from, src) + "." + keyword
+}
+
+// stageName returns the alias of a FROM instruction, falling back to the image name
+func stageName(from *sitter.Node, src []byte) string {
+	if alias := from.ChildByFieldName("as"); alias != nil {
+		return alias.Content(src)
+	}
+	if spec := from.NamedChild(0); spec != nil {
+		if name := spec.ChildByFieldName("name"); name != nil {
+			return name.Content(src)
+		}
+	}
+	return ""
+}
+
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
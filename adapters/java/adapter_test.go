@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/studyzy/codei18n/core/domain"
 )
@@ -41,7 +42,7 @@ public class Calculator {
 	assert.Len(t, comments, 1)
 
 	assert.Equal(t, "// 计算两个数的和", comments[0].SourceText)
-	assert.Equal(t, "com.example.Calculator#add", comments[0].Symbol)
+	assert.Equal(t, "com.example.Calculator#add(int,int)", comments[0].Symbol)
 	assert.Equal(t, domain.CommentTypeLine, comments[0].Type)
 }
 
@@ -148,7 +149,7 @@ public class Calculator {
 
 	// Method Javadoc
 	assert.Contains(t, comments[1].SourceText, "/**")
-	assert.Equal(t, "com.example.Calculator#add", comments[1].Symbol)
+	assert.Equal(t, "com.example.Calculator#add(int,int)", comments[1].Symbol)
 	assert.Equal(t, domain.CommentTypeDoc, comments[1].Type)
 }
 
@@ -234,3 +235,96 @@ public class Broken {
 	}
 	assert.True(t, found, "应该能提取到注释，即使代码有语法错误")
 }
+
+func TestAdapter_Parse_NestedSymbols(t *testing.T) {
+	src := `package com.example;
+
+public class Outer {
+    @Override
+    // 字符串表示
+    public String toString() { return ""; }
+
+    // 按名称查找
+    @Deprecated
+    public void find(String name) {}
+
+    // 按编号查找
+    public void find(java.util.List<String> ids, int... limits) {}
+
+    static {
+        // 静态初始化
+    }
+
+    // 内部类
+    class Inner {
+        // 内部方法
+        void run() {}
+    }
+
+    Runnable first = new Runnable() {
+        public void run() {
+            Runnable r = () -> {
+                // lambda 中的注释
+            };
+        }
+    };
+
+    Runnable second = new Runnable() {
+        // 第二个匿名类
+        public void run() {}
+    };
+
+    private int count = 0; // 计数器
+}
+
+record Point(
+    // 横坐标
+    int x,
+    int y) {
+    Point {
+        // 校验参数
+    }
+}
+
+@interface Label {
+    // 标签文本
+    String value() default "";
+}
+
+enum Color {
+    // 红色
+    RED,
+    GREEN {
+        // 常量类体
+        void paint() {}
+    };
+}
+`
+	comments, err := NewAdapter().Parse("Outer.java", []byte(src))
+	require.NoError(t, err)
+
+	symbols := make(map[string]string)
+	for _, c := range comments {
+		symbols[c.SourceText] = c.Symbol
+	}
+
+	expected := map[string]string{
+		"// 字符串表示":       "com.example.Outer#toString()",
+		"// 按名称查找":       "com.example.Outer#find(String)",
+		"// 按编号查找":       "com.example.Outer#find(java.util.List,int...)",
+		"// 静态初始化":       "com.example.Outer#<clinit>",
+		"// 内部类":         "com.example.Outer$Inner",
+		"// 内部方法":        "com.example.Outer$Inner#run()",
+		"// lambda 中的注释": "com.example.Outer$1#run()",
+		"// 第二个匿名类":      "com.example.Outer$2#run()",
+		"// 计数器":         "com.example.Outer#count",
+		"// 横坐标":         "com.example.Point#x",
+		"// 校验参数":        "com.example.Point#Point(int,int)",
+		"// 标签文本":        "com.example.Label#value()",
+		"// 红色":          "com.example.Color#RED",
+		"// 常量类体":        "com.example.Color$1#paint()",
+	}
+	for text, symbol := range expected {
+		assert.Equal(t, symbol, symbols[text], text)
+	}
+}
@@ -1,70 +1,79 @@
 package java
 
 import (
+	"strconv"
 	"strings"
 
 	sitter "github.com/smacker/go-tree-sitter"
 )
 
-// resolveSymbol 通过查找注释节点的下一个命名兄弟节点来确定符号路径
-// 对于 Java，符号路径格式为：package.ClassName#methodName 或 package.ClassName#fieldName
+// resolveSymbol 确定注释所属的符号路径
+// 符号路径格式为：package.Outer$Inner#method(String,int) 或 package.Outer#field
+// 行尾注释绑定到同一行的声明；前置注释跳过其他注释与注解，绑定到其后的声明；
+// 其余注释绑定到所在的最内层声明
 func resolveSymbol(node *sitter.Node, src []byte, packageName string) string {
-	// 查找下一个命名兄弟节点以附加注释
+	parent := node.Parent()
+
+	// @Override 与方法声明之间的注释位于 modifiers 内部
+	if parent != nil && parent.Type() == "modifiers" {
+		return getSymbolPath(parent.Parent(), src, packageName)
+	}
+
 	next := node.NextNamedSibling()
-	if next == nil {
-		// 如果没有下一个兄弟节点，可能是文件级注释
-		return ""
+	if prev := node.PrevNamedSibling(); prev != nil && !isComment(prev) &&
+		prev.EndPoint().Row == node.StartPoint().Row &&
+		(next == nil || next.StartPoint().Row != node.EndPoint().Row) {
+		if isDeclaration(prev, src) {
+			return getSymbolPath(prev, src, packageName)
+		}
+		return getSymbolPath(parent, src, packageName)
 	}
 
-	return getSymbolPath(next, src, packageName)
+	// 跳过注解与其他注释，找到真正被注释的声明
+	for next != nil && (isComment(next) || isAnnotation(next)) {
+		next = next.NextNamedSibling()
+	}
+	if next != nil && isDeclaration(next, src) {
+		return getSymbolPath(next, src, packageName)
+	}
+
+	if parent == nil {
+		return ""
+	}
+	return getSymbolPath(parent, src, packageName)
 }
 
 // getSymbolPath 构建给定节点的完整符号路径
+// 从节点向上遍历：类型名以 $ 连接（匿名类按出现顺序编号，如 Outer$1），
+// 最内层类型之内的第一个成员以 # 连接；lambda 与代码块不产生路径段
 func getSymbolPath(node *sitter.Node, src []byte, packageName string) string {
-	// 构建符号路径：从当前节点向上遍历，收集类名、方法名等
-	var parts []string
+	var types []string
 	var memberName string
-	var isMember bool
-
-	current := node
-	for current != nil {
-		switch current.Type() {
-		case "class_declaration", "interface_declaration", "enum_declaration":
-			// 顶级类型声明
-			name := getChildContent(current, "name", src)
-			if name != "" {
-				parts = append([]string{name}, parts...)
-			}
-		case "method_declaration", "constructor_declaration":
-			// 方法或构造器
-			if current == node {
-				memberName = getChildContent(current, "name", src)
-				isMember = true
-			}
-		case "field_declaration":
-			// 字段声明
-			if current == node {
-				// 字段声明可能包含多个变量声明器
-				declarator := current.ChildByFieldName("declarator")
-				if declarator != nil && declarator.Type() == "variable_declarator" {
-					memberName = getChildContent(declarator, "name", src)
-					isMember = true
-				}
-			}
+	seenType := false
+
+	for current := node; current != nil; current = current.Parent() {
+		if name := typeName(current, src); name != "" {
+			types = append([]string{name}, types...)
+			seenType = true
+			continue
+		}
+		// 局部类与匿名类内部的注释不属于外层方法
+		if !seenType && memberName == "" {
+			memberName = memberSignature(current, src)
 		}
-		current = current.Parent()
 	}
 
-	// 构建最终路径
 	var result []string
 	if packageName != "" {
 		result = append(result, packageName)
 	}
-	result = append(result, parts...)
+	if len(types) > 0 {
+		result = append(result, strings.Join(types, "$"))
+	}
 
-	// 如果是成员（方法或字段），使用 # 连接
-	if isMember && memberName != "" {
-		if len(result) > 0 {
+	// 如果是成员（方法、字段、初始化块等），使用 # 连接
+	if memberName != "" {
+		if len(types) > 0 {
 			result[len(result)-1] = result[len(result)-1] + "#" + memberName
 		} else {
 			result = append(result, memberName)
@@ -74,6 +83,178 @@ func getSymbolPath(node *sitter.Node, src []byte, packageName string) string {
 	return strings.Join(result, ".")
 }
 
+// isDeclaration 判断节点是否为可以拥有注释的声明
+func isDeclaration(node *sitter.Node, src []byte) bool {
+	return typeName(node, src) != "" || memberSignature(node, src) != ""
+}
+
+// typeName 返回类型声明贡献的路径段；匿名类返回其编号，非类型节点返回空字符串
+func typeName(node *sitter.Node, src []byte) string {
+	switch node.Type() {
+	case "class_declaration", "interface_declaration", "enum_declaration",
+		"record_declaration", "annotation_type_declaration":
+		return getChildContent(node, "name", src)
+	case "class_body":
+		if isAnonymousBody(node) {
+			return strconv.Itoa(anonymousIndex(node))
+		}
+	}
+	return ""
+}
+
+// memberSignature 返回成员在路径中的名称，方法与构造器附带参数类型以区分重载
+func memberSignature(node *sitter.Node, src []byte) string {
+	switch node.Type() {
+	case "method_declaration", "constructor_declaration":
+		return getChildContent(node, "name", src) + parameterTypes(node.ChildByFieldName("parameters"), src)
+	case "compact_constructor_declaration":
+		// 紧凑构造器的参数即记录组件
+		record := node.Parent()
+		if record != nil {
+			record = record.Parent()
+		}
+		if record == nil || record.Type() != "record_declaration" {
+			return getChildContent(node, "name", src)
+		}
+		return getChildContent(node, "name", src) + parameterTypes(record.ChildByFieldName("parameters"), src)
+	case "annotation_type_element_declaration":
+		return getChildContent(node, "name", src) + "()"
+	case "field_declaration", "constant_declaration":
+		// 字段声明可能包含多个变量声明器，取第一个
+		declarator := node.ChildByFieldName("declarator")
+		if declarator != nil && declarator.Type() == "variable_declarator" {
+			return getChildContent(declarator, "name", src)
+		}
+	case "enum_constant":
+		return getChildContent(node, "name", src)
+	case "formal_parameter":
+		// 记录组件：record Point(int x, int y)
+		if params := node.Parent(); params != nil && params.Parent() != nil &&
+			params.Parent().Type() == "record_declaration" {
+			return getChildContent(node, "name", src)
+		}
+	case "static_initializer":
+		return "<clinit>"
+	case "block":
+		// 实例初始化块直接位于类体中
+		if parent := node.Parent(); parent != nil && parent.Type() == "class_body" {
+			return "<init>"
+		}
+	}
+	return ""
+}
+
+// parameterTypes 将参数列表渲染为 (String,int...) 形式，泛型参数被擦除
+func parameterTypes(params *sitter.Node, src []byte) string {
+	if params == nil {
+		return "()"
+	}
+	var types []string
+	for i := 0; i < int(params.NamedChildCount()); i++ {
+		param := params.NamedChild(i)
+		switch param.Type() {
+		case "formal_parameter":
+			typ := eraseType(getChildContent(param, "type", src))
+			if dims := param.ChildByFieldName("dimensions"); dims != nil {
+				typ += eraseType(dims.Content(src))
+			}
+			types = append(types, typ)
+		case "spread_parameter":
+			for j := 0; j < int(param.NamedChildCount()); j++ {
+				if child := param.NamedChild(j); child.Type() != "modifiers" && child.Type() != "variable_declarator" {
+					types = append(types, eraseType(child.Content(src))+"...")
+					break
+				}
+			}
+		}
+	}
+	return "(" + strings.Join(types, ",") + ")"
+}
+
+// eraseType 去除类型中的泛型参数与空白
+func eraseType(typ string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range typ {
+		switch {
+		case r == '<':
+			depth++
+		case r == '>':
+			depth--
+		case depth == 0 && r != ' ' && r != '\t' && r != '\n' && r != '\r':
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// isAnonymousBody 判断类体是否属于匿名类（new T() { ... } 或带类体的枚举常量）
+func isAnonymousBody(node *sitter.Node) bool {
+	parent := node.Parent()
+	return parent != nil && (parent.Type() == "object_creation_expression" || parent.Type() == "enum_constant")
+}
+
+// isClassScope 判断节点是否开启新的类作用域（具名类型声明或匿名类体）
+func isClassScope(node *sitter.Node) bool {
+	switch node.Type() {
+	case "class_declaration", "interface_declaration", "enum_declaration",
+		"record_declaration", "annotation_type_declaration":
+		return true
+	case "class_body":
+		return isAnonymousBody(node)
+	}
+	return false
+}
+
+// enclosingScope 返回节点外层最近的类作用域
+func enclosingScope(node *sitter.Node) *sitter.Node {
+	for curr := node.Parent(); curr != nil; curr = curr.Parent() {
+		if isClassScope(curr) {
+			return curr
+		}
+	}
+	return nil
+}
+
+// anonymousIndex 按源码顺序为同一外层类中的匿名类编号，与 javac 的 Outer$1 命名一致
+func anonymousIndex(body *sitter.Node) int {
+	scope := enclosingScope(body)
+	if scope == nil {
+		return 1
+	}
+
+	index := 0
+	found := false
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		for i := 0; i < int(n.NamedChildCount()) && !found; i++ {
+			child := n.NamedChild(i)
+			if child.Type() == "class_body" && isAnonymousBody(child) {
+				index++
+				if child.Equal(body) {
+					found = true
+					return
+				}
+			}
+			// 嵌套类型中的匿名类单独编号
+			if isClassScope(child) {
+				continue
+			}
+			walk(child)
+		}
+	}
+	walk(scope)
+	return index
+}
+
+func isComment(node *sitter.Node) bool {
+	return node.Type() == "line_comment" || node.Type() == "block_comment"
+}
+
+func isAnnotation(node *sitter.Node) bool {
+	return node.Type() == "marker_annotation" || node.Type() == "annotation"
+}
+
 // getChildContent 获取节点指定字段的内容
 func getChildContent(node *sitter.Node, fieldName string, src []byte) string {
 	child := node.ChildByFieldName(fieldName)
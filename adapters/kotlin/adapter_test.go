@@ -0,0 +1,92 @@
+package kotlin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestAdapter_Parse_Symbols(t *testing.T) {
+	src := `package com.example.app
+
+/** 顶层函数 */
+fun topLevel(x: Int): Int = x
+
+// 单例
+object Registry {
+    // 注册
+    fun register() {}
+}
+
+/**
+ * 用户
+ */
+@Serializable
+data class User(val name: String) {
+    // 年龄
+    val age: Int = 0
+
+    companion object {
+        // 工厂方法
+        fun create(): User = User("a")
+    }
+}
+
+// 扩展函数
+fun String.shout(): String = this.uppercase()
+
+enum class Color {
+    // 红色
+    RED,
+    GREEN
+}
+`
+	adapter := NewAdapter()
+	assert.Equal(t, "kotlin", adapter.Language())
+
+	comments, err := adapter.Parse("User.kt", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 8)
+
+	symbols := make(map[string]string)
+	for _, c := range comments {
+		assert.Equal(t, "kotlin", c.Language)
+		assert.NotEmpty(t, c.ID)
+		symbols[c.SourceText] = c.Symbol
+	}
+
+	assert.Equal(t, "com.example.app.topLevel", symbols["/** 顶层函数 */"])
+	assert.Equal(t, "com.example.app.Registry", symbols["// 单例"])
+	assert.Equal(t, "com.example.app.Registry.register", symbols["// 注册"])
+	assert.Equal(t, "com.example.app.User", symbols["/**\n * 用户\n */"])
+	assert.Equal(t, "com.example.app.User.age", symbols["// 年龄"])
+	assert.Equal(t, "com.example.app.User.Companion.create", symbols["// 工厂方法"])
+	assert.Equal(t, "com.example.app.String.shout", symbols["// 扩展函数"])
+	assert.Equal(t, "com.example.app.Color.RED", symbols["// 红色"])
+
+	assert.Equal(t, domain.CommentTypeDoc, comments[0].Type)
+	assert.Equal(t, domain.CommentTypeLine, comments[1].Type)
+}
+
+func TestAdapter_Parse_StableIDs(t *testing.T) {
+	// Two functions with the same comment text must not collide
+	src := `package demo
+
+// 关闭
+fun String.close() {}
+
+// 关闭
+fun Int.close() {}
+`
+	adapter := NewAdapter()
+	comments, err := adapter.Parse("ext.kt", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+
+	assert.Equal(t, "demo.String.close", comments[0].Symbol)
+	assert.Equal(t, "demo.Int.close", comments[1].Symbol)
+	assert.NotEqual(t, comments[0].ID, comments[1].ID)
+}
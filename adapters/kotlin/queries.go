@@ -0,0 +1,8 @@
+package kotlin
+
+// kotlinCommentQuery is the Tree-sitter query to extract comments.
+// It matches line comments (//) and multiline comments (/* */ and KDoc /** */).
+const kotlinCommentQuery = `
+(line_comment) @comment
+(multiline_comment) @comment
+`
@@ -0,0 +1,131 @@
+package kotlin
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// findOwnerNode finds the node a comment belongs to.
+// Trailing comments bind to the enclosing scope, other comments bind to the
+// next declaration (skipping comments). A comment that the grammar attaches to
+// the package header or import list belongs to the declaration after it.
+func findOwnerNode(node *sitter.Node) *sitter.Node {
+	if prev := node.PrevNamedSibling(); prev != nil && prev.EndPoint().Row == node.StartPoint().Row {
+		return node.Parent()
+	}
+
+	for curr := node; curr != nil; curr = curr.Parent() {
+		next := curr.NextNamedSibling()
+		for next != nil && isComment(next) {
+			next = next.NextNamedSibling()
+		}
+		if next != nil {
+			return next
+		}
+		parent := curr.Parent()
+		if parent == nil || !isHeader(parent) {
+			break
+		}
+	}
+	return node.Parent()
+}
+
+// resolveSymbolPath builds a path like "com.example.User.Companion.create" by walking up the tree.
+// Extension functions are qualified by their receiver type, e.g. "com.example.String.shout".
+func resolveSymbolPath(node *sitter.Node, src []byte, packageName string) string {
+	var parts []string
+	for curr := node; curr != nil; curr = curr.Parent() {
+		if name := declarationName(curr, src); name != "" {
+			parts = append([]string{name}, parts...)
+		}
+	}
+
+	if packageName != "" {
+		parts = append([]string{packageName}, parts...)
+	}
+	return strings.Join(parts, ".")
+}
+
+// declarationName returns the name contributed by a declaration node to the symbol path.
+// The Kotlin grammar has no field names, so names are located by child node type.
+func declarationName(node *sitter.Node, src []byte) string {
+	switch node.Type() {
+	case "class_declaration", "object_declaration", "type_alias":
+		return childContentByType(node, "type_identifier", src)
+	case "companion_object":
+		if name := childContentByType(node, "type_identifier", src); name != "" {
+			return name
+		}
+		return "Companion"
+	case "function_declaration":
+		return functionName(node, src)
+	case "property_declaration":
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			child := node.NamedChild(i)
+			if child.Type() == "variable_declaration" {
+				return childContentByType(child, "simple_identifier", src)
+			}
+		}
+	case "enum_entry":
+		return childContentByType(node, "simple_identifier", src)
+	case "secondary_constructor":
+		return "constructor"
+	case "anonymous_initializer":
+		return "init"
+	}
+	return ""
+}
+
+// functionName returns the function name, prefixed by the receiver type for extension functions
+func functionName(node *sitter.Node, src []byte) string {
+	receiver := ""
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		switch child.Type() {
+		case "user_type", "nullable_type", "function_type", "parenthesized_type":
+			receiver = child.Content(src)
+		case "simple_identifier":
+			if receiver != "" {
+				return receiver + "." + child.Content(src)
+			}
+			return child.Content(src)
+		case "function_value_parameters":
+			return ""
+		}
+	}
+	return ""
+}
+
+// extractPackageName returns the package declared in the package header
+func extractPackageName(root *sitter.Node, src []byte) string {
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		child := root.NamedChild(i)
+		if child.Type() == "package_header" {
+			return childContentByType(child, "identifier", src)
+		}
+	}
+	return ""
+}
+
+func isComment(node *sitter.Node) bool {
+	return node.Type() == "line_comment" || node.Type() == "multiline_comment"
+}
+
+func isHeader(node *sitter.Node) bool {
+	switch node.Type() {
+	case "package_header", "import_list", "import_header", "file_annotation":
+		return true
+	}
+	return false
+}
+
+func childContentByType(node *sitter.Node, nodeType string, src []byte) string {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if child.Type() == nodeType {
+			return child.Content(src)
+		}
+	}
+	return ""
+}
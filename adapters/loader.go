@@ -0,0 +1,46 @@
+package adapters
+
+import (
+	"errors"
+
+	"github.com/studyzy/codei18n/adapters/declarative"
+	"github.com/studyzy/codei18n/adapters/external"
+	"github.com/studyzy/codei18n/core/config"
+)
+
+// LoadLanguages registers the query-driven languages described in dir,
+// normally .codei18n/languages. Invalid descriptors are reported but do not
+// prevent the valid ones from being registered.
+func LoadLanguages(dir string) error {
+	descriptors, err := declarative.LoadDir(dir)
+	errs := []error{err}
+	for _, d := range descriptors {
+		adapter, err := declarative.NewAdapter(d)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		Register(adapter, d.Extensions...)
+		if len(d.FileNames) > 0 {
+			RegisterFileName(d.Language, d.FileNames...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LoadExternalAdapters registers the adapters implemented by external executables.
+// None of them is run here: each handshakes on its first parse. Adapters configured
+// without a language or extensions are reported but do not prevent the others from
+// being registered.
+func LoadExternalAdapters(configs []config.ExternalAdapter) error {
+	var errs []error
+	for _, cfg := range configs {
+		adapter, err := external.NewAdapter(cfg)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		Register(adapter, adapter.Extensions()...)
+	}
+	return errors.Join(errs...)
+}
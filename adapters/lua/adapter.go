@@ -0,0 +1,168 @@
+package lua
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/lua"
+
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// Adapter implements the LanguageAdapter interface for Lua.
+// It extracts -- line comments, --[[ ]] block comments and --- documentation lines.
+type Adapter struct {
+	parser *sitter.Parser
+}
+
+// NewAdapter creates a new Lua adapter instance
+func NewAdapter() *Adapter {
+	p := sitter.NewParser()
+	p.SetLanguage(lua.GetLanguage())
+	return &Adapter{parser: p}
+}
+
+// Language returns the language identifier ("lua")
+func (a *Adapter) Language() string {
+	return "lua"
+}
+
+// Parse parses the provided Lua source code and extracts comments.
+// If src is nil, the source code is read from the file path.
+func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	if src == nil {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src = data
+	}
+
+	tree, err := a.parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	return a.extractComments(tree.RootNode(), src, file)
+}
+
+// extractComments walks the query matches and builds domain comments
+func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string) ([]*domain.Comment, error) {
+	q, err := sitter.NewQuery([]byte(luaCommentQuery), lua.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+	defer q.Close()
+
+	qc := sitter.NewQueryCursor()
+	defer qc.Close()
+
+	qc.Exec(q, root)
+
+	var comments []*domain.Comment
+
+	for {
+		m, ok := qc.NextMatch()
+		if !ok {
+			break
+		}
+
+		for _, c := range m.Captures {
+			node := c.Node
+
+			if q.CaptureNameForId(c.Index) == "doc" {
+				comments = append(comments, docComments(node, src, file)...)
+				continue
+			}
+
+			start, text := contentStart(node, src)
+			cType := domain.CommentTypeLine
+			if isLongComment(text) {
+				cType = domain.CommentTypeBlock
+			} else if strings.TrimSpace(strings.TrimLeft(text, "-")) == "" {
+				continue
+			}
+
+			comments = append(comments, newComment(file, resolveSymbol(findOwnerNode(node, start.Row), src), start, text, cType))
+		}
+	}
+
+	return comments, nil
+}
+
+// contentStart returns the position of the first non-blank character of a node and its
+// trimmed content. The grammar includes the whitespace before a node in its span.
+func contentStart(node *sitter.Node, src []byte) (sitter.Point, string) {
+	content := node.Content(src)
+	trimmed := strings.TrimLeft(content, " \t\r\n")
+
+	start := node.StartPoint()
+	for _, ch := range content[:len(content)-len(trimmed)] {
+		if ch == '\n' {
+			start.Row++
+			start.Column = 0
+		} else {
+			start.Column++
+		}
+	}
+	return start, strings.TrimRight(trimmed, " \t\r\n")
+}
+
+// docComments splits a documentation node into one doc comment per --- line,
+// all bound to the documented statement.
+func docComments(node *sitter.Node, src []byte, file string) []*domain.Comment {
+	symbol := resolveSymbol(node.Parent(), src)
+	start := node.StartPoint()
+
+	var comments []*domain.Comment
+	for i, line := range strings.Split(node.Content(src), "\n") {
+		text := strings.TrimRight(strings.TrimLeft(line, " \t"), " \t\r")
+		if !strings.HasPrefix(text, "---") || strings.TrimSpace(strings.TrimLeft(text, "-")) == "" {
+			continue
+		}
+
+		point := sitter.Point{Row: start.Row + uint32(i), Column: uint32(len(line) - len(strings.TrimLeft(line, " \t")))}
+		if i == 0 {
+			point.Column += start.Column
+		}
+		comments = append(comments, newComment(file, symbol, point, text, domain.CommentTypeDoc))
+	}
+	return comments
+}
+
+// newComment builds a comment starting at the given point; the end is derived from the text
+func newComment(file, symbol string, start sitter.Point, text string, cType domain.CommentType) *domain.Comment {
+	endCol := int(start.Column) + len(text)
+	if i := strings.LastIndex(text, "\n"); i >= 0 {
+		endCol = len(text) - i - 1
+	}
+
+	comment := &domain.Comment{
+		File:     file,
+		Language: "lua",
+		Symbol:   symbol,
+		Range: domain.TextRange{
+			StartLine: int(start.Row) + 1,
+			StartCol:  int(start.Column) + 1,
+			EndLine:   int(start.Row) + strings.Count(text, "\n") + 1,
+			EndCol:    endCol + 1,
+		},
+		SourceText: text,
+		Type:       cType,
+	}
+	comment.ID = utils.GenerateCommentID(comment)
+	return comment
+}
+
+// isLongComment reports whether the comment uses long brackets: --[[ ]] or --[==[ ]==]
+func isLongComment(text string) bool {
+	if !strings.HasPrefix(text, "--[") {
+		return false
+	}
+	rest := strings.TrimLeft(text[3:], "=")
+	return strings.HasPrefix(rest, "[")
+}
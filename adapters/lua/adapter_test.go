@@ -0,0 +1,84 @@
+package lua
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestAdapter_Parse_Functions(t *testing.T) {
+	src := `local M = {}
+
+-- 两数相加
+function M.add(a, b)
+  return a + b -- 行尾注释
+end
+
+--[[ 多行
+说明 ]]
+function M:method() end
+
+--[==[ 辅助函数 ]==]
+local function helper() end
+
+-- 匿名函数
+M.baz = function() end
+`
+	adapter := NewAdapter()
+	assert.Equal(t, "lua", adapter.Language())
+
+	comments, err := adapter.Parse("lib/m.lua", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 5)
+
+	assert.Equal(t, "-- 两数相加", comments[0].SourceText)
+	assert.Equal(t, "M.add", comments[0].Symbol)
+	assert.Equal(t, domain.CommentTypeLine, comments[0].Type)
+
+	assert.Equal(t, "M.add", comments[1].Symbol)
+
+	assert.Equal(t, "--[[ 多行\n说明 ]]", comments[2].SourceText)
+	assert.Equal(t, "M:method", comments[2].Symbol)
+	assert.Equal(t, domain.CommentTypeBlock, comments[2].Type)
+	assert.Equal(t, 9, comments[2].Range.EndLine)
+
+	assert.Equal(t, "helper", comments[3].Symbol)
+	assert.Equal(t, domain.CommentTypeBlock, comments[3].Type)
+
+	assert.Equal(t, "M.baz", comments[4].Symbol)
+}
+
+func TestAdapter_Parse_DocLines(t *testing.T) {
+	src := `--- 两数相加
+---@param a number 第一个数
+---@return number
+local function add(a) return a end
+
+function f()
+  --- 内部说明
+  local function g() end
+end
+`
+	adapter := NewAdapter()
+	comments, err := adapter.Parse("add.lua", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 4)
+
+	assert.Equal(t, "--- 两数相加", comments[0].SourceText)
+	assert.Equal(t, "add", comments[0].Symbol)
+	assert.Equal(t, domain.CommentTypeDoc, comments[0].Type)
+
+	assert.Equal(t, "---@param a number 第一个数", comments[1].SourceText)
+	assert.Equal(t, 2, comments[1].Range.StartLine)
+	assert.Equal(t, 1, comments[1].Range.StartCol)
+
+	assert.Equal(t, "---@return number", comments[2].SourceText)
+
+	assert.Equal(t, "--- 内部说明", comments[3].SourceText)
+	assert.Equal(t, "g", comments[3].Symbol)
+	assert.Equal(t, 7, comments[3].Range.StartLine)
+	assert.Equal(t, 3, comments[3].Range.StartCol)
+}
@@ -0,0 +1,9 @@
+package lua
+
+// luaCommentQuery is the Tree-sitter query to extract comments.
+// The grammar parses --- LuaDoc/EmmyLua lines as emmy_documentation attached to the
+// following statement; all other -- and --[[ ]] comments are (comment) nodes.
+const luaCommentQuery = `
+(comment) @comment
+(emmy_documentation) @doc
+`
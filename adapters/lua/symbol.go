@@ -0,0 +1,48 @@
+package lua
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// findOwnerNode finds the node a comment documents.
+// Trailing comments bind to the node on the same line, leading comments to the next
+// statement (skipping other comments), and comments at the end of a block to the enclosing scope.
+// row is the line the comment text starts on.
+func findOwnerNode(node *sitter.Node, row uint32) *sitter.Node {
+	if prev := node.PrevNamedSibling(); prev != nil && prev.Type() != "comment" &&
+		prev.EndPoint().Row == row {
+		return prev
+	}
+
+	next := node.NextNamedSibling()
+	for next != nil && next.Type() == "comment" {
+		next = next.NextNamedSibling()
+	}
+	if next != nil {
+		return next
+	}
+	return node.Parent()
+}
+
+// resolveSymbol returns the name of the innermost function containing node, as written
+// in the source: "helper", "M.add" or "M:method". Functions assigned to variables
+// ("M.baz = function() end") use the variable name.
+func resolveSymbol(node *sitter.Node, src []byte) string {
+	for n := node; n != nil; n = n.Parent() {
+		switch n.Type() {
+		case "function_statement":
+			if name := n.ChildByFieldName("name"); name != nil {
+				return strings.TrimSpace(name.Content(src))
+			}
+		case "variable_declaration":
+			value := n.ChildByFieldName("value")
+			name := n.ChildByFieldName("name")
+			if value != nil && name != nil && value.Type() == "function" {
+				return strings.TrimSpace(name.Content(src))
+			}
+		}
+	}
+	return ""
+}
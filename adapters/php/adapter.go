@@ -0,0 +1,114 @@
+package php
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/php"
+
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// Adapter implements the LanguageAdapter interface for PHP.
+// /** */ blocks are PHPDoc doc comments; //, # and /* */ are line and block comments.
+type Adapter struct {
+	parser *sitter.Parser
+}
+
+// NewAdapter creates a new PHP adapter instance
+func NewAdapter() *Adapter {
+	p := sitter.NewParser()
+	p.SetLanguage(php.GetLanguage())
+	return &Adapter{parser: p}
+}
+
+// Language returns the language identifier ("php")
+func (a *Adapter) Language() string {
+	return "php"
+}
+
+// Parse parses the provided PHP source code and extracts comments.
+// If src is nil, the source code is read from the file path.
+func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	if src == nil {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src = data
+	}
+
+	tree, err := a.parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	return a.extractComments(tree.RootNode(), src, file)
+}
+
+// extractComments walks the query matches and builds domain comments
+func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string) ([]*domain.Comment, error) {
+	q, err := sitter.NewQuery([]byte(phpCommentQuery), php.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+	defer q.Close()
+
+	qc := sitter.NewQueryCursor()
+	defer qc.Close()
+
+	qc.Exec(q, root)
+
+	var comments []*domain.Comment
+
+	for {
+		m, ok := qc.NextMatch()
+		if !ok {
+			break
+		}
+
+		for _, c := range m.Captures {
+			node := c.Node
+			text := strings.TrimRight(node.Content(src), " \t\r\n")
+
+			cType := domain.CommentTypeLine
+			if strings.HasPrefix(text, "/**") {
+				cType = domain.CommentTypeDoc
+			} else if strings.HasPrefix(text, "/*") {
+				cType = domain.CommentTypeBlock
+			}
+
+			comment := &domain.Comment{
+				File:     file,
+				Language: "php",
+				Symbol:   resolveSymbolPath(findOwnerNode(node), src),
+				Range: domain.TextRange{
+					StartLine: int(node.StartPoint().Row) + 1,
+					StartCol:  int(node.StartPoint().Column) + 1,
+					EndLine:   int(node.StartPoint().Row) + strings.Count(text, "\n") + 1,
+					EndCol:    endColumn(node, text) + 1,
+				},
+				SourceText: text,
+				Type:       cType,
+			}
+			comment.ID = utils.GenerateCommentID(comment)
+
+			comments = append(comments, comment)
+		}
+	}
+
+	return comments, nil
+}
+
+// endColumn returns the 0-based end column of the trimmed comment text.
+// The grammar includes the line break in // and # comment nodes.
+func endColumn(node *sitter.Node, text string) int {
+	if i := strings.LastIndex(text, "\n"); i >= 0 {
+		return len(text) - i - 1
+	}
+	return int(node.StartPoint().Column) + len(text)
+}
@@ -0,0 +1,80 @@
+package php
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestAdapter_Parse_Class(t *testing.T) {
+	src := `<?php
+namespace App\Models;
+
+/**
+ * 用户模型
+ * @property string $name
+ */
+class User extends Model
+{
+    // 最大长度
+    const MAX = 10;
+    # 用户名
+    private $name; // 行尾注释
+
+    /** 保存用户 */
+    #[Override]
+    public function save(): bool { return true; }
+}
+
+/* 辅助函数 */
+function helper() {}
+`
+	adapter := NewAdapter()
+	assert.Equal(t, "php", adapter.Language())
+
+	comments, err := adapter.Parse("app/Models/User.php", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 6)
+
+	assert.Equal(t, `App\Models\User`, comments[0].Symbol)
+	assert.Equal(t, domain.CommentTypeDoc, comments[0].Type)
+	assert.Equal(t, 4, comments[0].Range.StartLine)
+	assert.Equal(t, 7, comments[0].Range.EndLine)
+	assert.Equal(t, 4, comments[0].Range.EndCol)
+
+	assert.Equal(t, "// 最大长度", comments[1].SourceText)
+	assert.Equal(t, `App\Models\User::MAX`, comments[1].Symbol)
+	assert.Equal(t, domain.CommentTypeLine, comments[1].Type)
+
+	assert.Equal(t, "# 用户名", comments[2].SourceText)
+	assert.Equal(t, `App\Models\User::$name`, comments[2].Symbol)
+
+	assert.Equal(t, "// 行尾注释", comments[3].SourceText)
+	assert.Equal(t, `App\Models\User::$name`, comments[3].Symbol)
+	assert.Equal(t, 13, comments[3].Range.StartLine)
+	assert.Equal(t, 35, comments[3].Range.EndCol)
+
+	assert.Equal(t, `App\Models\User::save`, comments[4].Symbol)
+
+	assert.Equal(t, `App\Models\helper`, comments[5].Symbol)
+	assert.Equal(t, domain.CommentTypeBlock, comments[5].Type)
+}
+
+func TestAdapter_Parse_BracedNamespace(t *testing.T) {
+	src := `<?php
+namespace Shop {
+    interface Cart {
+        // 添加商品
+        public function add($item);
+    }
+}
+`
+	adapter := NewAdapter()
+	comments, err := adapter.Parse("Cart.php", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, `Shop\Cart::add`, comments[0].Symbol)
+}
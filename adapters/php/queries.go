@@ -0,0 +1,6 @@
+package php
+
+// phpCommentQuery is the Tree-sitter query to extract //, #, /* */ and PHPDoc comments
+const phpCommentQuery = `
+(comment) @comment
+`
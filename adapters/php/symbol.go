@@ -0,0 +1,114 @@
+package php
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// findOwnerNode finds the node a comment documents.
+// Trailing comments bind to the declaration on the same line, leading comments to the next
+// declaration (skipping other comments), and comments at the end of a body to the enclosing scope.
+func findOwnerNode(node *sitter.Node) *sitter.Node {
+	if prev := node.PrevNamedSibling(); prev != nil && prev.Type() != "comment" &&
+		prev.EndPoint().Row == node.StartPoint().Row {
+		return prev
+	}
+
+	next := node.NextNamedSibling()
+	for next != nil && next.Type() == "comment" {
+		next = next.NextNamedSibling()
+	}
+	if next != nil {
+		return next
+	}
+	return node.Parent()
+}
+
+// resolveSymbolPath builds the symbol in PHP notation: "App\Models\User" for classes,
+// "App\Models\User::save" for methods, "App\Models\User::$name" for properties,
+// "App\Models\User::MAX" for constants and "App\Models\helper" for functions.
+func resolveSymbolPath(node *sitter.Node, src []byte) string {
+	var types []string
+	member := ""
+
+	for n := node; n != nil; n = n.Parent() {
+		switch n.Type() {
+		case "method_declaration", "function_definition", "enum_case":
+			if member == "" {
+				member = fieldContent(n, "name", src)
+			}
+		case "property_declaration":
+			if member == "" {
+				member = firstDescendantContent(n, "variable_name", src)
+			}
+		case "const_declaration":
+			if member == "" {
+				member = firstDescendantContent(n, "name", src)
+			}
+		case "class_declaration", "interface_declaration", "trait_declaration", "enum_declaration":
+			types = append([]string{fieldContent(n, "name", src)}, types...)
+		}
+	}
+
+	prefix := namespaceOf(node, src)
+	if len(types) == 0 {
+		return joinNamespace(prefix, member)
+	}
+
+	symbol := joinNamespace(prefix, strings.Join(types, `\`))
+	if member != "" {
+		symbol += "::" + member
+	}
+	return symbol
+}
+
+// namespaceOf returns the namespace in effect at node: either an enclosing braced
+// namespace block or the closest preceding "namespace X;" statement.
+func namespaceOf(node *sitter.Node, src []byte) string {
+	top := node
+	for top.Parent() != nil {
+		if top.Parent().Type() == "namespace_definition" {
+			return fieldContent(top.Parent(), "name", src)
+		}
+		if top.Parent().Type() == "program" {
+			break
+		}
+		top = top.Parent()
+	}
+
+	for n := top; n != nil; n = n.PrevNamedSibling() {
+		if n.Type() == "namespace_definition" {
+			return fieldContent(n, "name", src)
+		}
+	}
+	return ""
+}
+
+func joinNamespace(ns, name string) string {
+	if ns == "" || name == "" {
+		return ns + name
+	}
+	return ns + `\` + name
+}
+
+func fieldContent(n *sitter.Node, field string, src []byte) string {
+	if child := n.ChildByFieldName(field); child != nil {
+		return child.Content(src)
+	}
+	return ""
+}
+
+// firstDescendantContent returns the content of the first descendant of the given type
+func firstDescendantContent(n *sitter.Node, kind string, src []byte) string {
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		child := n.NamedChild(i)
+		if child.Type() == kind {
+			return child.Content(src)
+		}
+		if content := firstDescendantContent(child, kind, src); content != "" {
+			return content
+		}
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+package protobuf
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/protobuf"
+
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// Adapter implements the LanguageAdapter interface for Protocol Buffers schemas.
+// Comments are bound to messages, fields, enums, enum values, services and RPCs.
+type Adapter struct {
+	parser *sitter.Parser
+}
+
+// NewAdapter creates a new Protocol Buffers adapter instance
+func NewAdapter() *Adapter {
+	p := sitter.NewParser()
+	p.SetLanguage(protobuf.GetLanguage())
+	return &Adapter{parser: p}
+}
+
+// Language returns the language identifier ("protobuf")
+func (a *Adapter) Language() string {
+	return "protobuf"
+}
+
+// Parse parses the provided .proto source and extracts comments.
+// If src is nil, the source code is read from the file path.
+func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	if src == nil {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src = data
+	}
+
+	tree, err := a.parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	return a.extractComments(tree.RootNode(), src, file)
+}
+
+// extractComments walks the query matches and builds domain comments
+func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string) ([]*domain.Comment, error) {
+	q, err := sitter.NewQuery([]byte(protoCommentQuery), protobuf.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+	defer q.Close()
+
+	qc := sitter.NewQueryCursor()
+	defer qc.Close()
+
+	qc.Exec(q, root)
+
+	pkg := packageName(root, src)
+	var comments []*domain.Comment
+
+	for {
+		m, ok := qc.NextMatch()
+		if !ok {
+			break
+		}
+
+		for _, c := range m.Captures {
+			node := c.Node
+			text := node.Content(src)
+
+			cType := domain.CommentTypeLine
+			if strings.HasPrefix(text, "/**") {
+				cType = domain.CommentTypeDoc
+			} else if strings.HasPrefix(text, "/*") {
+				cType = domain.CommentTypeBlock
+			}
+
+			comment := &domain.Comment{
+				File:     file,
+				Language: "protobuf",
+				Symbol:   resolveSymbolPath(findOwnerNode(node), src, pkg),
+				Range: domain.TextRange{
+					StartLine: int(node.StartPoint().Row) + 1,
+					StartCol:  int(node.StartPoint().Column) + 1,
+					EndLine:   int(node.EndPoint().Row) + 1,
+					EndCol:    int(node.EndPoint().Column) + 1,
+				},
+				SourceText: text,
+				Type:       cType,
+			}
+			comment.ID = utils.GenerateCommentID(comment)
+
+			comments = append(comments, comment)
+		}
+	}
+
+	return comments, nil
+}
@@ -0,0 +1,84 @@
+package protobuf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestAdapter_Parse_Service(t *testing.T) {
+	src := `syntax = "proto3";
+
+// 计费服务定义
+package acme.billing.v1;
+
+// 计费服务
+service Billing {
+  // 扣款
+  rpc Charge(ChargeRequest) returns (ChargeResponse); // 同步调用
+}
+`
+	adapter := NewAdapter()
+	assert.Equal(t, "protobuf", adapter.Language())
+
+	comments, err := adapter.Parse("billing.proto", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 4)
+
+	assert.Equal(t, "acme.billing.v1", comments[0].Symbol)
+	assert.Equal(t, "acme.billing.v1.Billing", comments[1].Symbol)
+	assert.Equal(t, "acme.billing.v1.Billing/Charge", comments[2].Symbol)
+	assert.Equal(t, "// 同步调用", comments[3].SourceText)
+	assert.Equal(t, "acme.billing.v1.Billing/Charge", comments[3].Symbol)
+	assert.Equal(t, domain.CommentTypeLine, comments[3].Type)
+	assert.NotEmpty(t, comments[3].ID)
+}
+
+func TestAdapter_Parse_Messages(t *testing.T) {
+	src := `syntax = "proto3";
+package shop;
+
+/** 订单 */
+message Order {
+  int64 id = 1; // 订单编号
+  /* 嵌套类型 */
+  message Item {
+    // 商品名称
+    string name = 1;
+  }
+  oneof payment {
+    // 银行卡
+    string card = 2;
+  }
+  // 扩展属性
+  map<string, string> meta = 3;
+  // 末尾注释
+}
+
+enum Status {
+  // 未知状态
+  STATUS_UNSPECIFIED = 0;
+}
+`
+	adapter := NewAdapter()
+	comments, err := adapter.Parse("shop.proto", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 8)
+
+	assert.Equal(t, "shop.Order", comments[0].Symbol)
+	assert.Equal(t, domain.CommentTypeDoc, comments[0].Type)
+
+	assert.Equal(t, "shop.Order.id", comments[1].Symbol)
+
+	assert.Equal(t, "shop.Order.Item", comments[2].Symbol)
+	assert.Equal(t, domain.CommentTypeBlock, comments[2].Type)
+
+	assert.Equal(t, "shop.Order.Item.name", comments[3].Symbol)
+	assert.Equal(t, "shop.Order.card", comments[4].Symbol)
+	assert.Equal(t, "shop.Order.meta", comments[5].Symbol)
+	assert.Equal(t, "shop.Order", comments[6].Symbol)
+	assert.Equal(t, "shop.Status.STATUS_UNSPECIFIED", comments[7].Symbol)
+}
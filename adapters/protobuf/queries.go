@@ -0,0 +1,6 @@
+package protobuf
+
+// protoCommentQuery is the Tree-sitter query to extract // and /* */ comments
+const protoCommentQuery = `
+(comment) @comment
+`
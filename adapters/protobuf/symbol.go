@@ -0,0 +1,98 @@
+package protobuf
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// findOwnerNode finds the declaration a comment documents, following protoc conventions:
+// a trailing comment documents the declaration on the same line, a leading comment
+// documents the next declaration (skipping other comments), and a comment at the end
+// of a body documents the enclosing declaration.
+func findOwnerNode(node *sitter.Node) *sitter.Node {
+	if prev := node.PrevNamedSibling(); prev != nil && prev.Type() != "comment" &&
+		prev.EndPoint().Row == node.StartPoint().Row {
+		return prev
+	}
+
+	next := node.NextNamedSibling()
+	for next != nil && next.Type() == "comment" {
+		next = next.NextNamedSibling()
+	}
+	if next != nil {
+		return next
+	}
+	return node.Parent()
+}
+
+// packageName returns the declared package, e.g. "acme.billing.v1"
+func packageName(root *sitter.Node, src []byte) string {
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		child := root.NamedChild(i)
+		if child.Type() != "package" {
+			continue
+		}
+		if ident := child.NamedChild(0); ident != nil {
+			return ident.Content(src)
+		}
+	}
+	return ""
+}
+
+// resolveSymbolPath builds the fully-qualified symbol of a declaration.
+// Types and members are joined with ".", RPCs with "/" as in gRPC method names:
+// "pkg.Message.field", "pkg.Enum.VALUE", "pkg.Service/Method".
+func resolveSymbolPath(node *sitter.Node, src []byte, pkg string) string {
+	var parts []string
+	method := ""
+
+	for n := node; n != nil; n = n.Parent() {
+		name := declarationName(n, src)
+		if name == "" {
+			continue
+		}
+		// oneof members share the scope of the enclosing message
+		if n.Type() == "oneof" && !n.Equal(node) {
+			continue
+		}
+		if n.Type() == "rpc" {
+			method = name
+			continue
+		}
+		parts = append([]string{name}, parts...)
+	}
+
+	if pkg != "" {
+		parts = append([]string{pkg}, parts...)
+	}
+	symbol := strings.Join(parts, ".")
+	if method != "" {
+		symbol += "/" + method
+	}
+	return symbol
+}
+
+// declarationName returns the name of a named declaration, or "" for other nodes
+func declarationName(n *sitter.Node, src []byte) string {
+	switch n.Type() {
+	case "message", "enum", "service", "rpc":
+		// The first named child is message_name, enum_name, service_name or rpc_name
+		if name := n.NamedChild(0); name != nil {
+			return name.Content(src)
+		}
+	case "field", "oneof_field", "map_field", "enum_field", "oneof":
+		return childIdentifier(n, src)
+	}
+	return ""
+}
+
+// childIdentifier returns the first direct identifier child (the declared name)
+func childIdentifier(n *sitter.Node, src []byte) string {
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		if child := n.NamedChild(i); child.Type() == "identifier" {
+			return child.Content(src)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+package python
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/python"
+
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// Adapter implements the LanguageAdapter interface for Python.
+// It uses Tree-sitter to extract # comments and triple-quoted docstrings.
+type Adapter struct {
+	parser *sitter.Parser
+}
+
+// NewAdapter creates a new Python adapter instance
+func NewAdapter() *Adapter {
+	p := sitter.NewParser()
+	p.SetLanguage(python.GetLanguage())
+	return &Adapter{parser: p}
+}
+
+// Language returns the language identifier ("python")
+func (a *Adapter) Language() string {
+	return "python"
+}
+
+// Parse parses the provided Python source code and extracts comments and docstrings.
+// If src is nil, the source code is read from the file path.
+func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	if src == nil {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src = data
+	}
+
+	tree, err := a.parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	return a.extractComments(tree.RootNode(), src, file)
+}
+
+// extractComments walks the query matches and builds domain comments
+func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string) ([]*domain.Comment, error) {
+	q, err := sitter.NewQuery([]byte(pythonCommentQuery), python.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+	defer q.Close()
+
+	qc := sitter.NewQueryCursor()
+	defer qc.Close()
+
+	qc.Exec(q, root)
+
+	module := moduleName(file)
+	var comments []*domain.Comment
+
+	for {
+		m, ok := qc.NextMatch()
+		if !ok {
+			break
+		}
+
+		for _, c := range m.Captures {
+			node := c.Node
+			text := node.Content(src)
+
+			var cType domain.CommentType
+			var symbol string
+
+			switch q.CaptureNameForId(c.Index) {
+			case "docstring":
+				owner := docstringOwner(node)
+				if owner == nil || !isTripleQuoted(text) {
+					continue
+				}
+				cType = domain.CommentTypeDoc
+				symbol = resolveSymbolPath(owner, src, module)
+			default:
+				if isEmptyComment(text) || isShebang(node, text) {
+					continue
+				}
+				cType = domain.CommentTypeLine
+				symbol = resolveSymbolPath(findOwnerNode(node), src, module)
+			}
+
+			comment := &domain.Comment{
+				File:     file,
+				Language: "python",
+				Symbol:   symbol,
+				Range: domain.TextRange{
+					StartLine: int(node.StartPoint().Row) + 1,
+					StartCol:  int(node.StartPoint().Column) + 1,
+					EndLine:   int(node.EndPoint().Row) + 1,
+					EndCol:    int(node.EndPoint().Column) + 1,
+				},
+				SourceText: text,
+				Type:       cType,
+			}
+			comment.ID = utils.GenerateCommentID(comment)
+
+			comments = append(comments, comment)
+		}
+	}
+
+	return comments, nil
+}
+
+// isTripleQuoted reports whether a string literal is triple-quoted (double or single quotes),
+// ignoring string prefixes such as r, u or b.
+func isTripleQuoted(text string) bool {
+	t := strings.TrimLeft(text, "rRuUbBfF")
+	return strings.HasPrefix(t, `"""`) || strings.HasPrefix(t, `'''`)
+}
+
+// isShebang reports whether the comment is the interpreter line of a script
+func isShebang(node *sitter.Node, text string) bool {
+	return node.StartPoint().Row == 0 && strings.HasPrefix(text, "#!")
+}
+
+func isEmptyComment(text string) bool {
+	return strings.TrimSpace(strings.TrimLeft(text, "#")) == ""
+}
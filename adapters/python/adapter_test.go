@@ -0,0 +1,97 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestAdapter_Parse_Docstrings(t *testing.T) {
+	src := `#!/usr/bin/env python
+"""模块文档"""
+
+
+class Server:
+    '''服务器类'''
+
+    def close(self):
+        """关闭连接"""
+        return None
+`
+	adapter := NewAdapter()
+	assert.Equal(t, "python", adapter.Language())
+
+	comments, err := adapter.Parse("pkg/server.py", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+
+	assert.Equal(t, `"""模块文档"""`, comments[0].SourceText)
+	assert.Equal(t, "pkg.server", comments[0].Symbol)
+	assert.Equal(t, domain.CommentTypeDoc, comments[0].Type)
+
+	assert.Equal(t, "'''服务器类'''", comments[1].SourceText)
+	assert.Equal(t, "pkg.server.Server", comments[1].Symbol)
+
+	assert.Equal(t, `"""关闭连接"""`, comments[2].SourceText)
+	assert.Equal(t, "pkg.server.Server.close", comments[2].Symbol)
+	assert.NotEmpty(t, comments[2].ID)
+}
+
+func TestAdapter_Parse_HashComments(t *testing.T) {
+	src := `import os
+
+# 默认超时
+TIMEOUT = 10
+
+
+@decorator
+# 处理请求
+def handle(req):
+    x = 1  # 行尾注释
+    # 返回结果
+    return x
+`
+	adapter := NewAdapter()
+	comments, err := adapter.Parse("app/__init__.py", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 4)
+
+	symbols := make(map[string]string)
+	for _, c := range comments {
+		assert.Equal(t, domain.CommentTypeLine, c.Type)
+		symbols[c.SourceText] = c.Symbol
+	}
+
+	assert.Equal(t, "app", symbols["# 默认超时"])
+	assert.Equal(t, "app.handle", symbols["# 处理请求"])
+	assert.Equal(t, "app.handle", symbols["# 行尾注释"])
+	assert.Equal(t, "app.handle", symbols["# 返回结果"])
+}
+
+func TestAdapter_Parse_NonDocstringStrings(t *testing.T) {
+	src := `def f():
+    x = 1
+    """not a docstring"""
+    return "plain"
+`
+	adapter := NewAdapter()
+	comments, err := adapter.Parse("f.py", []byte(src))
+	require.NoError(t, err)
+	assert.Empty(t, comments)
+}
+
+func TestAdapter_Parse_Range(t *testing.T) {
+	src := "def f():\n    # 注释\n    pass\n"
+	adapter := NewAdapter()
+	comments, err := adapter.Parse("f.py", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+
+	assert.Equal(t, 2, comments[0].Range.StartLine)
+	assert.Equal(t, 5, comments[0].Range.StartCol)
+	assert.Equal(t, 2, comments[0].Range.EndLine)
+	assert.Equal(t, 5+len("# 注释"), comments[0].Range.EndCol)
+}
@@ -0,0 +1,9 @@
+package python
+
+// pythonCommentQuery is the Tree-sitter query to extract comments and docstring candidates.
+// Every string expression statement is captured; docstrings are then filtered by position
+// (first statement of a module, class or function body).
+const pythonCommentQuery = `
+(comment) @comment
+(expression_statement (string) @docstring)
+`
@@ -0,0 +1,121 @@
+package python
+
+import (
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// findOwnerNode finds the node a # comment documents.
+// Leading comments bind to the next statement (skipping other comments),
+// trailing comments and comments at the end of a block bind to the enclosing scope.
+func findOwnerNode(node *sitter.Node) *sitter.Node {
+	if prev := node.PrevSibling(); prev != nil && prev.EndPoint().Row == node.StartPoint().Row {
+		return node.Parent()
+	}
+
+	next := node.NextNamedSibling()
+	for next != nil && next.Type() == "comment" {
+		next = next.NextNamedSibling()
+	}
+	if next != nil {
+		return next
+	}
+	return node.Parent()
+}
+
+// docstringOwner returns the module, class or function documented by the string node,
+// or nil if the string is not in docstring position.
+func docstringOwner(str *sitter.Node) *sitter.Node {
+	stmt := str.Parent()
+	if stmt == nil || stmt.Type() != "expression_statement" || stmt.NamedChildCount() != 1 {
+		return nil
+	}
+
+	container := stmt.Parent()
+	if container == nil || firstStatement(container) != stmt {
+		return nil
+	}
+
+	switch container.Type() {
+	case "module":
+		return container
+	case "block":
+		def := container.Parent()
+		if def != nil && (def.Type() == "class_definition" || def.Type() == "function_definition") {
+			return def
+		}
+	}
+	return nil
+}
+
+// firstStatement returns the first named child that is not a comment
+func firstStatement(node *sitter.Node) *sitter.Node {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if child.Type() != "comment" {
+			return child
+		}
+	}
+	return nil
+}
+
+// resolveSymbolPath builds a path like "pkg.module.Class.method" by walking up the tree.
+// Decorated definitions resolve to the definition they wrap.
+func resolveSymbolPath(node *sitter.Node, src []byte, module string) string {
+	if node != nil && node.Type() == "decorated_definition" {
+		if def := node.ChildByFieldName("definition"); def != nil {
+			node = def
+		}
+	}
+
+	var parts []string
+	for curr := node; curr != nil; curr = curr.Parent() {
+		switch curr.Type() {
+		case "class_definition", "function_definition":
+			if name := getChildContent(curr, "name", src); name != "" {
+				parts = append([]string{name}, parts...)
+			}
+		}
+	}
+
+	if module != "" {
+		parts = append([]string{module}, parts...)
+	}
+	return strings.Join(parts, ".")
+}
+
+// moduleName derives the dotted module path from the file path.
+// "pkg/module.py" becomes "pkg.module" and "pkg/__init__.py" becomes "pkg".
+// Absolute paths only contribute their base name.
+func moduleName(file string) string {
+	if file == "" {
+		return ""
+	}
+	path := filepath.ToSlash(filepath.Clean(file))
+	if filepath.IsAbs(file) {
+		path = filepath.Base(path)
+	}
+	path = strings.TrimSuffix(path, filepath.Ext(path))
+
+	var parts []string
+	for _, p := range strings.Split(path, "/") {
+		if p == "" || p == "." || p == ".." {
+			continue
+		}
+		parts = append(parts, p)
+	}
+	if len(parts) > 0 && parts[len(parts)-1] == "__init__" {
+		parts = parts[:len(parts)-1]
+	}
+	return strings.Join(parts, ".")
+}
+
+func getChildContent(node *sitter.Node, fieldName string, src []byte) string {
+	child := node.ChildByFieldName(fieldName)
+	if child != nil {
+		return child.Content(src)
+	}
+	return ""
+}
@@ -1,36 +1,300 @@
 package adapters
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 
-	"github.com/studyzy/codei18n/adapters/golang"
-	"github.com/studyzy/codei18n/adapters/java"
-	"github.com/studyzy/codei18n/adapters/rust"
-	"github.com/studyzy/codei18n/adapters/typescript"
 	"github.com/studyzy/codei18n/core"
+	"github.com/studyzy/codei18n/core/config"
 )
 
-// GetAdapter returns the appropriate LanguageAdapter for the given file
+// sniffLimit is the number of leading bytes inspected when detecting a language from content
+const sniffLimit = 512
+
+// Factory creates the adapter used for a single lookup.
+// Tree-sitter parsers are not safe for concurrent use, so built-in adapters are created per lookup.
+type Factory func() core.LanguageAdapter
+
+// Sniffer inspects the first bytes of a file and returns a language identifier, or "" if unsure
+type Sniffer func(head []byte) string
+
+// namePattern maps a case-insensitive base name glob such as "dockerfile.*" to a language
+type namePattern struct {
+	pattern  string
+	language string
+}
+
+// registry holds everything GetAdapter uses to pick an adapter for a file
+type registry struct {
+	mu           sync.RWMutex
+	factories    map[string]Factory  // language -> factory
+	extensions   map[string][]string // ".h" -> languages, most recent registration last
+	names        []namePattern
+	interpreters map[string]string // "python3" -> "python"
+	sniffers     []Sniffer
+	// cfg holds the adapter settings of the project configuration
+	cfg config.Config
+}
+
+var defaultRegistry = &registry{
+	factories:    make(map[string]Factory),
+	extensions:   make(map[string][]string),
+	interpreters: make(map[string]string),
+}
+
+// Register adds an adapter instance for the given extensions (".rb", ".rake").
+// The adapter is shared by all lookups, so it must be safe for reuse.
+// An extension may belong to several languages; the most recent registration is the default
+// and the languageOverrides config selects another.
+func Register(adapter core.LanguageAdapter, extensions ...string) {
+	RegisterFactory(adapter.Language(), func() core.LanguageAdapter { return adapter }, extensions...)
+}
+
+// RegisterFactory adds a language whose adapter is created by factory on every lookup
+func RegisterFactory(language string, factory Factory, extensions ...string) {
+	r := defaultRegistry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.factories[language] = factory
+	for _, ext := range extensions {
+		ext = normalizeExtension(ext)
+		langs := r.extensions[ext]
+		for i, l := range langs {
+			if l == language {
+				langs = append(langs[:i], langs[i+1:]...)
+				break
+			}
+		}
+		r.extensions[ext] = append(langs, language)
+	}
+}
+
+// RegisterFileName maps base name globs (case-insensitive, e.g. "makefile", ".env.*") to a registered language
+func RegisterFileName(language string, patterns ...string) {
+	r := defaultRegistry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, p := range patterns {
+		r.names = append(r.names, namePattern{pattern: strings.ToLower(p), language: language})
+	}
+}
+
+// RegisterInterpreter maps shebang interpreters (e.g. "bash", "python3") to a registered language
+func RegisterInterpreter(language string, interpreters ...string) {
+	r := defaultRegistry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range interpreters {
+		r.interpreters[name] = language
+	}
+}
+
+// RegisterSniffer adds a content sniffer consulted for files no extension or name matches
+func RegisterSniffer(s Sniffer) {
+	r := defaultRegistry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sniffers = append(r.sniffers, s)
+}
+
+// Configure applies the adapter settings of the project configuration, such as
+// sqlCommentClauses, languageOverrides and commentGrouping. Lookups made afterwards use them.
+func Configure(cfg *config.Config) {
+	r := defaultRegistry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cfg = *cfg
+}
+
+// settings returns the configuration passed to Configure
+func settings() config.Config {
+	r := defaultRegistry
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cfg
+}
+
+// Languages returns the identifiers of all registered languages, sorted
+func Languages() []string {
+	r := defaultRegistry
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	langs := make([]string, 0, len(r.factories))
+	for l := range r.factories {
+		langs = append(langs, l)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// ForLanguage returns an adapter for a registered language identifier
+func ForLanguage(language string) (core.LanguageAdapter, error) {
+	r := defaultRegistry
+	r.mu.RLock()
+	factory, ok := r.factories[language]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown language: %s", language)
+	}
+	return withGrouping(withDirectives(factory())), nil
+}
+
+// GetAdapter returns the appropriate LanguageAdapter for the given file.
+// Config overrides come first, then registered extensions and file names; files that
+// match neither are identified by their shebang or by content sniffing.
 func GetAdapter(filename string) (core.LanguageAdapter, error) {
+	return GetAdapterForContent(filename, nil)
+}
+
+// GetAdapterForContent is like GetAdapter but sniffs the given content instead of reading
+// the file, for sources that do not exist on disk such as stdin
+func GetAdapterForContent(filename string, src []byte) (core.LanguageAdapter, error) {
+	language, err := detectLanguage(filename, src)
+	if err != nil {
+		return nil, err
+	}
+	return ForLanguage(language)
+}
+
+// detectLanguage resolves the language identifier of a file
+func detectLanguage(filename string, src []byte) (string, error) {
 	ext := strings.ToLower(filepath.Ext(filename))
-	switch ext {
-	case ".go":
-		return golang.NewAdapter(), nil
-	case ".rs":
-		return rust.NewRustAdapter(), nil
-	case ".js", ".jsx", ".ts", ".tsx":
-		return typescript.NewAdapter(), nil
-	case ".java":
-		return java.NewAdapter("java"), nil
-	case ".kt":
-		return java.NewAdapter("kotlin"), nil
-	case ".groovy":
-		return java.NewAdapter("groovy"), nil
-	case ".scala":
-		return java.NewAdapter("scala"), nil
-	default:
-		return nil, fmt.Errorf("unsupported file extension: %s", ext)
+	base := strings.ToLower(filepath.Base(filename))
+
+	if lang, ok := overrideLanguage(ext, base); ok {
+		return lang, nil
+	}
+
+	r := defaultRegistry
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if langs := r.extensions[ext]; len(langs) > 0 {
+		return langs[len(langs)-1], nil
+	}
+	for _, n := range r.names {
+		if ok, _ := filepath.Match(n.pattern, base); ok {
+			return n.language, nil
+		}
+	}
+
+	head := src
+	if head == nil {
+		head = readHead(filename)
+	}
+	if lang := r.sniff(head); lang != "" {
+		return lang, nil
+	}
+	return "", fmt.Errorf("unsupported file extension: %s", ext)
+}
+
+// overrideLanguage applies the languageOverrides config. Keys starting with "." are
+// extensions; any other key is a base name glob. Keys are case-insensitive.
+func overrideLanguage(ext, base string) (string, bool) {
+	overrides := settings().LanguageOverrides
+	if len(overrides) == 0 {
+		return "", false
+	}
+	if ext != "" {
+		for key, lang := range overrides {
+			if strings.ToLower(key) == ext {
+				return lang, true
+			}
+		}
+	}
+	for key, lang := range overrides {
+		key = strings.ToLower(key)
+		if strings.HasPrefix(key, ".") && !strings.ContainsAny(key, "*?[") {
+			continue
+		}
+		if ok, _ := filepath.Match(key, base); ok {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// sniff identifies a language from the shebang line or the registered sniffers
+func (r *registry) sniff(head []byte) string {
+	if len(head) == 0 {
+		return ""
+	}
+	if interpreter := shebangInterpreter(head); interpreter != "" {
+		if lang, ok := r.interpreters[interpreter]; ok {
+			return lang
+		}
+		// python3.11 -> python
+		if lang, ok := r.interpreters[strings.TrimRight(interpreter, "0123456789.")]; ok {
+			return lang
+		}
+	}
+	for _, s := range r.sniffers {
+		if lang := s(head); lang != "" {
+			return lang
+		}
+	}
+	return ""
+}
+
+// shebangInterpreter returns the interpreter named by a "#!" line, looking through /usr/bin/env
+func shebangInterpreter(head []byte) string {
+	if !bytes.HasPrefix(head, []byte("#!")) {
+		return ""
+	}
+	line, _, _ := bufio.NewReader(bytes.NewReader(head[2:])).ReadLine()
+	fields := strings.Fields(string(line))
+	if len(fields) == 0 {
+		return ""
+	}
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" {
+		interpreter = ""
+		for _, f := range fields[1:] {
+			// Skip env options (-S) and variable assignments
+			if !strings.HasPrefix(f, "-") && !strings.Contains(f, "=") {
+				interpreter = filepath.Base(f)
+				break
+			}
+		}
+	}
+	return interpreter
+}
+
+// readHead returns the first bytes of a file, or nil if it cannot be read
+func readHead(filename string) []byte {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	buf := make([]byte, sniffLimit)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil
+	}
+	return buf[:n]
+}
+
+// normalizeExtension lower-cases an extension and adds the leading dot if missing
+func normalizeExtension(ext string) string {
+	ext = strings.ToLower(ext)
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
 	}
+	return ext
 }
@@ -0,0 +1,142 @@
+package adapters
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core"
+	"github.com/studyzy/codei18n/core/config"
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+// stubAdapter is a minimal adapter used to exercise registration
+type stubAdapter struct{ lang string }
+
+func (s *stubAdapter) Language() string { return s.lang }
+
+func (s *stubAdapter) Parse(string, []byte) ([]*domain.Comment, error) { return nil, nil }
+
+func TestGetAdapter_Builtins(t *testing.T) {
+	cases := map[string]string{
+		"main.go":          "go",
+		"lib.RS":           "rust",
+		"app.tsx":          "tsx",
+		"lib.mjs":          "javascript",
+		"lib.ts":           "typescript",
+		"App.vue":          "vue",
+		"index.htm":        "html",
+		"util.h":           "c",
+		"Gemfile":          "ruby",
+		"Dockerfile.dev":   "dockerfile",
+		"GNUmakefile":      "makefile",
+		".env.production":  "dotenv",
+		"schema.gql":       "graphql",
+		"db/001_init.sql":  "sql",
+		"ci/.github/a.yml": "yaml",
+	}
+	for file, lang := range cases {
+		a, err := GetAdapter(file)
+		require.NoError(t, err, file)
+		assert.Equal(t, lang, a.Language(), file)
+	}
+
+	_, err := GetAdapter("notes.txt")
+	assert.Error(t, err)
+}
+
+func TestGetAdapter_Sniffing(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	cases := map[string]string{
+		write("deploy", "#!/usr/bin/env bash\necho hi\n"):       "shell",
+		write("manage", "#!/usr/bin/env -S python3.11 -u\n"):    "python",
+		write("serve", "#!/usr/local/bin/node\n"):               "javascript",
+		write("legacy.inc", "<?php\n// helper\n"):               "php",
+		write("build", "#!/usr/bin/make -f\nall:\n\techo ok\n"): "makefile",
+		write("rakelike", "#!/usr/bin/env RUBYOPT=-W0 ruby\n"):  "ruby",
+	}
+	for path, lang := range cases {
+		a, err := GetAdapter(path)
+		require.NoError(t, err, path)
+		assert.Equal(t, lang, a.Language(), path)
+	}
+
+	_, err := GetAdapter(write("README", "plain text\n"))
+	assert.Error(t, err)
+
+	a, err := GetAdapterForContent("-", []byte("#!/bin/sh\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "shell", a.Language())
+}
+
+func TestGetAdapter_ConfigOverrides(t *testing.T) {
+	Configure(&config.Config{LanguageOverrides: map[string]string{
+		".inc":        "php",
+		".H":          "cpp",
+		"Jenkinsfile": "groovy",
+		".xyz":        "cobol",
+	}})
+	defer Configure(&config.Config{})
+
+	for file, lang := range map[string]string{
+		"lib/db.inc":  "php",
+		"src/a.h":     "cpp",
+		"Jenkinsfile": "groovy",
+		"src/a.c":     "c",
+	} {
+		a, err := GetAdapter(file)
+		require.NoError(t, err, file)
+		assert.Equal(t, lang, a.Language(), file)
+	}
+
+	_, err := GetAdapter("x.xyz")
+	assert.ErrorContains(t, err, "unknown language")
+}
+
+// unwrapped returns the registered adapter behind the wrappers added by the registry
+func unwrapped(a core.LanguageAdapter) core.LanguageAdapter {
+	for {
+		switch w := a.(type) {
+		case *directiveAdapter:
+			a = w.LanguageAdapter
+		case *groupingAdapter:
+			a = w.LanguageAdapter
+		default:
+			return a
+		}
+	}
+}
+
+func TestRegister_CustomAdapter(t *testing.T) {
+	stub := &stubAdapter{lang: "teststub"}
+	Register(stub, "stub", ".STUB2")
+	RegisterFileName("teststub", "stubfile")
+	RegisterInterpreter("teststub", "stubby")
+
+	for _, file := range []string{"a.stub", "b.stub2", "Stubfile"} {
+		a, err := GetAdapter(file)
+		require.NoError(t, err, file)
+		assert.Same(t, stub, unwrapped(a), file)
+	}
+
+	a, err := GetAdapterForContent("script", []byte("#!/opt/bin/stubby\n"))
+	require.NoError(t, err)
+	assert.Same(t, stub, unwrapped(a))
+	assert.Contains(t, Languages(), "teststub")
+
+	// The most recent registration of an extension wins
+	other := &stubAdapter{lang: "teststub2"}
+	Register(other, ".stub")
+	a, err = GetAdapter("a.stub")
+	require.NoError(t, err)
+	assert.Same(t, other, unwrapped(a))
+}
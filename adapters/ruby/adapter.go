@@ -0,0 +1,134 @@
+package ruby
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/ruby"
+
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+var (
+	// magicComment matches interpreter pragmas such as "# frozen_string_literal: true",
+	// which are directives and must never be rewritten
+	magicComment = regexp.MustCompile(`^#\s*(?:-\*-\s*)?(?:frozen_string_literal|encoding|coding|warn_indent|shareable_constant_value)\s*:`)
+	// yardTag matches a YARD tag line such as "# @param name [String] the name"
+	yardTag = regexp.MustCompile(`^#\s*@[a-z_]+`)
+)
+
+// Adapter implements the LanguageAdapter interface for Ruby.
+// It extracts # comments and =begin/=end blocks; comments carrying YARD tags are doc comments.
+type Adapter struct {
+	parser *sitter.Parser
+}
+
+// NewAdapter creates a new Ruby adapter instance
+func NewAdapter() *Adapter {
+	p := sitter.NewParser()
+	p.SetLanguage(ruby.GetLanguage())
+	return &Adapter{parser: p}
+}
+
+// Language returns the language identifier ("ruby")
+func (a *Adapter) Language() string {
+	return "ruby"
+}
+
+// Parse parses the provided Ruby source code and extracts comments.
+// If src is nil, the source code is read from the file path.
+func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	if src == nil {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src = data
+	}
+
+	tree, err := a.parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	return a.extractComments(tree.RootNode(), src, file)
+}
+
+// extractComments walks the query matches and builds domain comments
+func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string) ([]*domain.Comment, error) {
+	q, err := sitter.NewQuery([]byte(rubyCommentQuery), ruby.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+	defer q.Close()
+
+	qc := sitter.NewQueryCursor()
+	defer qc.Close()
+
+	qc.Exec(q, root)
+
+	var comments []*domain.Comment
+
+	for {
+		m, ok := qc.NextMatch()
+		if !ok {
+			break
+		}
+
+		for _, c := range m.Captures {
+			node := c.Node
+			text := strings.TrimRight(node.Content(src), " \t\r\n")
+
+			cType := domain.CommentTypeLine
+			switch {
+			case strings.HasPrefix(text, "=begin"):
+				cType = domain.CommentTypeBlock
+			case isEmptyComment(text) || isShebang(node, text):
+				continue
+			case magicComment.MatchString(text):
+				cType = domain.CommentTypeDirective
+			case yardTag.MatchString(text):
+				cType = domain.CommentTypeDoc
+			}
+
+			endLine := int(node.StartPoint().Row) + strings.Count(text, "\n")
+			endCol := int(node.StartPoint().Column) + len(text)
+			if i := strings.LastIndex(text, "\n"); i >= 0 {
+				endCol = len(text) - i - 1
+			}
+
+			comment := &domain.Comment{
+				File:     file,
+				Language: "ruby",
+				Symbol:   resolveSymbolPath(findOwnerNode(node), src),
+				Range: domain.TextRange{
+					StartLine: int(node.StartPoint().Row) + 1,
+					StartCol:  int(node.StartPoint().Column) + 1,
+					EndLine:   endLine + 1,
+					EndCol:    endCol + 1,
+				},
+				SourceText: text,
+				Type:       cType,
+			}
+			comment.ID = utils.GenerateCommentID(comment)
+
+			comments = append(comments, comment)
+		}
+	}
+
+	return comments, nil
+}
+
+// isShebang reports whether the comment is the interpreter line of a script
+func isShebang(node *sitter.Node, text string) bool {
+	return node.StartPoint().Row == 0 && strings.HasPrefix(text, "#!")
+}
+
+func isEmptyComment(text string) bool {
+	return strings.TrimSpace(strings.TrimLeft(text, "#")) == ""
+}
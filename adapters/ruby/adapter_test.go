@@ -0,0 +1,79 @@
+package ruby
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestAdapter_Parse_Classes(t *testing.T) {
+	src := `# frozen_string_literal: true
+
+# 计费模块
+module Billing
+  # 发票模型
+  #
+  # @param amount [Integer] 金额
+  class Invoice < Base
+    # 合计
+    def total # 行尾注释
+      1
+    end
+
+    # 构建实例
+    def self.build
+    end
+
+    class << self
+      # 默认发票
+      def default; end
+    end
+  end
+end
+`
+	adapter := NewAdapter()
+	assert.Equal(t, "ruby", adapter.Language())
+
+	comments, err := adapter.Parse("lib/billing.rb", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 8)
+
+	// Magic comments are reported so that scan lists them, but never translated
+	assert.Equal(t, "# frozen_string_literal: true", comments[0].SourceText)
+	assert.Equal(t, domain.CommentTypeDirective, comments[0].Type)
+	comments = comments[1:]
+
+	assert.Equal(t, "# 计费模块", comments[0].SourceText)
+	assert.Equal(t, "Billing", comments[0].Symbol)
+
+	assert.Equal(t, "Billing::Invoice", comments[1].Symbol)
+	assert.Equal(t, domain.CommentTypeLine, comments[1].Type)
+
+	assert.Equal(t, "# @param amount [Integer] 金额", comments[2].SourceText)
+	assert.Equal(t, "Billing::Invoice", comments[2].Symbol)
+	assert.Equal(t, domain.CommentTypeDoc, comments[2].Type)
+
+	assert.Equal(t, "Billing::Invoice#total", comments[3].Symbol)
+	assert.Equal(t, "Billing::Invoice#total", comments[4].Symbol)
+	assert.Equal(t, "Billing::Invoice.build", comments[5].Symbol)
+	assert.Equal(t, "Billing::Invoice.default", comments[6].Symbol)
+}
+
+func TestAdapter_Parse_BlockComment(t *testing.T) {
+	src := "def helper\nend\n\n=begin\n多行说明\n=end\ndef run\nend\n"
+
+	adapter := NewAdapter()
+	comments, err := adapter.Parse("script.rb", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+
+	assert.Equal(t, "=begin\n多行说明\n=end", comments[0].SourceText)
+	assert.Equal(t, domain.CommentTypeBlock, comments[0].Type)
+	assert.Equal(t, "run", comments[0].Symbol)
+	assert.Equal(t, 4, comments[0].Range.StartLine)
+	assert.Equal(t, 6, comments[0].Range.EndLine)
+	assert.Equal(t, 5, comments[0].Range.EndCol)
+}
@@ -0,0 +1,6 @@
+package ruby
+
+// rubyCommentQuery is the Tree-sitter query to extract # and =begin/=end comments
+const rubyCommentQuery = `
+(comment) @comment
+`
@@ -0,0 +1,84 @@
+package ruby
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// findOwnerNode finds the node a comment documents.
+// Trailing comments bind to the node on the same line, leading comments to the next
+// statement (skipping other comments), and comments at the end of a body to the enclosing scope.
+// The grammar places comments that precede the first statement of a class or module
+// before its body_statement, so the body is entered to find that statement.
+func findOwnerNode(node *sitter.Node) *sitter.Node {
+	if prev := node.PrevNamedSibling(); prev != nil && prev.Type() != "comment" &&
+		prev.EndPoint().Row == node.StartPoint().Row {
+		return prev
+	}
+
+	next := nextStatement(node.NextNamedSibling())
+	if next != nil && next.Type() == "body_statement" {
+		if first := nextStatement(next.NamedChild(0)); first != nil {
+			return first
+		}
+	}
+	if next != nil {
+		return next
+	}
+	return node.Parent()
+}
+
+// nextStatement returns n or its first following sibling that is not a comment
+func nextStatement(n *sitter.Node) *sitter.Node {
+	for n != nil && n.Type() == "comment" {
+		n = n.NextNamedSibling()
+	}
+	return n
+}
+
+// resolveSymbolPath builds the symbol in Ruby documentation notation:
+// "Billing::Invoice" for namespaces, "Billing::Invoice#total" for instance methods
+// and "Billing::Invoice.build" for singleton methods.
+func resolveSymbolPath(node *sitter.Node, src []byte) string {
+	var parts []string
+	method := ""
+	sep := "#"
+
+	for n := node; n != nil; n = n.Parent() {
+		switch n.Type() {
+		case "method":
+			if method == "" {
+				method = fieldContent(n, "name", src)
+			}
+		case "singleton_method":
+			if method == "" {
+				method = fieldContent(n, "name", src)
+				sep = "."
+			}
+		case "singleton_class":
+			if method != "" {
+				sep = "."
+			}
+		case "class", "module":
+			parts = append([]string{fieldContent(n, "name", src)}, parts...)
+		}
+	}
+
+	symbol := strings.Join(parts, "::")
+	switch {
+	case method == "":
+		return symbol
+	case symbol == "":
+		return method
+	default:
+		return symbol + sep + method
+	}
+}
+
+func fieldContent(n *sitter.Node, field string, src []byte) string {
+	if child := n.ChildByFieldName(field); child != nil {
+		return child.Content(src)
+	}
+	return ""
+}
@@ -2,14 +2,39 @@ package rust
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	sitter "github.com/smacker/go-tree-sitter"
 )
 
+// namedItems are the nodes whose "name" field contributes a segment to the symbol path.
+var namedItems = map[string]bool{
+	"function_item":           true,
+	"function_signature_item": true,
+	"struct_item":             true,
+	"enum_item":               true,
+	"union_item":              true,
+	"trait_item":              true,
+	"mod_item":                true,
+	"const_item":              true,
+	"static_item":             true,
+	"type_item":               true,
+	"associated_type":         true,
+	"macro_definition":        true,
+	"enum_variant":            true,
+	"field_declaration":       true,
+}
+
+// lifetimePattern matches lifetimes such as 'a and 'static inside type names
+var lifetimePattern = regexp.MustCompile(`'\w+\s*,?\s*`)
+
 // ResolveSymbolPath resolves the semantic symbol path for a given node by traversing up the syntax tree.
 // It constructs a path string like "mod_name::StructName::method_name".
-// Supported nodes: function_item, struct_item, enum_item, trait_item, mod_item, impl_item.
+// Items, associated items, enum variants and struct fields are named after their declaration,
+// tuple struct fields after their index, impl blocks after their normalized trait and type
+// ("impl<fmt::Display for Wrapper>"), and closures after their position ("main::{closure#0}").
 func ResolveSymbolPath(node *sitter.Node, src []byte) string {
 	var parts []string
 	curr := node
@@ -17,26 +42,26 @@ func ResolveSymbolPath(node *sitter.Node, src []byte) string {
 	for curr != nil {
 		name := ""
 
-		switch curr.Type() {
-		case "function_item":
-			name = getChildContent(curr, "name", src)
-		case "struct_item":
-			name = getChildContent(curr, "name", src)
-		case "enum_item":
-			name = getChildContent(curr, "name", src)
-		case "trait_item":
-			name = getChildContent(curr, "name", src)
-		case "mod_item":
+		switch typ := curr.Type(); {
+		case namedItems[typ]:
 			name = getChildContent(curr, "name", src)
-		case "impl_item":
+		case typ == "impl_item":
 			// Handle impl blocks: impl Foo or impl Bar for Foo
-			typeName := getChildContent(curr, "type", src)
-			traitName := getChildContent(curr, "trait", src)
+			typeName := normalizeType(getChildContent(curr, "type", src))
+			traitName := normalizeType(getChildContent(curr, "trait", src))
 			if traitName != "" {
 				name = fmt.Sprintf("impl<%s for %s>", traitName, typeName)
 			} else if typeName != "" {
 				name = fmt.Sprintf("impl<%s>", typeName)
 			}
+		case typ == "closure_expression":
+			name = fmt.Sprintf("{closure#%d}", closureIndex(curr))
+		default:
+			if parent := curr.Parent(); parent != nil && parent.Type() == "ordered_field_declaration_list" {
+				if index := tupleFieldIndex(parent, curr); index >= 0 {
+					name = strconv.Itoa(index)
+				}
+			}
 		}
 
 		if name != "" {
@@ -48,6 +73,83 @@ func ResolveSymbolPath(node *sitter.Node, src []byte) string {
 	return strings.Join(parts, "::")
 }
 
+// normalizeType strips generic arguments, lifetimes and whitespace from a type,
+// so that "Wrapper<'a, T>" and "Wrapper<U>" both become "Wrapper".
+func normalizeType(typ string) string {
+	typ = lifetimePattern.ReplaceAllString(typ, "")
+
+	var b strings.Builder
+	depth := 0
+	for _, r := range typ {
+		switch {
+		case r == '<':
+			depth++
+		case r == '>':
+			depth--
+		case depth == 0 && r != ' ' && r != '\t' && r != '\n' && r != '\r':
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// tupleFieldIndex returns the position of a tuple struct field, counting only the field
+// types, or -1 if node is not part of a field. The visibility modifier and attributes that
+// precede a field type belong to that field.
+func tupleFieldIndex(list, node *sitter.Node) int {
+	index := 0
+	reached := false
+	for i := 0; i < int(list.ChildCount()); i++ {
+		child := list.Child(i)
+		if child.Equal(node) {
+			reached = true
+		}
+		if list.FieldNameForChild(i) == "type" {
+			if reached {
+				return index
+			}
+			index++
+		}
+	}
+	return -1
+}
+
+// closureIndex numbers a closure among the closures of its enclosing item or closure,
+// in source order, like rustc's {closure#N}.
+func closureIndex(closure *sitter.Node) int {
+	scope := closure.Parent()
+	for scope != nil && !namedItems[scope.Type()] && scope.Type() != "closure_expression" {
+		scope = scope.Parent()
+	}
+	if scope == nil {
+		return 0
+	}
+
+	index := 0
+	found := false
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		for i := 0; i < int(n.NamedChildCount()) && !found; i++ {
+			child := n.NamedChild(i)
+			if child.Type() == "closure_expression" {
+				if child.Equal(closure) {
+					found = true
+					return
+				}
+				// Closures nested in another closure are numbered within it
+				index++
+				continue
+			}
+			if namedItems[child.Type()] {
+				continue
+			}
+			walk(child)
+		}
+	}
+	walk(scope)
+	return index
+}
+
 func getChildContent(node *sitter.Node, fieldName string, src []byte) string {
 	child := node.ChildByFieldName(fieldName)
 	if child != nil {
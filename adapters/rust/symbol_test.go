@@ -0,0 +1,120 @@
+package rust
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSymbolPath_ItemKinds(t *testing.T) {
+	src := []byte(`
+/// Maximum size
+pub const MAX: usize = 8;
+
+/// Global counter
+static COUNT: AtomicUsize = AtomicUsize::new(0);
+
+/// Result alias
+type Result<T> = std::result::Result<T, Error>;
+
+/// Squares a value
+macro_rules! square {
+    // single expression
+    ($x:expr) => { $x * $x };
+}
+
+/// Raw bits
+union Bits {
+    /// As integer
+    int: u32,
+}
+
+pub struct Config {
+    /// Listen address
+    pub addr: String,
+    /// Port number
+    #[serde(default)]
+    pub port: u16,
+}
+
+pub struct Point(
+    /// Horizontal
+    pub f32,
+    /// Vertical
+    f32,
+);
+
+pub enum Shape {
+    /// A circle
+    Circle { radius: f32 },
+    /// A square
+    Square(f32),
+}
+
+pub trait Store {
+    /// Item type
+    type Item;
+    /// Capacity
+    const CAP: usize;
+    /// Fetch an item
+    fn get(&self) -> Self::Item;
+}
+
+impl<'a, T: Clone> fmt::Display for Wrapper<'a, T> where T: Send {
+    /// Formats it
+    fn fmt(&self) {}
+}
+
+impl<T> Stack<T> {
+    /// Pushes
+    fn push(&mut self) {
+        let f = |x| x;
+        let g = |y| {
+            // inside second closure
+            y
+        };
+    }
+}
+`)
+	comments, err := NewRustAdapter().Parse("lib.rs", src)
+	require.NoError(t, err)
+
+	symbols := make(map[string]string)
+	for _, c := range comments {
+		symbols[strings.TrimSpace(c.SourceText)] = c.Symbol
+	}
+
+	expected := map[string]string{
+		"/// Maximum size":         "MAX",
+		"/// Global counter":       "COUNT",
+		"/// Result alias":         "Result",
+		"/// Squares a value":      "square",
+		"// single expression":     "square",
+		"/// Raw bits":             "Bits",
+		"/// As integer":           "Bits::int",
+		"/// Listen address":       "Config::addr",
+		"/// Port number":          "Config::port",
+		"/// Horizontal":           "Point::0",
+		"/// Vertical":             "Point::1",
+		"/// A circle":             "Shape::Circle",
+		"/// A square":             "Shape::Square",
+		"/// Item type":            "Store::Item",
+		"/// Capacity":             "Store::CAP",
+		"/// Fetch an item":        "Store::get",
+		"/// Formats it":           "impl<fmt::Display for Wrapper>::fmt",
+		"/// Pushes":               "impl<Stack>::push",
+		"// inside second closure": "impl<Stack>::push::{closure#1}",
+	}
+	for text, symbol := range expected {
+		assert.Equal(t, symbol, symbols[text], text)
+	}
+}
+
+func TestNormalizeType(t *testing.T) {
+	assert.Equal(t, "Wrapper", normalizeType("Wrapper<'a, T>"))
+	assert.Equal(t, "&Foo", normalizeType("&'a Foo"))
+	assert.Equal(t, "std::vec::Vec", normalizeType("std::vec::Vec<Box<dyn Fn()>>"))
+	assert.Equal(t, "", normalizeType(""))
+}
@@ -0,0 +1,73 @@
+package scala
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestAdapter_Parse_Symbols(t *testing.T) {
+	src := `package com.example.app
+
+/** 注册表 */
+object Registry {
+  // 注册
+  def register(x: Int): Unit = {
+    // 内部逻辑
+  }
+  val count = 1 // 计数
+}
+
+// 特质
+trait Shape {
+  // 面积
+  def area: Double
+}
+
+class Foo {
+  /** 方法 */
+  @inline def go(): Int = 1
+}
+`
+	adapter := NewAdapter()
+	assert.Equal(t, "scala", adapter.Language())
+
+	comments, err := adapter.Parse("Registry.scala", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 7)
+
+	symbols := make(map[string]string)
+	for _, c := range comments {
+		assert.NotEmpty(t, c.ID)
+		symbols[c.SourceText] = c.Symbol
+	}
+
+	assert.Equal(t, "com.example.app.Registry", symbols["/** 注册表 */"])
+	assert.Equal(t, "com.example.app.Registry.register", symbols["// 注册"])
+	assert.Equal(t, "com.example.app.Registry.register", symbols["// 内部逻辑"])
+	assert.Equal(t, "com.example.app.Registry", symbols["// 计数"])
+	assert.Equal(t, "com.example.app.Shape", symbols["// 特质"])
+	assert.Equal(t, "com.example.app.Shape.area", symbols["// 面积"])
+	assert.Equal(t, "com.example.app.Foo.go", symbols["/** 方法 */"])
+
+	assert.Equal(t, domain.CommentTypeDoc, comments[0].Type)
+	assert.Equal(t, domain.CommentTypeLine, comments[1].Type)
+}
+
+func TestAdapter_Parse_NestedPackage(t *testing.T) {
+	src := `package com.example
+
+package util {
+  // 工具
+  object Strings
+}
+`
+	adapter := NewAdapter()
+	comments, err := adapter.Parse("util.scala", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "com.example.util.Strings", comments[0].Symbol)
+}
@@ -0,0 +1,8 @@
+package scala
+
+// scalaCommentQuery is the Tree-sitter query to extract comments.
+// It matches line comments (//) and block comments (/* */ and ScalaDoc /** */).
+const scalaCommentQuery = `
+(comment) @comment
+(block_comment) @comment
+`
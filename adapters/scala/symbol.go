@@ -0,0 +1,91 @@
+package scala
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// findOwnerNode finds the node a comment belongs to.
+// Trailing comments bind to the enclosing scope, other comments bind to the
+// next definition (skipping comments), falling back to the enclosing scope.
+func findOwnerNode(node *sitter.Node) *sitter.Node {
+	if prev := node.PrevNamedSibling(); prev != nil && prev.EndPoint().Row == node.StartPoint().Row {
+		return node.Parent()
+	}
+
+	next := node.NextNamedSibling()
+	for next != nil && isComment(next) {
+		next = next.NextNamedSibling()
+	}
+	if next != nil {
+		return next
+	}
+	return node.Parent()
+}
+
+// resolveSymbolPath builds a path like "com.example.Registry.register" by walking up the tree.
+// packageName is the file-level package clause; packages with a body contribute while walking up.
+func resolveSymbolPath(node *sitter.Node, src []byte, packageName string) string {
+	var parts []string
+	for curr := node; curr != nil; curr = curr.Parent() {
+		if name := definitionName(curr, src); name != "" {
+			parts = append([]string{name}, parts...)
+		}
+	}
+
+	if packageName != "" {
+		parts = append([]string{packageName}, parts...)
+	}
+	return strings.Join(parts, ".")
+}
+
+// definitionName returns the name contributed by a definition node to the symbol path
+func definitionName(node *sitter.Node, src []byte) string {
+	switch node.Type() {
+	case "object_definition", "class_definition", "trait_definition", "enum_definition",
+		"function_definition", "function_declaration", "type_definition", "given_definition",
+		"simple_enum_case", "full_enum_case", "val_declaration", "var_declaration":
+		return getChildContent(node, "name", src)
+	case "val_definition", "var_definition":
+		if pattern := node.ChildByFieldName("pattern"); pattern != nil && pattern.Type() == "identifier" {
+			return pattern.Content(src)
+		}
+	case "enum_case_definitions":
+		// case Red, Green: use the first case
+		if node.NamedChildCount() > 0 {
+			return getChildContent(node.NamedChild(0), "name", src)
+		}
+	case "package_clause":
+		if node.ChildByFieldName("body") != nil {
+			return getChildContent(node, "name", src)
+		}
+	case "extension_definition":
+		return "extension"
+	}
+	return ""
+}
+
+// extractPackageName returns the file-level package, joining chained clauses (package a; package b)
+func extractPackageName(root *sitter.Node, src []byte) string {
+	var parts []string
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		child := root.NamedChild(i)
+		if child.Type() == "package_clause" && child.ChildByFieldName("body") == nil {
+			parts = append(parts, getChildContent(child, "name", src))
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+func isComment(node *sitter.Node) bool {
+	return node.Type() == "comment" || node.Type() == "block_comment"
+}
+
+func getChildContent(node *sitter.Node, fieldName string, src []byte) string {
+	child := node.ChildByFieldName(fieldName)
+	if child != nil {
+		return child.Content(src)
+	}
+	return ""
+}
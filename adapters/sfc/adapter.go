@@ -0,0 +1,218 @@
+package sfc
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/css"
+	"github.com/smacker/go-tree-sitter/html"
+	"github.com/smacker/go-tree-sitter/svelte"
+
+	"github.com/studyzy/codei18n/adapters/typescript"
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// Adapter implements the LanguageAdapter interface for multi-language documents:
+// Vue and Svelte single-file components and plain HTML pages.
+// Template comments (<!-- -->) are extracted directly, <script> blocks go through
+// the TypeScript adapter and <style> blocks through the CSS grammar.
+type Adapter struct {
+	language string
+	grammar  *sitter.Language
+	scripts  *typescript.Adapter
+}
+
+// NewAdapter creates a new adapter for the given document language ("vue", "svelte" or "html")
+func NewAdapter(lang string) *Adapter {
+	grammar := html.GetLanguage()
+	if lang == "svelte" {
+		grammar = svelte.GetLanguage()
+	}
+	return &Adapter{
+		language: lang,
+		grammar:  grammar,
+		scripts:  typescript.NewAdapter(),
+	}
+}
+
+// Language returns the document language identifier
+func (a *Adapter) Language() string {
+	return a.language
+}
+
+// Parse parses the document and extracts comments from the template, script and style blocks.
+// All ranges are absolute positions within the document.
+func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	if src == nil {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src = data
+	}
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(a.grammar)
+
+	tree, err := parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	return a.extractComments(tree.RootNode(), src, file)
+}
+
+// extractComments walks the document and collects comments of every embedded language
+func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string) ([]*domain.Comment, error) {
+	q, err := sitter.NewQuery([]byte(markupQuery), a.grammar)
+	if err != nil {
+		return nil, err
+	}
+	defer q.Close()
+
+	qc := sitter.NewQueryCursor()
+	defer qc.Close()
+
+	qc.Exec(q, root)
+
+	var comments []*domain.Comment
+
+	for {
+		m, ok := qc.NextMatch()
+		if !ok {
+			break
+		}
+
+		for _, c := range m.Captures {
+			node := c.Node
+
+			var found []*domain.Comment
+			switch q.CaptureNameForId(c.Index) {
+			case "comment":
+				found = a.templateComment(node, src)
+			case "script":
+				found, err = a.scriptComments(node, src, file)
+			case "style":
+				found, err = a.styleComments(node, src)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			for _, comment := range found {
+				comment.File = file
+				comment.Language = a.language
+				comment.ID = utils.GenerateCommentID(comment)
+			}
+			comments = append(comments, found...)
+		}
+	}
+
+	return comments, nil
+}
+
+// templateComment converts a <!-- --> node into a comment bound to its enclosing element path
+func (a *Adapter) templateComment(node *sitter.Node, src []byte) []*domain.Comment {
+	text := node.Content(src)
+	if strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(text, "<!--"), "-->")) == "" {
+		return nil
+	}
+
+	return []*domain.Comment{{
+		Symbol: elementPath(node, src),
+		Range: domain.TextRange{
+			StartLine: int(node.StartPoint().Row) + 1,
+			StartCol:  int(node.StartPoint().Column) + 1,
+			EndLine:   int(node.EndPoint().Row) + 1,
+			EndCol:    int(node.EndPoint().Column) + 1,
+		},
+		SourceText: text,
+		Type:       domain.CommentTypeBlock,
+	}}
+}
+
+// scriptComments runs the TypeScript adapter over a <script> block.
+// The rest of the document is blanked out so the reported positions are already absolute.
+func (a *Adapter) scriptComments(node *sitter.Node, src []byte, file string) ([]*domain.Comment, error) {
+	body := rawText(node)
+	if body == nil {
+		return nil, nil
+	}
+
+	ext, ok := scriptExtension(node, src)
+	if !ok {
+		return nil, nil
+	}
+
+	return a.scripts.ParseAs(file, blankOutside(src, body.StartByte(), body.EndByte()), ext)
+}
+
+// styleComments extracts /* */ comments from a <style> block using the CSS grammar
+func (a *Adapter) styleComments(node *sitter.Node, src []byte) ([]*domain.Comment, error) {
+	body := rawText(node)
+	if body == nil {
+		return nil, nil
+	}
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(css.GetLanguage())
+
+	masked := blankOutside(src, body.StartByte(), body.EndByte())
+	tree, err := parser.ParseCtx(context.Background(), nil, masked)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	q, err := sitter.NewQuery([]byte(cssCommentQuery), css.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+	defer q.Close()
+
+	qc := sitter.NewQueryCursor()
+	defer qc.Close()
+
+	qc.Exec(q, tree.RootNode())
+
+	var comments []*domain.Comment
+	for {
+		m, ok := qc.NextMatch()
+		if !ok {
+			break
+		}
+		for _, c := range m.Captures {
+			n := c.Node
+			comments = append(comments, &domain.Comment{
+				Symbol: cssSelector(n, masked),
+				Range: domain.TextRange{
+					StartLine: int(n.StartPoint().Row) + 1,
+					StartCol:  int(n.StartPoint().Column) + 1,
+					EndLine:   int(n.EndPoint().Row) + 1,
+					EndCol:    int(n.EndPoint().Column) + 1,
+				},
+				SourceText: n.Content(src),
+				Type:       domain.CommentTypeBlock,
+			})
+		}
+	}
+	return comments, nil
+}
+
+// blankOutside returns a copy of src where every byte outside [start, end) is replaced
+// by a space, keeping line breaks so line and column numbers stay unchanged.
+func blankOutside(src []byte, start, end uint32) []byte {
+	masked := make([]byte, len(src))
+	for i, b := range src {
+		if (uint32(i) >= start && uint32(i) < end) || b == '\n' || b == '\r' {
+			masked[i] = b
+		} else {
+			masked[i] = ' '
+		}
+	}
+	return masked
+}
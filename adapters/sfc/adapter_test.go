@@ -0,0 +1,110 @@
+package sfc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestAdapter_Parse_Vue(t *testing.T) {
+	src := `<template>
+  <!-- 头部 -->
+  <div v-if="ok">{{ msg }}</div>
+</template>
+
+<script setup lang="ts">
+// 导入依赖
+import { ref } from 'vue'
+/** 计数器 */
+const count = ref(0)
+</script>
+
+<style scoped>
+/* 按钮样式 */
+.btn { color: red; }
+</style>
+`
+	adapter := NewAdapter("vue")
+	assert.Equal(t, "vue", adapter.Language())
+
+	comments, err := adapter.Parse("App.vue", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 4)
+
+	lines := strings.Split(src, "\n")
+	for _, c := range comments {
+		assert.Equal(t, "vue", c.Language)
+		assert.Equal(t, "App.vue", c.File)
+		assert.NotEmpty(t, c.ID)
+		// Ranges must be absolute positions inside the document
+		line := lines[c.Range.StartLine-1]
+		assert.Equal(t, c.SourceText, line[c.Range.StartCol-1:c.Range.EndCol-1])
+	}
+
+	assert.Equal(t, "<!-- 头部 -->", comments[0].SourceText)
+	assert.Equal(t, "template", comments[0].Symbol)
+	assert.Equal(t, domain.CommentTypeBlock, comments[0].Type)
+
+	assert.Equal(t, "// 导入依赖", comments[1].SourceText)
+	assert.Equal(t, 7, comments[1].Range.StartLine)
+
+	assert.Equal(t, "/** 计数器 */", comments[2].SourceText)
+	assert.Equal(t, "count", comments[2].Symbol)
+	assert.Equal(t, domain.CommentTypeDoc, comments[2].Type)
+
+	assert.Equal(t, "/* 按钮样式 */", comments[3].SourceText)
+	assert.Equal(t, ".btn", comments[3].Symbol)
+	assert.Equal(t, 14, comments[3].Range.StartLine)
+}
+
+func TestAdapter_Parse_Svelte(t *testing.T) {
+	src := `<script>
+  // 名称
+  let name = 'world';
+</script>
+
+<div>
+  <!-- 标题 -->
+  <h1>Hello {name}</h1>
+</div>
+`
+	adapter := NewAdapter("svelte")
+	comments, err := adapter.Parse("App.svelte", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+
+	assert.Equal(t, "// 名称", comments[0].SourceText)
+	assert.Equal(t, "name", comments[0].Symbol)
+	assert.Equal(t, 2, comments[0].Range.StartLine)
+	assert.Equal(t, 3, comments[0].Range.StartCol)
+
+	assert.Equal(t, "<!-- 标题 -->", comments[1].SourceText)
+	assert.Equal(t, "div", comments[1].Symbol)
+	assert.Equal(t, 7, comments[1].Range.StartLine)
+}
+
+func TestAdapter_Parse_HTML(t *testing.T) {
+	src := `<html>
+<body>
+<!-- 主体 --><script>/* 内联 */ var a = 1;</script>
+<script type="text/template"><!-- not parsed --></script>
+</body>
+</html>
+`
+	adapter := NewAdapter("html")
+	comments, err := adapter.Parse("index.html", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+
+	assert.Equal(t, "<!-- 主体 -->", comments[0].SourceText)
+	assert.Equal(t, "html.body", comments[0].Symbol)
+
+	// Script content on the same line as other markup keeps its absolute column
+	assert.Equal(t, "/* 内联 */", comments[1].SourceText)
+	assert.Equal(t, 3, comments[1].Range.StartLine)
+	assert.Equal(t, len("<!-- 主体 --><script>")+1, comments[1].Range.StartCol)
+}
@@ -0,0 +1,14 @@
+package sfc
+
+// markupQuery is the Tree-sitter query to extract template comments and embedded blocks.
+// It works for both the HTML and the Svelte grammar, which share these node types.
+const markupQuery = `
+(comment) @comment
+(script_element) @script
+(style_element) @style
+`
+
+// cssCommentQuery is the Tree-sitter query to extract comments from style blocks
+const cssCommentQuery = `
+(comment) @comment
+`
@@ -0,0 +1,128 @@
+package sfc
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// elementPath builds a path like "template.div" from the tag names of the enclosing elements
+func elementPath(node *sitter.Node, src []byte) string {
+	var parts []string
+	for curr := node.Parent(); curr != nil; curr = curr.Parent() {
+		if curr.Type() != "element" {
+			continue
+		}
+		if name := tagName(curr, src); name != "" {
+			parts = append([]string{name}, parts...)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// tagName returns the tag name of an element from its start tag
+func tagName(element *sitter.Node, src []byte) string {
+	for i := 0; i < int(element.NamedChildCount()); i++ {
+		child := element.NamedChild(i)
+		if child.Type() == "start_tag" || child.Type() == "self_closing_tag" {
+			for j := 0; j < int(child.NamedChildCount()); j++ {
+				if name := child.NamedChild(j); name.Type() == "tag_name" {
+					return strings.ToLower(name.Content(src))
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// rawText returns the raw_text child of a script or style element
+func rawText(element *sitter.Node) *sitter.Node {
+	for i := 0; i < int(element.NamedChildCount()); i++ {
+		if child := element.NamedChild(i); child.Type() == "raw_text" {
+			return child
+		}
+	}
+	return nil
+}
+
+// scriptExtension picks the grammar for a <script> block from its lang/type attributes.
+// It returns false for non-JavaScript script types such as templates or JSON.
+func scriptExtension(element *sitter.Node, src []byte) (string, bool) {
+	attrs := attributes(element, src)
+	switch strings.ToLower(attrs["lang"]) {
+	case "ts", "typescript":
+		return ".ts", true
+	case "tsx":
+		return ".tsx", true
+	case "jsx":
+		return ".jsx", true
+	}
+
+	typ := strings.ToLower(attrs["type"])
+	switch {
+	case typ == "", typ == "module", strings.Contains(typ, "javascript"), strings.Contains(typ, "ecmascript"):
+		return ".js", true
+	case strings.Contains(typ, "typescript"):
+		return ".ts", true
+	}
+	return "", false
+}
+
+// attributes collects the attributes of an element's start tag
+func attributes(element *sitter.Node, src []byte) map[string]string {
+	attrs := make(map[string]string)
+	for i := 0; i < int(element.NamedChildCount()); i++ {
+		tag := element.NamedChild(i)
+		if tag.Type() != "start_tag" {
+			continue
+		}
+		for j := 0; j < int(tag.NamedChildCount()); j++ {
+			attr := tag.NamedChild(j)
+			if attr.Type() != "attribute" {
+				continue
+			}
+			var name, value string
+			for k := 0; k < int(attr.NamedChildCount()); k++ {
+				part := attr.NamedChild(k)
+				switch part.Type() {
+				case "attribute_name":
+					name = strings.ToLower(part.Content(src))
+				case "attribute_value":
+					value = part.Content(src)
+				case "quoted_attribute_value":
+					value = strings.Trim(part.Content(src), `"'`)
+				}
+			}
+			attrs[name] = value
+		}
+	}
+	return attrs
+}
+
+// cssSelector returns the selectors of the rule a style comment belongs to:
+// the enclosing rule for comments inside a block, otherwise the next rule.
+func cssSelector(node *sitter.Node, src []byte) string {
+	for curr := node.Parent(); curr != nil; curr = curr.Parent() {
+		if curr.Type() == "rule_set" {
+			return selectorsOf(curr, src)
+		}
+	}
+	for next := node.NextNamedSibling(); next != nil; next = next.NextNamedSibling() {
+		if next.Type() == "rule_set" {
+			return selectorsOf(next, src)
+		}
+		if next.Type() != "comment" {
+			break
+		}
+	}
+	return ""
+}
+
+func selectorsOf(rule *sitter.Node, src []byte) string {
+	for i := 0; i < int(rule.NamedChildCount()); i++ {
+		if child := rule.NamedChild(i); child.Type() == "selectors" {
+			return strings.Join(strings.Fields(child.Content(src)), " ")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+package sql
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/sql"
+
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// Adapter implements the LanguageAdapter interface for SQL scripts and migrations.
+// It extracts -- and /* */ comments and binds them to the statement, table or column
+// they document. Optionally, COMMENT clauses ("COMMENT ON ... IS '...'" and MySQL
+// "COMMENT '...'") are extracted as translatable doc comments too.
+type Adapter struct {
+	parser         *sitter.Parser
+	commentClauses bool
+}
+
+// NewAdapter creates a new SQL adapter instance.
+// If commentClauses is true, COMMENT clause literals are extracted as well.
+func NewAdapter(commentClauses bool) *Adapter {
+	p := sitter.NewParser()
+	p.SetLanguage(sql.GetLanguage())
+	return &Adapter{parser: p, commentClauses: commentClauses}
+}
+
+// Language returns the language identifier ("sql")
+func (a *Adapter) Language() string {
+	return "sql"
+}
+
+// Parse parses the provided SQL source and extracts comments.
+// If src is nil, the source code is read from the file path.
+func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	if src == nil {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src = data
+	}
+
+	tree, err := a.parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	comments, err := a.extractComments(tree.RootNode(), src, file)
+	if err != nil {
+		return nil, err
+	}
+	if a.commentClauses {
+		comments = append(comments, a.extractCommentClauses(tree.RootNode(), src, file)...)
+		sortByPosition(comments)
+	}
+	return comments, nil
+}
+
+// extractComments walks the query matches and builds domain comments
+func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string) ([]*domain.Comment, error) {
+	q, err := sitter.NewQuery([]byte(sqlCommentQuery), sql.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+	defer q.Close()
+
+	qc := sitter.NewQueryCursor()
+	defer qc.Close()
+
+	qc.Exec(q, root)
+
+	var comments []*domain.Comment
+
+	for {
+		m, ok := qc.NextMatch()
+		if !ok {
+			break
+		}
+
+		for _, c := range m.Captures {
+			node := c.Node
+			text := strings.TrimRight(node.Content(src), " \t\r\n")
+
+			cType := domain.CommentTypeLine
+			if strings.HasPrefix(text, "/**") {
+				cType = domain.CommentTypeDoc
+			} else if strings.HasPrefix(text, "/*") {
+				cType = domain.CommentTypeBlock
+			}
+
+			symbol := resolveSymbol(findOwnerNode(node), src)
+			if symbol == "" {
+				// Dialect-specific statements (procedures, triggers) often fail to parse;
+				// fall back to the header of the statement following the comment.
+				symbol = headerSymbol(src[node.EndByte():])
+			}
+
+			comments = append(comments, newComment(file, symbol, node.StartPoint(), text, cType))
+		}
+	}
+
+	return comments, nil
+}
+
+// newComment builds a comment starting at the given point; the end is derived from the text
+func newComment(file, symbol string, start sitter.Point, text string, cType domain.CommentType) *domain.Comment {
+	endLine := int(start.Row) + strings.Count(text, "\n")
+	endCol := int(start.Column) + len(text)
+	if i := strings.LastIndex(text, "\n"); i >= 0 {
+		endCol = len(text) - i - 1
+	}
+
+	comment := &domain.Comment{
+		File:     file,
+		Language: "sql",
+		Symbol:   symbol,
+		Range: domain.TextRange{
+			StartLine: int(start.Row) + 1,
+			StartCol:  int(start.Column) + 1,
+			EndLine:   endLine + 1,
+			EndCol:    endCol + 1,
+		},
+		SourceText: text,
+		Type:       cType,
+	}
+	comment.ID = utils.GenerateCommentID(comment)
+	return comment
+}
@@ -0,0 +1,87 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+const migrationSQL = `-- 用户表
+CREATE TABLE users (
+  id BIGINT PRIMARY KEY, -- 主键
+  /* 显示名称 */
+  name VARCHAR(64) NOT NULL COMMENT '用户名'
+) COMMENT = '用户表';
+
+COMMENT ON COLUMN users.name IS '名字';
+
+-- 增加邮箱字段
+ALTER TABLE users ADD COLUMN email TEXT;
+
+-- 计算总额
+CREATE FUNCTION total(a int) RETURNS int AS $$ SELECT a $$ LANGUAGE sql;
+`
+
+func TestAdapter_Parse_Comments(t *testing.T) {
+	adapter := NewAdapter(false)
+	assert.Equal(t, "sql", adapter.Language())
+
+	comments, err := adapter.Parse("migrations/001_init.sql", []byte(migrationSQL))
+	require.NoError(t, err)
+	require.Len(t, comments, 5)
+
+	assert.Equal(t, "-- 用户表", comments[0].SourceText)
+	assert.Equal(t, "users", comments[0].Symbol)
+	assert.Equal(t, domain.CommentTypeLine, comments[0].Type)
+
+	assert.Equal(t, "-- 主键", comments[1].SourceText)
+	assert.Equal(t, "users.id", comments[1].Symbol)
+
+	assert.Equal(t, "/* 显示名称 */", comments[2].SourceText)
+	assert.Equal(t, "users.name", comments[2].Symbol)
+	assert.Equal(t, domain.CommentTypeBlock, comments[2].Type)
+
+	assert.Equal(t, "users", comments[3].Symbol)
+	assert.Equal(t, "total", comments[4].Symbol)
+	assert.NotEmpty(t, comments[4].ID)
+}
+
+func TestAdapter_Parse_CommentClauses(t *testing.T) {
+	adapter := NewAdapter(true)
+	comments, err := adapter.Parse("migrations/001_init.sql", []byte(migrationSQL))
+	require.NoError(t, err)
+	require.Len(t, comments, 8)
+
+	assert.Equal(t, "'用户名'", comments[3].SourceText)
+	assert.Equal(t, "users.name", comments[3].Symbol)
+	assert.Equal(t, domain.CommentTypeDoc, comments[3].Type)
+	assert.Equal(t, 5, comments[3].Range.StartLine)
+	assert.Equal(t, 37, comments[3].Range.StartCol)
+
+	assert.Equal(t, "'用户表'", comments[4].SourceText)
+	assert.Equal(t, "users", comments[4].Symbol)
+	assert.Equal(t, 6, comments[4].Range.StartLine)
+	assert.Equal(t, 13, comments[4].Range.StartCol)
+
+	assert.Equal(t, "'名字'", comments[5].SourceText)
+	assert.Equal(t, "users.name", comments[5].Symbol)
+}
+
+func TestAdapter_Parse_UnparsedProcedure(t *testing.T) {
+	src := `DELIMITER //
+-- 结算订单
+CREATE PROCEDURE settle_orders(IN day DATE)
+BEGIN
+  UPDATE orders SET settled = 1 WHERE created_at < day;
+END //
+DELIMITER ;
+`
+	adapter := NewAdapter(false)
+	comments, err := adapter.Parse("proc.sql", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "settle_orders", comments[0].Symbol)
+}
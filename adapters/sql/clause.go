@@ -0,0 +1,94 @@
+package sql
+
+import (
+	"sort"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+// extractCommentClauses collects the string literals of COMMENT clauses:
+// "COMMENT ON TABLE t IS '...'", column "COMMENT '...'" and table option "COMMENT = '...'".
+func (a *Adapter) extractCommentClauses(root *sitter.Node, src []byte, file string) []*domain.Comment {
+	var comments []*domain.Comment
+	add := func(symbol string, start sitter.Point, literal string) {
+		if len(literal) >= 2 && literal[0] == '\'' && literal[len(literal)-1] == '\'' {
+			comments = append(comments, newComment(file, symbol, start, literal, domain.CommentTypeDoc))
+		}
+	}
+
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		switch n.Type() {
+		case "comment_statement":
+			if lit := literalAfter(n, "keyword_is"); lit != nil {
+				symbol := ""
+				if ref := objectReference(n); ref != nil {
+					symbol = unquoteIdentifier(ref.Content(src))
+				}
+				add(symbol, lit.StartPoint(), lit.Content(src))
+			}
+		case "column_definition":
+			if lit := literalAfter(n, "keyword_comment"); lit != nil {
+				add(resolveSymbol(n, src), lit.StartPoint(), lit.Content(src))
+			}
+		case "table_option":
+			// The grammar does not expose the value of "COMMENT = '...'", so locate it in the text
+			text := n.Content(src)
+			if name := n.ChildByFieldName("name"); name != nil && strings.EqualFold(name.Content(src), "comment") {
+				if i := strings.IndexByte(text, '\''); i >= 0 {
+					start := n.StartPoint()
+					start.Column += uint32(i)
+					add(resolveSymbol(n, src), start, quotedPrefix(text[i:]))
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(root)
+
+	return comments
+}
+
+// literalAfter returns the string literal directly following a keyword child, or nil
+func literalAfter(n *sitter.Node, keyword string) *sitter.Node {
+	for i := 0; i+1 < int(n.ChildCount()); i++ {
+		if n.Child(i).Type() != keyword {
+			continue
+		}
+		if lit := n.Child(i + 1); lit.Type() == "literal" {
+			return lit
+		}
+	}
+	return nil
+}
+
+// quotedPrefix returns the single-quoted string literal at the start of s,
+// honoring ” escapes, or "" if the literal is not terminated.
+func quotedPrefix(s string) string {
+	for i := 1; i < len(s); i++ {
+		if s[i] != '\'' {
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '\'' {
+			i++
+			continue
+		}
+		return s[:i+1]
+	}
+	return ""
+}
+
+// sortByPosition orders comments by their start position
+func sortByPosition(comments []*domain.Comment) {
+	sort.SliceStable(comments, func(i, j int) bool {
+		if comments[i].Range.StartLine != comments[j].Range.StartLine {
+			return comments[i].Range.StartLine < comments[j].Range.StartLine
+		}
+		return comments[i].Range.StartCol < comments[j].Range.StartCol
+	})
+}
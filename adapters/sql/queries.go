@@ -0,0 +1,8 @@
+package sql
+
+// sqlCommentQuery is the Tree-sitter query to extract comments.
+// The grammar exposes -- comments as (comment) and /* */ comments as (marginalia).
+const sqlCommentQuery = `
+(comment) @comment
+(marginalia) @comment
+`
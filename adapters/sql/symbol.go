@@ -0,0 +1,117 @@
+package sql
+
+import (
+	"regexp"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// statementHeader matches the header of a DDL statement, capturing its object name
+var statementHeader = regexp.MustCompile(`(?i)^(?:CREATE|ALTER|DROP)\s+(?:OR\s+REPLACE\s+)?(?:(?:TEMP|TEMPORARY|UNIQUE|MATERIALIZED)\s+)*` +
+	`(?:TABLE|VIEW|FUNCTION|PROCEDURE|TRIGGER|INDEX|SEQUENCE|TYPE|SCHEMA)\s+(?:IF\s+(?:NOT\s+)?EXISTS\s+)?` +
+	"([\\w.\"`\\[\\]]+)")
+
+// isComment reports whether a node is a -- or /* */ comment
+func isComment(n *sitter.Node) bool {
+	return n.Type() == "comment" || n.Type() == "marginalia"
+}
+
+// findOwnerNode finds the node a comment documents.
+// Trailing comments bind to the node on the same line, leading comments to the next
+// statement or column definition (skipping other comments), and comments at the end
+// of a group to the enclosing node.
+func findOwnerNode(node *sitter.Node) *sitter.Node {
+	if prev := node.PrevNamedSibling(); prev != nil && !isComment(prev) &&
+		prev.EndPoint().Row == node.StartPoint().Row {
+		return prev
+	}
+
+	next := node.NextNamedSibling()
+	for next != nil && isComment(next) {
+		next = next.NextNamedSibling()
+	}
+	if next != nil {
+		return next
+	}
+	return node.Parent()
+}
+
+// resolveSymbol builds the symbol of a node: the object name of the enclosing statement,
+// plus the column name inside column definitions ("users", "users.email").
+// It returns "" if the node is not inside a recognized statement.
+func resolveSymbol(node *sitter.Node, src []byte) string {
+	if node == nil {
+		return ""
+	}
+	if node.Type() == "statement" && node.NamedChildCount() > 0 {
+		node = node.NamedChild(0)
+	}
+
+	var parts []string
+	for n := node; n != nil; n = n.Parent() {
+		switch {
+		case n.Type() == "ERROR":
+			return ""
+		case n.Type() == "column_definition":
+			if name := n.ChildByFieldName("name"); name != nil {
+				parts = append([]string{unquoteIdentifier(name.Content(src))}, parts...)
+			}
+		case isObjectStatement(n.Type()):
+			if ref := objectReference(n); ref != nil {
+				parts = append([]string{unquoteIdentifier(ref.Content(src))}, parts...)
+			}
+			return strings.Join(parts, ".")
+		}
+	}
+	return ""
+}
+
+// isObjectStatement reports whether a statement node defines or changes a named object
+func isObjectStatement(kind string) bool {
+	return strings.HasPrefix(kind, "create_") || strings.HasPrefix(kind, "alter_") ||
+		strings.HasPrefix(kind, "drop_") || kind == "comment_statement"
+}
+
+// objectReference returns the first object_reference child of a statement
+func objectReference(n *sitter.Node) *sitter.Node {
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		if child := n.NamedChild(i); child.Type() == "object_reference" {
+			return child
+		}
+	}
+	return nil
+}
+
+// headerSymbol returns the object name of the DDL statement at the start of rest,
+// skipping whitespace and comments, or "" if rest does not start with one.
+func headerSymbol(rest []byte) string {
+	s := string(rest)
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		switch {
+		case strings.HasPrefix(s, "--"):
+			i := strings.IndexByte(s, '\n')
+			if i < 0 {
+				return ""
+			}
+			s = s[i+1:]
+		case strings.HasPrefix(s, "/*"):
+			i := strings.Index(s, "*/")
+			if i < 0 {
+				return ""
+			}
+			s = s[i+2:]
+		default:
+			if m := statementHeader.FindStringSubmatch(s); m != nil {
+				return unquoteIdentifier(m[1])
+			}
+			return ""
+		}
+	}
+}
+
+// unquoteIdentifier removes identifier quoting: "a"."b", `a`, [a]
+func unquoteIdentifier(s string) string {
+	return strings.NewReplacer(`"`, "", "`", "", "[", "", "]", "").Replace(s)
+}
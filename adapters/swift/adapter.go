@@ -0,0 +1,120 @@
+package swift
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/swift"
+
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// Adapter implements the LanguageAdapter interface for Swift.
+// /// and /** */ are DocC doc comments; // MARK: and // TODO: comments expose only their description.
+type Adapter struct {
+	parser *sitter.Parser
+}
+
+// NewAdapter creates a new Swift adapter instance
+func NewAdapter() *Adapter {
+	p := sitter.NewParser()
+	p.SetLanguage(swift.GetLanguage())
+	return &Adapter{parser: p}
+}
+
+// Language returns the language identifier ("swift")
+func (a *Adapter) Language() string {
+	return "swift"
+}
+
+// Parse parses the provided Swift source code and extracts comments.
+// If src is nil, the source code is read from the file path.
+func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
+	if src == nil {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src = data
+	}
+
+	tree, err := a.parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	return a.extractComments(tree.RootNode(), src, file)
+}
+
+// extractComments walks the query matches and builds domain comments
+func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string) ([]*domain.Comment, error) {
+	q, err := sitter.NewQuery([]byte(swiftCommentQuery), swift.GetLanguage())
+	if err != nil {
+		return nil, err
+	}
+	defer q.Close()
+
+	qc := sitter.NewQueryCursor()
+	defer qc.Close()
+
+	qc.Exec(q, root)
+
+	var comments []*domain.Comment
+
+	for {
+		m, ok := qc.NextMatch()
+		if !ok {
+			break
+		}
+
+		for _, c := range m.Captures {
+			node := c.Node
+			text := strings.TrimRight(node.Content(src), " \t\r\n")
+			start := node.StartPoint()
+
+			cType := domain.CommentTypeLine
+			switch {
+			case strings.HasPrefix(text, "///") || strings.HasPrefix(text, "/**"):
+				cType = domain.CommentTypeDoc
+			case strings.HasPrefix(text, "/*"):
+				cType = domain.CommentTypeBlock
+			default:
+				if prefix := utils.TaskMarkerPrefix(text); prefix != "" {
+					text = text[len(prefix):]
+					start.Column += uint32(len(prefix))
+				}
+			}
+			if strings.TrimSpace(strings.TrimLeft(text, "/")) == "" {
+				continue
+			}
+
+			endCol := int(start.Column) + len(text)
+			if i := strings.LastIndex(text, "\n"); i >= 0 {
+				endCol = len(text) - i - 1
+			}
+
+			comment := &domain.Comment{
+				File:     file,
+				Language: "swift",
+				Symbol:   resolveSymbolPath(findOwnerNode(node), src),
+				Range: domain.TextRange{
+					StartLine: int(start.Row) + 1,
+					StartCol:  int(start.Column) + 1,
+					EndLine:   int(start.Row) + strings.Count(text, "\n") + 1,
+					EndCol:    endCol + 1,
+				},
+				SourceText: text,
+				Type:       cType,
+			}
+			comment.ID = utils.GenerateCommentID(comment)
+
+			comments = append(comments, comment)
+		}
+	}
+
+	return comments, nil
+}
@@ -0,0 +1,86 @@
+package swift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+const homeSwift = `import UIKit
+
+/// 首页控制器
+class HomeViewController: UIViewController {
+    // MARK: - 生命周期
+
+    override func viewDidLoad() {
+        super.viewDidLoad() // TODO: 加载数据
+    }
+
+    /// 标题
+    var title: String = ""
+}
+
+extension HomeViewController {
+    /**
+     行数
+     - Returns: 行数
+     */
+    func numberOfRows() -> Int { 0 }
+}
+
+enum Direction {
+    /// 北
+    case north
+}
+
+// MARK: -
+`
+
+func TestAdapter_Parse(t *testing.T) {
+	adapter := NewAdapter()
+	assert.Equal(t, "swift", adapter.Language())
+
+	comments, err := adapter.Parse("Home.swift", []byte(homeSwift))
+	require.NoError(t, err)
+	require.Len(t, comments, 6)
+
+	assert.Equal(t, "/// 首页控制器", comments[0].SourceText)
+	assert.Equal(t, "HomeViewController", comments[0].Symbol)
+	assert.Equal(t, domain.CommentTypeDoc, comments[0].Type)
+
+	// MARK: keeps its prefix out of the translatable text
+	assert.Equal(t, "生命周期", comments[1].SourceText)
+	assert.Equal(t, domain.CommentTypeLine, comments[1].Type)
+	assert.Equal(t, 5, comments[1].Range.StartLine)
+	assert.Equal(t, 16, comments[1].Range.StartCol)
+	assert.Equal(t, "HomeViewController.viewDidLoad", comments[1].Symbol)
+
+	assert.Equal(t, "加载数据", comments[2].SourceText)
+	assert.Equal(t, "HomeViewController.viewDidLoad", comments[2].Symbol)
+
+	assert.Equal(t, "HomeViewController.title", comments[3].Symbol)
+
+	assert.Equal(t, "HomeViewController.numberOfRows", comments[4].Symbol)
+	assert.Equal(t, domain.CommentTypeDoc, comments[4].Type)
+
+	assert.Equal(t, "Direction.north", comments[5].Symbol)
+	assert.NotEmpty(t, comments[5].ID)
+}
+
+func TestAdapter_Parse_Markers(t *testing.T) {
+	src := "// MARK: - 数据源\n// TODO(alice): 限制最大值\n// FIXME: 修复崩溃\n"
+	comments, err := NewAdapter().Parse("Markers.swift", []byte(src))
+	require.NoError(t, err)
+
+	var texts []string
+	var cols []int
+	for _, c := range comments {
+		texts = append(texts, c.SourceText)
+		cols = append(cols, c.Range.StartCol)
+	}
+	assert.Equal(t, []string{"数据源", "限制最大值", "修复崩溃"}, texts)
+	assert.Equal(t, []int{12, 17, 11}, cols)
+}
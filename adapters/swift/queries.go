@@ -0,0 +1,7 @@
+package swift
+
+// swiftCommentQuery is the Tree-sitter query to extract //, ///, /* */ and /** */ comments
+const swiftCommentQuery = `
+(comment) @comment
+(multiline_comment) @comment
+`
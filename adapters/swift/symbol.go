@@ -0,0 +1,71 @@
+package swift
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// isComment reports whether a node is a comment
+func isComment(n *sitter.Node) bool {
+	return n.Type() == "comment" || n.Type() == "multiline_comment"
+}
+
+// findOwnerNode finds the node a comment documents.
+// Trailing comments bind to the node on the same line, leading comments to the next
+// declaration (skipping other comments), and comments at the end of a body to the enclosing scope.
+func findOwnerNode(node *sitter.Node) *sitter.Node {
+	if prev := node.PrevNamedSibling(); prev != nil && !isComment(prev) &&
+		prev.EndPoint().Row == node.StartPoint().Row {
+		return prev
+	}
+
+	next := node.NextNamedSibling()
+	for next != nil && isComment(next) {
+		next = next.NextNamedSibling()
+	}
+	if next != nil {
+		return next
+	}
+	return node.Parent()
+}
+
+// resolveSymbolPath builds the dotted symbol of a node: "HomeViewController.viewDidLoad",
+// "Outer.Inner.f", "Direction.north". Members of extensions are reported under the
+// extended type, so "extension Foo { func bar() }" yields "Foo.bar".
+func resolveSymbolPath(node *sitter.Node, src []byte) string {
+	var parts []string
+	for n := node; n != nil; n = n.Parent() {
+		if name := declarationName(n, src); name != "" {
+			parts = append([]string{name}, parts...)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// declarationName returns the name declared by n, or "" if n is not a declaration
+func declarationName(n *sitter.Node, src []byte) string {
+	switch n.Type() {
+	case "class_declaration", "protocol_declaration", "typealias_declaration",
+		"function_declaration", "protocol_function_declaration", "enum_entry":
+		// class_declaration covers class, struct, enum, actor and extension;
+		// an extension's name is the extended type
+		if name := n.ChildByFieldName("name"); name != nil {
+			return name.Content(src)
+		}
+	case "init_declaration":
+		return "init"
+	case "deinit_declaration":
+		return "deinit"
+	case "subscript_declaration":
+		return "subscript"
+	case "property_declaration", "protocol_property_declaration":
+		if name := n.ChildByFieldName("name"); name != nil {
+			if id := name.ChildByFieldName("bound_identifier"); id != nil {
+				return id.Content(src)
+			}
+			return name.Content(src)
+		}
+	}
+	return ""
+}
@@ -34,20 +34,29 @@ func NewLLMTranslator(apiKey, baseURL, model string) *LLMTranslator {
 	}
 }
 
-// Translate translates a single text
-func (t *LLMTranslator) Translate(ctx context.Context, text, from, to string) (string, error) {
-	// Simple prompt construction
-	prompt := fmt.Sprintf(
+// placeholderRule asks the model to keep the ⟦n⟧ placeholders that stand for doc tags, links
+// and code; a translation that loses or alters one is rejected when they are re-inserted
+const placeholderRule = "Keep every placeholder such as ⟦1⟧ or ⟦2⟧ exactly as written, where it belongs in the sentence: never translate, renumber or remove them."
+
+// buildPrompt builds the prompt for translating a single text
+func buildPrompt(text, from, to string) string {
+	return fmt.Sprintf(
 		"You are a professional code comment translator. Translate the following code comment from %s to %s.\n"+
 			"Rules:\n"+
 			"1. Keep technical terms, variable names, and code snippets unchanged.\n"+
 			"2. Maintain the tone and style of the original comment.\n"+
 			"3. Output ONLY the translated text, no explanations or quotes.\n"+
 			"4. If the text is already in the target language, return it as is.\n"+
-			"5. Preserve all line breaks and formatting.\n\n"+
+			"5. Preserve all line breaks and formatting.\n"+
+			"6. %s\n\n"+
 			"Original: %s",
-		from, to, text,
+		from, to, placeholderRule, text,
 	)
+}
+
+// Translate translates a single text
+func (t *LLMTranslator) Translate(ctx context.Context, text, from, to string) (string, error) {
+	prompt := buildPrompt(text, from, to)
 
 	resp, err := t.client.CreateChatCompletion(
 		ctx,
@@ -142,9 +151,10 @@ func (t *LLMTranslator) buildBatchPrompt(texts []string, from, to string) string
 			"3. The number of elements MUST match the input.\n"+
 			"4. Keep technical terms, variable names, and code snippets unchanged.\n"+
 			"5. If a comment is already in the target language, return it as is.\n"+
-			"6. Preserve all line breaks and formatting.\n\n"+
+			"6. Preserve all line breaks and formatting.\n"+
+			"7. %s\n\n"+
 			"Input:\n%s",
-		from, to, string(inputJSON),
+		from, to, placeholderRule, string(inputJSON),
 	)
 }
 
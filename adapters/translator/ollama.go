@@ -40,17 +40,7 @@ func NewOllamaTranslator(endpoint, model string) *OllamaTranslator {
 
 // Translate implements single text translation.
 func (t *OllamaTranslator) Translate(ctx context.Context, text, from, to string) (string, error) {
-	prompt := fmt.Sprintf(
-		"You are a professional code comment translator. Translate the following code comment from %s to %s.\n"+
-			"Rules:\n"+
-			"1. Keep technical terms, variable names, and code snippets unchanged.\n"+
-			"2. Maintain the tone and style of the original comment.\n"+
-			"3. Output ONLY the translated text, no explanations or quotes.\n"+
-			"4. If the text is already in the target language, return it as is.\n"+
-			"5. Preserve all line breaks and formatting.\n\n"+
-			"Original: %s",
-		from, to, text,
-	)
+	prompt := buildPrompt(text, from, to)
 
 	reqBody := struct {
 		Model    string          `json:"model"`
@@ -0,0 +1,51 @@
+package translator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptsKeepPlaceholders(t *testing.T) {
+	ctx := context.Background()
+	var prompts []string
+
+	server := NewMockLLMServer(func(req *openai.ChatCompletionRequest) (*openai.ChatCompletionResponse, error) {
+		prompts = append(prompts, req.Messages[len(req.Messages)-1].Content)
+		if len(prompts) == 1 {
+			return createMockResponse("// ⟦1⟧ 用户名"), nil
+		}
+		return createMockResponse(`["// ⟦1⟧ 用户名", "// 两数相加"]`), nil
+	})
+	defer server.Close()
+
+	llm := NewLLMTranslator("test-key", server.URL, "test-model")
+	_, err := llm.Translate(ctx, "// ⟦1⟧ the user name", "en", "zh-CN")
+	require.NoError(t, err)
+	_, err = llm.TranslateBatch(ctx, []string{"// ⟦1⟧ the user name", "// Adds two numbers"}, "en", "zh-CN")
+	require.NoError(t, err)
+
+	ollamaServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []ollamaMessage `json:"messages"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		prompts = append(prompts, req.Messages[len(req.Messages)-1].Content)
+		json.NewEncoder(w).Encode(map[string]any{"message": map[string]string{"role": "assistant", "content": "// ⟦1⟧ 用户名"}})
+	}))
+	defer ollamaServer.Close()
+
+	_, err = NewOllamaTranslator(ollamaServer.URL, "test-model").Translate(ctx, "// ⟦1⟧ the user name", "en", "zh-CN")
+	require.NoError(t, err)
+
+	require.Len(t, prompts, 3)
+	for _, prompt := range prompts {
+		assert.Contains(t, prompt, placeholderRule)
+	}
+}
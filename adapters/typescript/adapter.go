@@ -12,20 +12,76 @@ import (
 	"github.com/smacker/go-tree-sitter/typescript/typescript"
 
 	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
 )
 
-type Adapter struct{}
+// Dialect identifiers reported as the comment language
+const (
+	JavaScript = "javascript"
+	TypeScript = "typescript"
+	TSX        = "tsx"
+)
+
+// dialects maps file extensions to the dialect whose grammar parses them.
+// The JavaScript grammar also covers JSX.
+var dialects = map[string]string{
+	".js":  JavaScript,
+	".jsx": JavaScript,
+	".mjs": JavaScript,
+	".cjs": JavaScript,
+	".ts":  TypeScript,
+	".mts": TypeScript,
+	".cts": TypeScript,
+	".tsx": TSX,
+}
 
+// Adapter implements core.LanguageAdapter for JavaScript, TypeScript and TSX
+type Adapter struct {
+	dialect string
+}
+
+// NewAdapter creates an adapter that picks the dialect from each file's extension
 func NewAdapter() *Adapter {
 	return &Adapter{}
 }
 
+// NewDialectAdapter creates an adapter that parses every file as the given dialect,
+// regardless of its extension. It backs the javascript and tsx registrations.
+func NewDialectAdapter(dialect string) *Adapter {
+	return &Adapter{dialect: dialect}
+}
+
 func (a *Adapter) Language() string {
-	return "typescript"
+	if a.dialect != "" {
+		return a.dialect
+	}
+	return TypeScript
 }
 
 func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
-	lang, err := getLanguage(file)
+	dialect := a.dialect
+	if dialect == "" {
+		// Extensionless node scripts and overridden extensions default to JavaScript
+		dialect = JavaScript
+		if d, ok := dialects[strings.ToLower(filepath.Ext(file))]; ok {
+			dialect = d
+		}
+	}
+	return a.parseDialect(file, src, dialect)
+}
+
+// ParseAs parses src with the grammar selected by ext (".js", ".jsx", ".ts" or ".tsx")
+// instead of the extension of file. It is used for script blocks embedded in other documents.
+func (a *Adapter) ParseAs(file string, src []byte, ext string) ([]*domain.Comment, error) {
+	dialect, ok := dialects[strings.ToLower(ext)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported file extension for typescript adapter: %s", ext)
+	}
+	return a.parseDialect(file, src, dialect)
+}
+
+func (a *Adapter) parseDialect(file string, src []byte, dialect string) ([]*domain.Comment, error) {
+	lang, err := getLanguage(dialect)
 	if err != nil {
 		return nil, err
 	}
@@ -41,28 +97,23 @@ func (a *Adapter) Parse(file string, src []byte) ([]*domain.Comment, error) {
 
 	rootNode := tree.RootNode()
 
-	return a.extractComments(rootNode, src, file, lang)
+	return a.extractComments(rootNode, src, file, lang, dialect)
 }
 
-func getLanguage(filename string) (*sitter.Language, error) {
-	ext := strings.ToLower(filepath.Ext(filename))
-	switch ext {
-	case ".js", ".jsx":
-		// Ideally we use javascript for .js/.jsx, but for simplicity in this MVP
-		// we can also stick to specific grammars.
-		// Note: .jsx might need javascript grammar or a specific jsx one if available/integrated.
-		// The smacker/go-tree-sitter javascript grammar usually handles JSX.
+func getLanguage(dialect string) (*sitter.Language, error) {
+	switch dialect {
+	case JavaScript:
 		return javascript.GetLanguage(), nil
-	case ".ts":
+	case TypeScript:
 		return typescript.GetLanguage(), nil
-	case ".tsx":
+	case TSX:
 		return tsx.GetLanguage(), nil
 	default:
-		return nil, fmt.Errorf("unsupported file extension for typescript adapter: %s", ext)
+		return nil, fmt.Errorf("unsupported dialect for typescript adapter: %s", dialect)
 	}
 }
 
-func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string, lang *sitter.Language) ([]*domain.Comment, error) {
+func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string, lang *sitter.Language, dialect string) ([]*domain.Comment, error) {
 	q, err := sitter.NewQuery([]byte(queryTS), lang)
 	if err != nil {
 		return nil, fmt.Errorf("invalid query: %w", err)
@@ -96,30 +147,10 @@ func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string, la
 				}
 			}
 
-			// Resolve symbol
-			symbol := resolveSymbol(node, src)
-
-			// Generate ID
-			// We need a stable ID.
-			// ID = SHA1(file + lang + symbol + normalized_text)
-			// But since we can't depend on core/utils internal logic easily if it's not exported or if we want to be consistent:
-			// core/utils/id.go usually has ID generation. Let's assume we can use it or replicate it.
-			// Let's use the core/domain one if available or utils.
-			// Wait, core/utils/id.go was mentioned in exploration.
-			// We need to import "github.com/studyzy/codei18n/core/utils" if it's public.
-			// Let's check imports.
-
-			// For now, let's just create the object, ID will be handled by caller or we need to add utils.
-			// CodeI18n core seems to handle ID generation in scanner usually?
-			// Actually, the adapter returns comments, and scanner might enrich them or adapter should set ID.
-			// Checking `adapters/golang/parser.go` would clarify.
-
-			// Let's assume we need to generate ID here.
-
 			comment := &domain.Comment{
 				File:     file,
-				Language: "typescript", // or javascript, but adapter says typescript
-				Symbol:   symbol,
+				Language: dialect,
+				Symbol:   resolveSymbol(node, src),
 				Range: domain.TextRange{
 					StartLine: int(node.StartPoint().Row) + 1,
 					StartCol:  int(node.StartPoint().Column) + 1,
@@ -129,12 +160,7 @@ func (a *Adapter) extractComments(root *sitter.Node, src []byte, file string, la
 				SourceText: text,
 				Type:       cType,
 			}
-
-			// We won't set ID here if we don't have the util, but we should tries to.
-			// Let's rely on scanner to set ID or add utils import.
-			// In `core/scanner/scanner.go`: "comments, err := adapter.Parse(...) ... for c in comments { c.ID = utils.GenerateID(...) }"
-			// If scanner does it, we are good. If adapter must do it, we need to know.
-			// Let's check `adapters/golang/parser.go` later. For now, leave ID empty.
+			comment.ID = utils.GenerateCommentID(comment)
 
 			comments = append(comments, comment)
 		}
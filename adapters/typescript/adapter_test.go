@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/studyzy/codei18n/core/domain"
 )
@@ -122,3 +123,111 @@ func TestAdapter_Parse_Range(t *testing.T) {
 	// EndCol should be the position of the comment's end.
 	assert.Greater(t, firstComment.Range.EndCol, firstComment.Range.StartCol, "EndCol 应该大于 StartCol")
 }
+
+func TestAdapter_Parse_Symbols(t *testing.T) {
+	src := `namespace Shapes {
+  export enum Color {
+    // primary
+    Red = 1,
+    // secondary
+    Green,
+  }
+  export abstract class Base {
+    // the id
+    private id: number = 0;
+    area(): number {
+      // not implemented
+      return 0;
+    }
+  }
+}
+declare module "foo" {
+  // bar it
+  export function bar(): void;
+}
+const api = {
+  // fetch it
+  get() { return 1; },
+  nested: {
+    // deep value
+    deep: () => 1,
+  },
+};
+interface Point {
+  x: number; // horizontal
+}
+module.exports.helper = function () {
+  // helps
+};
+`
+	comments, err := NewAdapter().Parse("shapes.ts", []byte(src))
+	require.NoError(t, err)
+
+	symbols := make(map[string]string)
+	for _, c := range comments {
+		symbols[c.SourceText] = c.Symbol
+		assert.Equal(t, TypeScript, c.Language)
+		assert.NotEmpty(t, c.ID)
+	}
+	assert.Equal(t, "Shapes.Color.Red", symbols["// primary"])
+	assert.Equal(t, "Shapes.Color.Green", symbols["// secondary"])
+	assert.Equal(t, "Shapes.Base.id", symbols["// the id"])
+	assert.Equal(t, "Shapes.Base.area", symbols["// not implemented"])
+	assert.Equal(t, "foo.bar", symbols["// bar it"])
+	assert.Equal(t, "api.get", symbols["// fetch it"])
+	assert.Equal(t, "api.nested.deep", symbols["// deep value"])
+	assert.Equal(t, "Point.x", symbols["// horizontal"])
+	assert.Equal(t, "module.exports.helper", symbols["// helps"])
+}
+
+func TestAdapter_Parse_JSXAndClassFields(t *testing.T) {
+	src := `class Counter {
+  // current count
+  count = 0;
+}
+function App() {
+  return (
+    <main>
+      {/* page header */}
+      <Header />
+    </main>
+  );
+}
+`
+	comments, err := NewAdapter().Parse("app.jsx", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+
+	assert.Equal(t, "Counter.count", comments[0].Symbol)
+	assert.Equal(t, "App.main", comments[1].Symbol)
+	for _, c := range comments {
+		assert.Equal(t, JavaScript, c.Language)
+	}
+}
+
+func TestAdapter_Dialects(t *testing.T) {
+	src := []byte("// hello\nconst x = 1;\n")
+	cases := map[string]string{
+		"a.js":  JavaScript,
+		"a.mjs": JavaScript,
+		"a.ts":  TypeScript,
+		"a.cts": TypeScript,
+		"a.tsx": TSX,
+		"serve": JavaScript,
+	}
+	for file, dialect := range cases {
+		comments, err := NewAdapter().Parse(file, src)
+		require.NoError(t, err, file)
+		require.Len(t, comments, 1, file)
+		assert.Equal(t, dialect, comments[0].Language, file)
+	}
+
+	// A dialect adapter ignores the extension, e.g. for languageOverrides
+	adapter := NewDialectAdapter(TSX)
+	assert.Equal(t, TSX, adapter.Language())
+	comments, err := adapter.Parse("widget.inc", []byte("// w\nconst W = () => <div />;\n"))
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, TSX, comments[0].Language)
+	assert.Equal(t, "W", comments[0].Symbol)
+}
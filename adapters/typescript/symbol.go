@@ -6,103 +6,102 @@ import (
 	sitter "github.com/smacker/go-tree-sitter"
 )
 
-// resolveSymbol determines the symbol path for a comment node by looking at its following sibling.
+// resolveSymbol determines the symbol path for a comment node.
+// A comment trailing a declaration on the same line binds to that declaration, a comment
+// placed before a declaration (possibly with other comments in between) binds to it, and
+// any other comment binds to the innermost enclosing declaration.
 func resolveSymbol(node *sitter.Node, src []byte) string {
-	// Look for the next named sibling to attach the comment to
+	if prev := node.PrevNamedSibling(); prev != nil && prev.Type() != "comment" &&
+		prev.EndPoint().Row == node.StartPoint().Row {
+		if owner := unwrap(prev); declarationName(owner, src) != "" {
+			return symbolPath(owner, src)
+		}
+		return symbolPath(node.Parent(), src)
+	}
+
 	next := node.NextNamedSibling()
-	if next == nil {
-		return ""
+	for next != nil && next.Type() == "comment" {
+		next = next.NextNamedSibling()
+	}
+	if next != nil {
+		if owner := unwrap(next); declarationName(owner, src) != "" {
+			return symbolPath(owner, src)
+		}
 	}
 
-	return getSymbolPath(next, src)
+	return symbolPath(node.Parent(), src)
 }
 
-func getSymbolPath(node *sitter.Node, src []byte) string {
-	parts := []string{}
-	current := node
-
-	// Build path from current node up to root, but here we usually just want to know
-	// "what is this node?" and maybe its parent context if it's a method.
-	// For MVP, let's implement basic identification of the immediate node.
-	// Advanced path building (e.g. Class.Method) usually requires traversing UP parents.
-
-	// Strategy:
-	// 1. Identify the name of the current node.
-	// 2. Traverse parents to prepend context (Class, Module, Interface).
-
-	name := getNodeName(current, src)
-	if name != "" {
-		parts = append(parts, name)
+// symbolPath joins the names of node and all its named ancestors, outermost first
+func symbolPath(node *sitter.Node, src []byte) string {
+	var parts []string
+	for curr := node; curr != nil; curr = curr.Parent() {
+		if name := declarationName(curr, src); name != "" {
+			parts = append([]string{name}, parts...)
+		}
 	}
+	return strings.Join(parts, ".")
+}
 
-	// Traverse up to find parents
-	parent := current.Parent()
-	for parent != nil {
-		parentName := ""
-		switch parent.Type() {
-		case "class_declaration", "interface_declaration", "module_declaration", "class":
-			parentName = getNodeName(parent, src)
+// unwrap returns the declaration inside statements that only wrap one:
+// export, const/let/var, declare and expression statements.
+func unwrap(node *sitter.Node) *sitter.Node {
+	switch node.Type() {
+	case "export_statement":
+		if decl := node.ChildByFieldName("declaration"); decl != nil {
+			return unwrap(decl)
 		}
-
-		if parentName != "" {
-			// Prepend parent name
-			parts = append([]string{parentName}, parts...)
+		if value := node.ChildByFieldName("value"); value != nil {
+			return unwrap(value)
+		}
+	case "lexical_declaration", "variable_declaration", "ambient_declaration", "expression_statement":
+		if node.NamedChildCount() > 0 {
+			return unwrap(node.NamedChild(0))
 		}
-		parent = parent.Parent()
 	}
-
-	return strings.Join(parts, ".")
+	return node
 }
 
-func getNodeName(node *sitter.Node, src []byte) string {
+// declarationName returns the name a node contributes to a symbol path, or "" if it has none
+func declarationName(node *sitter.Node, src []byte) string {
 	switch node.Type() {
-	case "function_declaration", "generator_function_declaration":
-		// (function_declaration name: (identifier) @name)
-		return getChildContent(node, "name", src)
-
-	case "method_definition":
-		// (method_definition name: (property_identifier) @name)
-		return getChildContent(node, "name", src)
-
-	case "class_declaration", "class":
-		// (class_declaration name: (identifier) @name)
-		return getChildContent(node, "name", src)
-
-	case "interface_declaration":
-		// (interface_declaration name: (type_identifier) @name)
-		return getChildContent(node, "name", src)
-
-	case "type_alias_declaration":
-		// (type_alias_declaration name: (type_identifier) @name)
-		return getChildContent(node, "name", src)
-
-	case "variable_declarator":
-		// (variable_declarator name: (identifier) @name value: (arrow_function))
-		// Check if it's an arrow function or function expression
-		val := node.ChildByFieldName("value")
-		if val != nil && (val.Type() == "arrow_function" || val.Type() == "function_expression") {
-			return getChildContent(node, "name", src)
+	case "function_declaration", "generator_function_declaration", "function_signature",
+		"class_declaration", "abstract_class_declaration", "class",
+		"interface_declaration", "type_alias_declaration", "enum_declaration", "enum_assignment",
+		"method_definition", "method_signature", "abstract_method_signature",
+		"public_field_definition", "property_signature",
+		"internal_module", "module", "variable_declarator":
+		// (function_declaration name: (identifier)), (module name: (string)) ...
+		return unquote(getChildContent(node, "name", src))
+
+	case "field_definition":
+		// JavaScript class fields: (field_definition property: (property_identifier))
+		return getChildContent(node, "property", src)
+
+	case "pair":
+		// Object literal members: (pair key: (property_identifier) value: ...)
+		return unquote(getChildContent(node, "key", src))
+
+	case "property_identifier":
+		// Enum members without an initializer are bare identifiers in the enum body
+		if parent := node.Parent(); parent != nil && parent.Type() == "enum_body" {
+			return node.Content(src)
 		}
-		// Also handle simple variable assignments if needed, but usually we care about functions/classes
-		return getChildContent(node, "name", src)
 
-	case "lexical_declaration":
-		// const x = ...; lexical_declaration contains variable_declarator
-		// We might need to look inside if the comment is above 'const'
-		if node.NamedChildCount() > 0 {
-			firstChild := node.NamedChild(0)
-			if firstChild.Type() == "variable_declarator" {
-				return getNodeName(firstChild, src)
-			}
+	case "assignment_expression":
+		// module.exports.helper = function () {}
+		if left := node.ChildByFieldName("left"); left != nil &&
+			(left.Type() == "member_expression" || left.Type() == "identifier") {
+			return left.Content(src)
 		}
 
-	case "export_statement":
-		// export const x = ...; or export function ...
-		// We need to look at the declaration being exported
-		if node.NamedChildCount() > 0 {
-			decl := node.NamedChild(0) // declaration
-			return getNodeName(decl, src)
+	case "jsx_element":
+		if open := node.ChildByFieldName("open_tag"); open != nil {
+			return getChildContent(open, "name", src)
 		}
+
+	case "jsx_self_closing_element":
+		return getChildContent(node, "name", src)
 	}
 
 	return ""
@@ -115,3 +114,8 @@ func getChildContent(node *sitter.Node, fieldName string, src []byte) string {
 	}
 	return ""
 }
+
+// unquote strips the quotes of string names such as declare module "foo" or { "a-b": 1 }
+func unquote(name string) string {
+	return strings.Trim(name, "\"'`")
+}
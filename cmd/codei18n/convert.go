@@ -11,8 +11,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/studyzy/codei18n/adapters"
+	"github.com/studyzy/codei18n/adapters/hashcomment"
 	"github.com/studyzy/codei18n/core"
 	"github.com/studyzy/codei18n/core/config"
+	"github.com/studyzy/codei18n/core/doccomment"
 	"github.com/studyzy/codei18n/core/domain"
 	"github.com/studyzy/codei18n/core/mapping"
 	"github.com/studyzy/codei18n/core/utils"
@@ -56,12 +58,14 @@ func runConvert() {
 		cfg = config.DefaultConfig()
 	}
 
-	storePath := filepath.Join(".codei18n", "mappings.json")
-	store := mapping.NewStore(storePath)
+	store, err := mapping.ForBackend(cfg.MappingStorage)
+	if err != nil {
+		log.Fatal("%v", err)
+	}
 	if err := store.Load(); err != nil {
 		log.Fatal("加载映射文件失败: %v", err)
 	}
-	log.Info("Loaded store with %d comments", len(store.GetMapping().Comments))
+	defer store.Close()
 
 	// Identify files
 	var files []string
@@ -138,7 +142,7 @@ func runConvert() {
 	}
 }
 
-func processFile(file string, adapter core.LanguageAdapter, store *mapping.Store, cfg *config.Config) int {
+func processFile(file string, adapter core.LanguageAdapter, store core.MappingStore, cfg *config.Config) int {
 	// Read file
 	src, err := os.ReadFile(file)
 	if err != nil {
@@ -177,6 +181,11 @@ func processFile(file string, adapter core.LanguageAdapter, store *mapping.Store
 	}
 
 	for _, c := range comments {
+		// Directives must reach the compiler or linter byte for byte
+		if c.Type == domain.CommentTypeDirective {
+			continue
+		}
+
 		// Generate ID
 		if c.ID == "" {
 			c.ID = utils.GenerateCommentID(c)
@@ -189,21 +198,19 @@ func processFile(file string, adapter core.LanguageAdapter, store *mapping.Store
 		var found bool
 
 		// Normalize current comment text for comparison
-		normalizedCurrent := utils.NormalizeCommentText(c.SourceText)
+		normalizedCurrent := utils.NormalizeLanguageText(c.Language, c.SourceText)
 		log.Info("Current Text: '%s' (normalized: '%s')", c.SourceText, normalizedCurrent)
 
 		// If converting to SourceLanguage (e.g. en), we try to restore original
 		if convertTo == cfg.SourceLanguage {
 			// First, check if current text is already in target language (by ID)
-			if transMap, exists := store.GetMapping().Comments[c.ID]; exists {
-				if enText, hasEn := transMap[cfg.SourceLanguage]; hasEn {
-					normalizedEn := utils.NormalizeCommentText(enText)
-					if normalizedEn == normalizedCurrent {
-						// Already in target language, no conversion needed
-						targetText = enText
-						found = true
-						log.Info("Comment already in target language (ID=%s)", c.ID)
-					}
+			if enText, hasEn := store.Get(c.ID, cfg.SourceLanguage); hasEn {
+				normalizedEn := utils.NormalizeLanguageText(c.Language, enText)
+				if normalizedEn == normalizedCurrent {
+					// Already in target language, no conversion needed
+					targetText = enText
+					found = true
+					log.Info("Comment already in target language (ID=%s)", c.ID)
 				}
 			}
 
@@ -212,40 +219,45 @@ func processFile(file string, adapter core.LanguageAdapter, store *mapping.Store
 				// Restore Mode: ZH -> EN
 				// Current text is likely in LocalLanguage (ZH)
 				// Search in store by comparing normalized values
-				for id, transMap := range store.GetMapping().Comments {
-					zhText, hasZh := transMap[cfg.LocalLanguage]
-					enText, hasEn := transMap[cfg.SourceLanguage]
-
-					if hasZh && hasEn {
-						normalizedZh := utils.NormalizeCommentText(zhText)
-						if normalizedZh == normalizedCurrent {
-							targetText = enText
-							found = true
-							log.Info("Found by reverse lookup: ID=%s, ZH='%s' -> EN='%s'", id, zhText, enText)
-
-							// Key fix: When reverting to English, automatically generate IDs for new English comments and migrate existing translations to them.
-							// This way, subsequent scan/map updates can directly recognize this English comment and will not treat it as an untranslated new comment.
-
-							// 1. Construct the English version of the Comment object (simulating the converted state)
-							tempC := *c
-							tempC.SourceText = enText // Assuming mapping stores plain text or marked text, Normalize will process it
-
-							// 2. Calculate new ID
-							newID := utils.GenerateCommentID(&tempC)
-
-							// 3. If the new ID differs from the old ID (it definitely will, because the text has changed), then migrate the data
-							if newID != id {
-								log.Info("Migrating mapping for restored English comment: %s -> %s", id, newID)
-								// Ensure the new ID has bilingual data
-								store.Set(newID, cfg.SourceLanguage, enText)
-								store.Set(newID, cfg.LocalLanguage, zhText)
-								// Delete the old ID (based on Chinese text) to keep mappings clean
-								store.Delete(id)
-								log.Info("Deleted old mapping ID: %s", id)
-							}
-
-							break
-						}
+				var id, zhText, enText string
+				err := store.Iterate(func(entryID string, transMap map[string]string) bool {
+					zh, hasZh := transMap[cfg.LocalLanguage]
+					en, hasEn := transMap[cfg.SourceLanguage]
+					if hasZh && hasEn && utils.NormalizeLanguageText(c.Language, zh) == normalizedCurrent {
+						id, zhText, enText = entryID, zh, en
+						found = true
+						return false
+					}
+					return true
+				})
+				if err != nil {
+					log.Warn("读取映射失败: %v", err)
+				}
+
+				if found {
+					targetText = enText
+					log.Info("Found by reverse lookup: ID=%s, ZH='%s' -> EN='%s'", id, zhText, enText)
+
+					// Key fix: When reverting to English, automatically generate IDs for new English comments and migrate existing translations to them.
+					// This way, subsequent scan/map updates can directly recognize this English comment and will not treat it as an untranslated new comment.
+
+					// 1. Construct the English version of the Comment object (simulating the converted state)
+					tempC := *c
+					tempC.SourceText = enText // Assuming mapping stores plain text or marked text, Normalize will process it
+
+					// 2. Calculate new ID
+					newID := utils.GenerateCommentID(&tempC)
+
+					// 3. If the new ID differs from the old ID (it definitely will, because the text has changed), then migrate the data
+					if newID != id {
+						log.Info("Migrating mapping for restored English comment: %s -> %s", id, newID)
+						// Ensure the new ID has bilingual data
+						store.Set(newID, cfg.SourceLanguage, enText)
+						store.Set(newID, cfg.LocalLanguage, zhText)
+						store.SetAnchor(newID, domain.Anchor{File: c.File, Symbol: c.Symbol, Language: c.Language})
+						// Delete the old ID (based on Chinese text) to keep mappings clean
+						store.Delete(id)
+						log.Info("Deleted old mapping ID: %s", id)
 					}
 				}
 			}
@@ -269,12 +281,17 @@ func processFile(file string, adapter core.LanguageAdapter, store *mapping.Store
 		}
 
 		if found {
-			log.Info("Comparing: Src='%s' Tgt='%s'", normalizedCurrent, utils.NormalizeCommentText(targetText))
+			log.Info("Comparing: Src='%s' Tgt='%s'", normalizedCurrent, utils.NormalizeLanguageText(c.Language, targetText))
 		}
 
 		if found {
 			// Compare normalized texts to avoid unnecessary replacements
-			normalizedTarget := utils.NormalizeCommentText(targetText)
+			normalizedTarget := utils.NormalizeLanguageText(c.Language, targetText)
+			if c.Type == domain.CommentTypeDoc && !doccomment.ParseLanguage(c.Language, c.SourceText).PreservedIn(targetText) {
+				// Inline doc markup (e.g. <see cref="..."/> or \ref Foo) must survive translation verbatim
+				log.Warn("翻译丢失了文档注释标记，跳过: '%s' -> '%s'", c.SourceText, targetText)
+				continue
+			}
 			if normalizedTarget != normalizedCurrent {
 				log.Info("Applying change: '%s' -> '%s'", normalizedCurrent, normalizedTarget)
 				// Calculate offsets
@@ -284,21 +301,18 @@ func processFile(file string, adapter core.LanguageAdapter, store *mapping.Store
 				startOffset := lineOffsets[startLineIdx] + c.Range.StartCol - 1
 				endOffset := lineOffsets[endLineIdx] + c.Range.EndCol - 1
 
-				finalText := targetText
-				// If targetText doesn't have markers, add them based on original type
-				// Note: For Rust, we need to handle /// and //! markers too
-				if c.Type == domain.CommentTypeDoc {
-					// Need heuristic to determine /// or //!
-					// Check original source
-					if strings.HasPrefix(c.SourceText, "///") && !strings.HasPrefix(targetText, "///") {
-						finalText = "/// " + targetText
-					} else if strings.HasPrefix(c.SourceText, "//!") && !strings.HasPrefix(targetText, "//!") {
-						finalText = "//! " + targetText
+				finalText, formatted := "", false
+				if f, ok := adapter.(core.CommentFormatter); ok {
+					finalText, formatted = f.FormatComment(c, targetText)
+				}
+				if !formatted {
+					finalText = applyCommentMarkers(c, targetText)
+				}
+				if c.Range.EndLine > c.Range.StartLine && !strings.Contains(c.SourceText, "\n") {
+					// A paragraph of grouped line comments
+					if marker := utils.LineCommentMarker(c.SourceText); marker != "" {
+						finalText = reflowLineComment(finalText, marker, lines[startLineIdx:endLineIdx+1], c.Range.StartCol)
 					}
-				} else if c.Type == domain.CommentTypeLine && !strings.HasPrefix(targetText, "//") {
-					finalText = "// " + targetText
-				} else if c.Type == domain.CommentTypeBlock && !strings.HasPrefix(targetText, "/*") {
-					finalText = "/* " + targetText + " */"
 				}
 
 				replacements = append(replacements, replacement{
@@ -343,3 +357,153 @@ func processFile(file string, adapter core.LanguageAdapter, store *mapping.Store
 
 	return missingCount
 }
+
+// applyCommentMarkers adds comment markers to targetText based on the original comment
+// if the translation doesn't carry them already.
+func applyCommentMarkers(c *domain.Comment, targetText string) string {
+	// Python style: # comments and triple-quoted docstrings
+	if hashCommentLanguages[c.Language] && strings.HasPrefix(c.SourceText, "#") {
+		if strings.HasPrefix(targetText, "#") {
+			return targetText
+		}
+		return "# " + targetText
+	}
+	if q := docstringDelimiter(c.SourceText); c.Language == "python" && q != "" {
+		if docstringDelimiter(targetText) != "" {
+			return targetText
+		}
+		// A bare delimiter inside the body would terminate the literal early
+		prefix := c.SourceText[:strings.Index(c.SourceText, q)]
+		return prefix + q + strings.ReplaceAll(targetText, q, "") + q
+	}
+
+	// SQL COMMENT clause literals, with '' escaping
+	if c.Language == "sql" && strings.HasPrefix(c.SourceText, "'") {
+		if strings.HasPrefix(targetText, "'") {
+			return targetText
+		}
+		return "'" + strings.ReplaceAll(targetText, "'", "''") + "'"
+	}
+
+	// Ruby =begin/=end blocks; the markers must stay on their own lines
+	if c.Language == "ruby" && strings.HasPrefix(c.SourceText, "=begin") {
+		if strings.HasPrefix(targetText, "=begin") {
+			return targetText
+		}
+		// A line starting with =end inside the body would terminate the block early
+		lines := strings.Split(targetText, "\n")
+		body := lines[:0]
+		for _, line := range lines {
+			if !strings.HasPrefix(line, "=end") {
+				body = append(body, line)
+			}
+		}
+		return "=begin\n" + strings.Join(body, "\n") + "\n=end"
+	}
+
+	// Lua long comments keep the bracket level of the original
+	if m := utils.LuaLongBracket.FindStringSubmatch(c.SourceText); c.Language == "lua" && m != nil {
+		if utils.LuaLongBracket.MatchString(targetText) {
+			return targetText
+		}
+		closing := "]" + m[1] + "]"
+		return m[0] + " " + strings.ReplaceAll(targetText, closing, "") + " " + closing
+	}
+
+	// SQL / Lua style -- comments (--- for LuaDoc)
+	if dashCommentLanguages[c.Language] && strings.HasPrefix(c.SourceText, "--") {
+		marker := "--"
+		if strings.HasPrefix(c.SourceText, "---") && c.Type == domain.CommentTypeDoc {
+			marker = "---"
+		}
+		if strings.HasPrefix(targetText, marker) {
+			return targetText
+		}
+		return marker + " " + targetText
+	}
+
+	// HTML / template comments
+	if markupCommentLanguages[c.Language] && strings.HasPrefix(c.SourceText, "<!--") {
+		if strings.HasPrefix(targetText, "<!--") {
+			return targetText
+		}
+		return "<!-- " + strings.ReplaceAll(targetText, "-->", "") + " -->"
+	}
+
+	// Note: For Rust, we need to handle /// and //! markers too
+	if c.Type == domain.CommentTypeDoc {
+		// Need heuristic to determine /// or //!
+		// Check original source
+		if strings.HasPrefix(c.SourceText, "///") && !strings.HasPrefix(targetText, "///") {
+			return "/// " + targetText
+		} else if strings.HasPrefix(c.SourceText, "//!") && !strings.HasPrefix(targetText, "//!") {
+			return "//! " + targetText
+		}
+	} else if c.Type == domain.CommentTypeLine && strings.HasPrefix(c.SourceText, "//") && !strings.HasPrefix(targetText, "//") {
+		// Marker-less fragments (e.g. the description of "// MARK: - ...") are replaced in place
+		return "// " + targetText
+	} else if c.Type == domain.CommentTypeBlock && strings.HasPrefix(c.SourceText, "/*") && !strings.HasPrefix(targetText, "/*") {
+		return "/* " + targetText + " */"
+	}
+	return targetText
+}
+
+// minReflowWidth keeps re-flowed paragraphs readable when the original lines are very short
+const minReflowWidth = 20
+
+// reflowLineComment lays the translation of a grouped line comment out over marker-prefixed
+// lines no wider than the original paragraph, indented like its first line.
+func reflowLineComment(text, marker string, original []string, startCol int) string {
+	indent := strings.TrimRight(original[0], "\r")[:startCol-1]
+	width := minReflowWidth
+	for _, line := range original {
+		body := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), marker))
+		width = max(width, utils.DisplayWidth(body))
+	}
+
+	body := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), marker))
+	wrapped := utils.WrapParagraph(body, width)
+	for i, line := range wrapped {
+		wrapped[i] = strings.TrimRight(marker+" "+line, " ")
+	}
+	return strings.Join(wrapped, "\n"+indent)
+}
+
+// hashCommentLanguages are the built-in languages whose line comments start with #.
+// In other languages a leading # is part of the text, e.g. "#1" in a Doxygen fragment.
+var hashCommentLanguages = map[string]bool{
+	"python":               true,
+	"ruby":                 true,
+	"php":                  true,
+	hashcomment.Shell:      true,
+	hashcomment.YAML:       true,
+	hashcomment.TOML:       true,
+	hashcomment.Dockerfile: true,
+	hashcomment.Makefile:   true,
+	hashcomment.Dotenv:     true,
+}
+
+// markupCommentLanguages are the built-in languages with <!-- --> comments
+var markupCommentLanguages = map[string]bool{
+	"vue":    true,
+	"svelte": true,
+	"html":   true,
+}
+
+// dashCommentLanguages are the built-in languages whose line comments start with --
+var dashCommentLanguages = map[string]bool{
+	"sql": true,
+	"lua": true,
+}
+
+// docstringDelimiter returns the triple quote used by a Python docstring literal
+// (after any string prefix such as r or u), or "" if text is not a docstring.
+func docstringDelimiter(text string) string {
+	t := strings.TrimLeft(text, "rRuUbBfF")
+	for _, q := range []string{`"""`, "'''"} {
+		if strings.HasPrefix(t, q) {
+			return q
+		}
+	}
+	return ""
+}
@@ -3,7 +3,9 @@ package main
 import (
 	"fmt"
 	"os"
-	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -14,9 +16,13 @@ import (
 )
 
 var (
-	mapScanDir string
-	mapDryRun  bool
-	mapLang    string
+	mapScanDir    string
+	mapDryRun     bool
+	mapLang       string
+	mapPruneGrace string
+	mapArchive    bool
+	mapMigrateTo  string
+	mapKeepSource bool
 )
 
 // mapCmd represents the map command
@@ -36,6 +42,33 @@ var mapUpdateCmd = &cobra.Command{
 	},
 }
 
+// mapPruneCmd represents the map prune command
+var mapPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "清理失效的映射",
+	Long: `扫描项目中的注释，删除映射文件中对应注释已不存在的条目。
+
+使用 --grace 为失效条目保留宽限期，使用 --archive 将其移入归档区，
+归档的翻译在之后的 map update 中仍可通过重新锚定恢复。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runMapPrune()
+	},
+}
+
+// mapMigrateCmd represents the map migrate command
+var mapMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "切换映射存储后端",
+	Long: `将映射从当前存储后端复制到另一个后端，并更新配置中的 mappingStorage。
+
+file:    单个 .codei18n/mappings.json 文件
+sharded: 按源文件拆分到 .codei18n/mappings/<path>.json，便于代码评审与合并
+bolt:    嵌入式数据库 .codei18n/mappings.db，增量写入，适合大型仓库`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runMapMigrate()
+	},
+}
+
 // mapGetCmd represents the map get command
 var mapGetCmd = &cobra.Command{
 	Use:   "get [commentID]",
@@ -49,11 +82,21 @@ var mapGetCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(mapCmd)
 	mapCmd.AddCommand(mapUpdateCmd)
+	mapCmd.AddCommand(mapPruneCmd)
+	mapCmd.AddCommand(mapMigrateCmd)
 	mapCmd.AddCommand(mapGetCmd)
 
 	mapUpdateCmd.Flags().StringVar(&mapScanDir, "scan-dir", ".", "扫描目录以更新映射")
 	mapUpdateCmd.Flags().BoolVar(&mapDryRun, "dry-run", false, "仅显示变更，不写入文件")
 
+	mapPruneCmd.Flags().StringVar(&mapScanDir, "scan-dir", ".", "扫描目录以确定失效的映射")
+	mapPruneCmd.Flags().BoolVar(&mapDryRun, "dry-run", false, "仅显示将被清理的条目，不写入文件")
+	mapPruneCmd.Flags().StringVar(&mapPruneGrace, "grace", "0", "宽限期，注释失效超过该时长才清理 (如 72h、30d)")
+	mapPruneCmd.Flags().BoolVar(&mapArchive, "archive", false, "将失效条目移入归档区而不是删除")
+
+	mapMigrateCmd.Flags().StringVar(&mapMigrateTo, "to", "", "目标存储后端 (file、sharded 或 bolt)")
+	mapMigrateCmd.Flags().BoolVar(&mapKeepSource, "keep-source", false, "迁移后保留旧的映射存储")
+
 	mapGetCmd.Flags().StringVar(&mapLang, "lang", "", "目标语言代码 (默认使用配置中的 LocalLanguage)")
 }
 
@@ -71,6 +114,46 @@ func runMapUpdate() {
 	}
 
 	log.Success("发现 %d 条注释，新增 %d 条映射", result.TotalComments, result.AddedCount)
+	reportMigrations(result)
+	if mapDryRun {
+		log.Info("Dry run 模式，不保存文件")
+	} else {
+		log.Success("映射文件已更新: %s", result.StorePath)
+	}
+}
+
+func runMapPrune() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Warn("无法加载配置，使用默认值: %v", err)
+		cfg = config.DefaultConfig()
+	}
+
+	grace, err := parseGrace(mapPruneGrace)
+	if err != nil {
+		log.Fatal("无效的宽限期 %q: %v", mapPruneGrace, err)
+	}
+
+	result, err := workflow.MapPrune(cfg, mapScanDir, workflow.PruneOptions{
+		DryRun:  mapDryRun,
+		Grace:   grace,
+		Archive: mapArchive,
+	})
+	if err != nil {
+		log.Fatal("Map prune failed: %v", err)
+	}
+
+	if result.UnsyncedCount > 0 {
+		log.Warn("有 %d 条注释尚未写入映射，建议先运行 map update 以迁移可沿用的翻译", result.UnsyncedCount)
+	}
+	action := "删除"
+	if mapArchive {
+		action = "归档"
+	}
+	for _, id := range result.Pruned {
+		log.Info("%s: %s", action, id)
+	}
+	log.Success("发现 %d 条失效映射，%s %d 条，%d 条仍在宽限期内", result.StaleCount, action, len(result.Pruned), result.PendingCount)
 	if mapDryRun {
 		log.Info("Dry run 模式，不保存文件")
 	} else {
@@ -78,6 +161,42 @@ func runMapUpdate() {
 	}
 }
 
+// parseGrace parses a grace period, accepting a day suffix ("30d") besides Go durations
+func parseGrace(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+func runMapMigrate() {
+	if mapMigrateTo == "" {
+		log.Fatal("必须指定目标存储后端: --to <file|sharded|bolt>")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Warn("无法加载配置，使用默认值: %v", err)
+		cfg = config.DefaultConfig()
+	}
+
+	result, err := workflow.MapMigrate(cfg, mapMigrateTo, mapKeepSource)
+	if err != nil {
+		log.Fatal("Map migrate failed: %v", err)
+	}
+	log.Success("已将 %d 条映射从 %s 迁移到 %s: %s", result.Count, result.From, result.To, result.StorePath)
+
+	if err := config.UpdateConfigFile("mappingStorage", result.To); err != nil {
+		log.Warn("更新配置失败，请手动将 mappingStorage 设置为 %s: %v", result.To, err)
+		return
+	}
+	log.Success("配置已更新: mappingStorage = %s", result.To)
+}
+
 func runMapGet(commentID string) {
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -89,11 +208,14 @@ func runMapGet(commentID string) {
 		targetLang = cfg.LocalLanguage
 	}
 
-	storePath := filepath.Join(".codei18n", "mappings.json")
-	store := mapping.NewStore(storePath)
+	store, err := mapping.ForBackend(cfg.MappingStorage)
+	if err != nil {
+		log.Fatal("%v", err)
+	}
 	if err := store.Load(); err != nil {
 		log.Fatal("加载映射文件失败: %v", err)
 	}
+	defer store.Close()
 
 	if text, ok := store.Get(commentID, targetLang); ok {
 		fmt.Println(text)
@@ -102,3 +224,24 @@ func runMapGet(commentID string) {
 		os.Exit(1)
 	}
 }
+
+// reportMigrations lists the translations re-attached to renamed, moved or edited comments
+func reportMigrations(result *workflow.MapUpdateResult) {
+	if len(result.Migrated) == 0 {
+		return
+	}
+
+	changed := 0
+	for _, m := range result.Migrated {
+		switch {
+		case m.TextChanged():
+			changed++
+			log.Warn("沿用翻译（原文有改动，相似度 %.0f%%，建议复核）: %s %s %s -> %s", m.Similarity*100, m.File, m.Symbol, m.OldID, m.NewID)
+		case m.Renamed:
+			log.Info("沿用翻译（文件重命名）: %s %s %s -> %s", m.File, m.Symbol, m.OldID, m.NewID)
+		default:
+			log.Info("沿用翻译: %s %s %s -> %s", m.File, m.Symbol, m.OldID, m.NewID)
+		}
+	}
+	log.Success("迁移 %d 条已有翻译（其中 %d 条原文有改动），%d 条新注释待翻译", len(result.Migrated), changed, result.AddedCount)
+}
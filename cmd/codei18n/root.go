@@ -7,6 +7,9 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"github.com/studyzy/codei18n/adapters"
+	"github.com/studyzy/codei18n/core/config"
 )
 
 var (
@@ -57,6 +60,7 @@ func initConfig() {
 		if verbose {
 			fmt.Fprintf(os.Stderr, "Using config file: %s\n", viper.ConfigFileUsed())
 		}
+		loadLanguages()
 		return
 	}
 
@@ -82,6 +86,27 @@ func initConfig() {
 	if !configLoaded && verbose {
 		fmt.Fprintln(os.Stderr, "未找到配置文件，使用内置默认配置")
 	}
+
+	loadLanguages()
+}
+
+// loadLanguages applies the adapter settings of the configuration and registers the
+// project's query-driven languages from .codei18n/languages and the external adapters
+// listed in the configuration
+func loadLanguages() {
+	if err := adapters.LoadLanguages(filepath.Join(".codei18n", "languages")); err != nil {
+		fmt.Fprintf(os.Stderr, "加载自定义语言失败: %v\n", err)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "解析配置失败: %v\n", err)
+		return
+	}
+	adapters.Configure(cfg)
+	if err := adapters.LoadExternalAdapters(cfg.ExternalAdapters); err != nil {
+		fmt.Fprintf(os.Stderr, "加载外部适配器失败: %v\n", err)
+	}
 }
 
 func loadConfigFile(path string, merge bool) (bool, error) {
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/spf13/cobra"
 
@@ -94,10 +93,13 @@ func runScan() {
 		}
 
 		// Load store
-		storePath := filepath.Join(".codei18n", "mappings.json")
-		store := mapping.NewStore(storePath)
+		store, err := mapping.ForBackend(cfg.MappingStorage)
+		if err != nil {
+			log.Fatal("%v", err)
+		}
 		// We ignore error here, if file doesn't exist, we just don't show translations
 		// or better: log.Warn
+		defer store.Close()
 		if err := store.Load(); err != nil {
 			log.Warn("加载映射文件失败: %v", err)
 		} else {
@@ -19,13 +19,14 @@ var (
 	translateBatchSize   int
 	translateTarget      string
 	translateSource      string
+	translateLanguage    string
 )
 
 var translateCmd = &cobra.Command{
 	Use:   "translate",
 	Short: "自动翻译缺失的注释",
 	Long: `调用配置的翻译引擎（LLM(OpenAI/DeepSeek) 或本地 Ollama）自动翻译映射文件中缺失的条目。
-如果通过管道传入文本，则直接翻译该文本并输出到标准输出。
+如果通过管道传入文本，则直接翻译该文本并输出到标准输出；用 --language 指明注释所属的编程语言，以保护该语言特有的文档标记。
 可以使用 --target 和 --source 标志来覆盖默认的语言设置。`,
 	Run: func(cmd *cobra.Command, args []string) {
 		runTranslate()
@@ -41,6 +42,7 @@ func init() {
 	translateCmd.Flags().IntVar(&translateBatchSize, "batch-size", 0, "每批翻译的数量 (覆盖配置)")
 	translateCmd.Flags().StringVarP(&translateTarget, "target", "t", "", "指定目标语言 (如 en, zh-CN)")
 	translateCmd.Flags().StringVarP(&translateSource, "source", "s", "", "指定源语言 (如 zh-CN, en)")
+	translateCmd.Flags().StringVar(&translateLanguage, "language", "", "管道输入的注释所属的编程语言 (如 csharp, cpp)")
 }
 
 func runTranslate() {
@@ -79,7 +81,7 @@ func runTranslate() {
 			return
 		}
 
-		translated, err := workflow.TranslateText(cfg, opts, text)
+		translated, err := workflow.TranslateText(cfg, opts, translateLanguage, text)
 		if err != nil {
 			log.Fatal("Translation failed: %v", err)
 		}
@@ -16,6 +16,25 @@ type Config struct {
 	TranslationProvider string            `json:"translationProvider" mapstructure:"translationProvider"`
 	TranslationConfig   map[string]string `json:"translationConfig" mapstructure:"translationConfig"`
 	BatchSize           int               `json:"batchSize" mapstructure:"batchSize"`
+	SQLCommentClauses   bool              `json:"sqlCommentClauses,omitempty" mapstructure:"sqlCommentClauses"`
+	LanguageOverrides   map[string]string `json:"languageOverrides,omitempty" mapstructure:"-"`
+	ExternalAdapters    []ExternalAdapter `json:"externalAdapters,omitempty" mapstructure:"externalAdapters"`
+	CommentGrouping     string            `json:"commentGrouping,omitempty" mapstructure:"commentGrouping"`
+	MappingStorage      string            `json:"mappingStorage,omitempty" mapstructure:"mappingStorage"`
+}
+
+// ExternalAdapter configures a language adapter implemented by a separate executable
+type ExternalAdapter struct {
+	// Command is the executable to run. A bare name is looked up in PATH; a relative path
+	// such as ./tools/adapter is relative to the working directory, the project root
+	// whose .codei18n directory the CLI reads
+	Command string   `json:"command" mapstructure:"command"`
+	Args    []string `json:"args,omitempty" mapstructure:"args"`
+	// Language and Extensions are required: they register the adapter without running it
+	Language   string   `json:"language,omitempty" mapstructure:"language"`
+	Extensions []string `json:"extensions,omitempty" mapstructure:"extensions"`
+	// Timeout is the per-invocation timeout in seconds (default 30)
+	Timeout int `json:"timeout,omitempty" mapstructure:"timeout"`
 }
 
 // DefaultConfig returns the default configuration
@@ -45,6 +64,9 @@ func LoadConfig() (*Config, error) {
 	if err := viper.Unmarshal(&cfg); err != nil {
 		return nil, err
 	}
+	// Extension keys such as ".inc" contain viper's key delimiter and cannot be decoded
+	// into the struct; keys come back lower-cased
+	cfg.LanguageOverrides = viper.GetStringMapString("languageOverrides")
 	// Ensure BatchSize is positive
 	if cfg.BatchSize <= 0 {
 		cfg.BatchSize = 10
@@ -85,3 +107,24 @@ func SaveConfig(cfg *Config) error {
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(cfg)
 }
+
+// UpdateConfigFile sets one key in the .codei18n/config.json file, keeping the other keys as written
+func UpdateConfigFile(key string, value interface{}) error {
+	configFile := filepath.Join(".codei18n", "config.json")
+	raw, err := os.ReadFile(configFile)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]interface{})
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return err
+	}
+	values[key] = value
+
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(configFile, append(data, '\n'), 0644)
+}
@@ -0,0 +1,215 @@
+// Package doccomment splits comment text into translatable prose and protected segments.
+//
+// Protected segments are doc markup that must survive translation byte for byte: tags and
+// their arguments (@param name, @throws IOException, @return {string}), inline tags
+// ({@link Foo#bar}), intra-doc and Go doc links ([`Vec`], [io.Reader]), inline code, URLs,
+// and fenced or indented code blocks. Languages with their own doc markup add to these:
+// XML doc tags in C#, Doxygen commands in C and C++, YARD in Ruby, PHPDoc, EmmyLua, DocC in
+// Swift and dartdoc. Translators receive the prose with numbered
+// placeholders in place of the protected segments, which are re-inserted afterwards.
+package doccomment
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// protectedPattern matches every kind of protected segment. Alternatives are tried in order,
+// so code blocks win over the inline markup they contain.
+var protectedPattern = regexp.MustCompile(strings.Join([]string{
+	// Fenced code blocks, including the comment prefixes of the lines in between
+	"(?s:```.*?```)",
+	// Indented code lines after a comment marker: "//\tcode", "///     code", " *     code"
+	`(?m:^[ \t]*(?://[/!]?|#+|\*|--+)(?:\t| {4,})\S.*$)`,
+	// Inline tags: {@link Foo#bar label}, {@code x}, {@inheritDoc}, {@template name}
+	`\{@[^{}]*\}`,
+	// Tags that take a name or reference, with an optional JSDoc {Type}; ref, p, a and c are
+	// the Doxygen spellings
+	`\B@(?:ref|p|a|c|param|arg|argument|prop|property|tparam|typeparam|template|throws|exception|raises?|see|since|version|var|type|typedef|field|key|callback|event|fires|memberof|alias|name)\b` +
+		`(?:[ \t]*\{[^{}\n]*\})?(?:[ \t]+[\w.$:#\[\]'"=-]+)?`,
+	// Any other tag, with an optional JSDoc {Type}: @return {string}, @deprecated, @Override
+	`\B@[A-Za-z][\w-]*(?:[ \t]*\{[^{}\n]*\})?`,
+	// Inline code, including DocC ``Symbol`` links
+	"``[^`]+``|`[^`\n]+`",
+	// Rust intra-doc links [`Vec::new`] and Go doc links [io.Reader], [*bytes.Buffer]
+	"\\[`[^`\\]]+`\\](?:\\([^)\\s]*\\))?",
+	`\[\*?[A-Za-z_][\w:.<>]*(?:\(\))?\](?:\([^)\s]*\))?`,
+	// Markdown link destinations: "[the docs](https://...)" keeps its link text translatable
+	`\]\([^)\s]+\)`,
+	// Bare URLs
+	`https?://[^\s)>\]]+`,
+}, "|"))
+
+// Per-language doc markup, protected in addition to protectedPattern
+var (
+	// xmlDocPattern matches XML doc tags such as <see cref="Foo"/> or <paramref name="x"/>
+	xmlDocPattern = regexp.MustCompile(`<[^<>]*>`)
+	// doxygenPattern matches Doxygen commands such as \ref Foo, @p name or \c value
+	doxygenPattern = regexp.MustCompile(`[@\\](?:ref|p|a|c)\s+[A-Za-z_][\w:.]*|[@\\][A-Za-z]+`)
+	// yardPattern matches YARD tags, their parameter names and [Type] lists
+	yardPattern = regexp.MustCompile(`@(?:param|option|yieldparam)\s+\w+|@[a-z_]+|\[[^\]\n]*\]`)
+	// phpDocPattern matches PHPDoc tags such as @param or {@inheritDoc} and $variables
+	phpDocPattern = regexp.MustCompile(`@[A-Za-z][\w-]*|\$\w+`)
+	// emmyPattern matches LuaDoc / EmmyLua annotations such as @param
+	emmyPattern = regexp.MustCompile(`@[A-Za-z]\w*`)
+	// doccPattern matches DocC callouts such as "- Parameter name:" / "- Returns:" and ``Symbol`` links
+	doccPattern = regexp.MustCompile("-\\s+(?:Parameters?|Returns|Throws|Note|Warning|Important|Precondition|Postcondition|SeeAlso)(?:\\s+\\w+)?:|``[^`]+``")
+	// dartdocPattern matches dartdoc [references] and {@template}/{@macro} directives
+	dartdocPattern = regexp.MustCompile(`\[[\w.]+\]|\{@[^}]*\}`)
+)
+
+// languagePatterns lists the doc markup of each language that has its own
+var languagePatterns = map[string]*regexp.Regexp{
+	"csharp": xmlDocPattern,
+	"c":      doxygenPattern,
+	"cpp":    doxygenPattern,
+	"ruby":   yardPattern,
+	"php":    phpDocPattern,
+	"lua":    emmyPattern,
+	"swift":  doccPattern,
+	"dart":   dartdocPattern,
+}
+
+// combinedPatterns caches protectedPattern joined with each language pattern
+var combinedPatterns = func() map[string]*regexp.Regexp {
+	combined := make(map[string]*regexp.Regexp, len(languagePatterns))
+	for lang, p := range languagePatterns {
+		combined[lang] = regexp.MustCompile(protectedPattern.String() + "|" + p.String())
+	}
+	return combined
+}()
+
+// placeholderPattern matches the placeholders Masked puts in place of protected segments
+var placeholderPattern = regexp.MustCompile(`⟦(\d+)⟧`)
+
+// Segment is a run of comment text
+type Segment struct {
+	Text      string
+	Protected bool
+}
+
+// Doc is a comment split into prose and protected segments
+type Doc struct {
+	Segments []Segment
+}
+
+// Parse splits text into segments using the markup shared by all languages. Text that
+// already contains placeholder brackets is treated as plain prose so that Restore cannot
+// confuse them.
+func Parse(text string) *Doc {
+	return ParseLanguage("", text)
+}
+
+// ParseLanguage is like Parse, but also protects the doc markup of the given language
+// (as reported by its adapter). Unknown or empty languages get the shared markup only.
+func ParseLanguage(language, text string) *Doc {
+	pattern, ok := combinedPatterns[language]
+	if !ok {
+		pattern = protectedPattern
+	}
+
+	d := &Doc{}
+	if strings.ContainsAny(text, "⟦⟧") {
+		d.Segments = []Segment{{Text: text}}
+		return d
+	}
+
+	last := 0
+	for _, loc := range pattern.FindAllStringIndex(text, -1) {
+		if loc[0] > last {
+			d.Segments = append(d.Segments, Segment{Text: text[last:loc[0]]})
+		}
+		d.Segments = append(d.Segments, Segment{Text: text[loc[0]:loc[1]], Protected: true})
+		last = loc[1]
+	}
+	if last < len(text) || len(d.Segments) == 0 {
+		d.Segments = append(d.Segments, Segment{Text: text[last:]})
+	}
+	return d
+}
+
+// Protected returns the protected segments in order
+func (d *Doc) Protected() []string {
+	var protected []string
+	for _, s := range d.Segments {
+		if s.Protected {
+			protected = append(protected, s.Text)
+		}
+	}
+	return protected
+}
+
+// PreservedIn reports whether every protected segment appears unchanged in target, a
+// translation made without masking, e.g. by hand
+func (d *Doc) PreservedIn(target string) bool {
+	for _, p := range d.Protected() {
+		if !strings.Contains(target, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasProse reports whether anything besides protected segments, comment markers and
+// punctuation is left to translate
+func (d *Doc) HasProse() bool {
+	for _, s := range d.Segments {
+		if !s.Protected && strings.ContainsFunc(s.Text, unicode.IsLetter) {
+			return true
+		}
+	}
+	return false
+}
+
+// Masked returns the text to send to a translator: the prose with ⟦1⟧, ⟦2⟧... in place
+// of the protected segments. Text without protected segments is returned unchanged.
+func (d *Doc) Masked() string {
+	var b strings.Builder
+	n := 0
+	for _, s := range d.Segments {
+		if s.Protected {
+			n++
+			fmt.Fprintf(&b, "⟦%d⟧", n)
+		} else {
+			b.WriteString(s.Text)
+		}
+	}
+	return b.String()
+}
+
+// Restore re-inserts the protected segments into a translation of Masked. It fails if the
+// translator dropped, duplicated or invented a placeholder.
+func (d *Doc) Restore(translated string) (string, error) {
+	protected := d.Protected()
+	if len(protected) == 0 {
+		return translated, nil
+	}
+	seen := make([]bool, len(protected))
+
+	var err error
+	restored := placeholderPattern.ReplaceAllStringFunc(translated, func(p string) string {
+		var n int
+		fmt.Sscanf(placeholderPattern.FindStringSubmatch(p)[1], "%d", &n)
+		switch {
+		case n < 1 || n > len(protected):
+			err = fmt.Errorf("unknown placeholder %s in translation", p)
+		case seen[n-1]:
+			err = fmt.Errorf("placeholder %s repeated in translation", p)
+		default:
+			seen[n-1] = true
+			return protected[n-1]
+		}
+		return p
+	})
+	if err != nil {
+		return "", err
+	}
+	for i, ok := range seen {
+		if !ok {
+			return "", fmt.Errorf("translation lost protected segment %q", protected[i])
+		}
+	}
+	return restored, nil
+}
@@ -0,0 +1,93 @@
+package doccomment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_ProtectedSegments(t *testing.T) {
+	cases := map[string][]string{
+		"/**\n * Sends a request.\n * @param {string} url the target\n * @return {Promise} the response\n */": {
+			"@param {string} url", "@return {Promise}",
+		},
+		"/** Use {@link Client#send(Request)} instead. @since 2.1 */": {
+			"{@link Client#send(Request)}", "@since 2.1",
+		},
+		"/// Returns a [`Vec`] built by [Builder::build].":       {"[`Vec`]", "[Builder::build]"},
+		"// Read reads from [io.Reader] until `EOF`.":            {"[io.Reader]", "`EOF`"},
+		"// See [the docs](https://example.com/a) for details.":  {"](https://example.com/a)"},
+		"// Contact admin@example.com for access.":               nil,
+		"/// Example:\n/// ```\n/// let x = add(1, 2);\n/// ```": {"```\n/// let x = add(1, 2);\n/// ```"},
+		"// For example:\n//\tx := New()\n//\tx.Run()":           {"//\tx := New()", "//\tx.Run()"},
+		"# @throws IOException if the file is missing":           {"@throws IOException"},
+	}
+	for text, expected := range cases {
+		assert.Equal(t, expected, Parse(text).Protected(), text)
+	}
+}
+
+func TestMaskAndRestore(t *testing.T) {
+	text := "/** @param name the user name, see {@link User} */"
+	doc := Parse(text)
+	assert.Equal(t, "/** ⟦1⟧ the user name, see ⟦2⟧ */", doc.Masked())
+
+	restored, err := doc.Restore("/** ⟦1⟧ 用户名，参见 ⟦2⟧ */")
+	require.NoError(t, err)
+	assert.Equal(t, "/** @param name 用户名，参见 {@link User} */", restored)
+
+	_, err = doc.Restore("/** ⟦1⟧ 用户名 */")
+	assert.Error(t, err, "a lost placeholder must be rejected")
+	_, err = doc.Restore("/** ⟦1⟧ ⟦1⟧ ⟦2⟧ */")
+	assert.Error(t, err, "a repeated placeholder must be rejected")
+	_, err = doc.Restore("/** ⟦1⟧ ⟦2⟧ ⟦3⟧ */")
+	assert.Error(t, err, "an invented placeholder must be rejected")
+}
+
+func TestPlainTextUnchanged(t *testing.T) {
+	for _, text := range []string{"// Adds two numbers", "# 中文注释", "// literal ⟦1⟧ bracket @param x"} {
+		doc := Parse(text)
+		assert.Equal(t, text, doc.Masked())
+		restored, err := doc.Restore(text)
+		require.NoError(t, err)
+		assert.Equal(t, text, restored)
+	}
+}
+
+func TestHasProse(t *testing.T) {
+	assert.True(t, Parse("// @param x the value").HasProse())
+	assert.False(t, Parse("// @Override").HasProse())
+	assert.False(t, Parse("//\tfmt.Println(x)").HasProse())
+}
+
+func TestParseLanguage_LanguageMarkup(t *testing.T) {
+	cases := []struct {
+		language, text string
+		expected       []string
+	}{
+		{"csharp", `/// Returns the <see cref="Account.Balance"/> of <paramref name="id"/>.`,
+			[]string{`<see cref="Account.Balance"/>`, `<paramref name="id"/>`}},
+		{"cpp", `/// Forwards \p request to \ref Router::dispatch, @c nullptr on failure.`,
+			[]string{`\p request`, `\ref Router::dispatch`, `@c nullptr`}},
+		{"ruby", "# @param name [String, nil] the user name", []string{"@param name", "[String, nil]"}},
+		{"php", "/** Escapes $value for the query. */", []string{"$value"}},
+		{"swift", "/// - Parameter count: how many to take", []string{"- Parameter count:"}},
+		// The shared markup still applies
+		{"csharp", "/// See https://example.com/api.", []string{"https://example.com/api."}},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, ParseLanguage(tc.language, tc.text).Protected(), tc.text)
+	}
+
+	// Language markup is not protected for other languages
+	assert.Empty(t, Parse(`/// Returns the <see cref="Balance"/>.`).Protected())
+	assert.Empty(t, ParseLanguage("go", `// Forwards \p request.`).Protected())
+}
+
+func TestPreservedIn(t *testing.T) {
+	doc := ParseLanguage("csharp", `/// Returns the <see cref="Balance"/>.`)
+	assert.True(t, doc.PreservedIn(`/// 返回 <see cref="Balance"/>。`))
+	assert.False(t, doc.PreservedIn(`/// 返回 <参见 cref="Balance"/>。`))
+	assert.True(t, Parse("// Adds two numbers").PreservedIn("// 两数相加"))
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // TextRange represents the range of text in the source code (1-based)
 type TextRange struct {
 	StartLine int `json:"startLine"` // Start line number
@@ -15,6 +17,9 @@ const (
 	CommentTypeLine  CommentType = "line"  // Single line comment //
 	CommentTypeBlock CommentType = "block" // Block comment /* */
 	CommentTypeDoc   CommentType = "doc"   // Documentation comment
+	// CommentTypeDirective is a compiler or tool directive (//go:generate, // eslint-disable, # noqa).
+	// Directives are reported by scan but never added to mappings, translated or converted.
+	CommentTypeDirective CommentType = "directive"
 )
 
 // Comment represents a single comment extracted from AST
@@ -29,7 +34,7 @@ type Comment struct {
 	// Language is the programming language identifier (e.g., "go", "rust")
 	Language string `json:"language"`
 
-	// Symbol is the semantic symbol path (e.g., "package.main.CalculateBalance")
+	// Symbol is the semantic symbol path (e.g., "billing.(*Ledger).CalculateBalance")
 	// For comments not bound to specific symbols, use "file.global" or similar
 	Symbol string `json:"symbol"`
 
@@ -74,4 +79,27 @@ type Mapping struct {
 	// Second Level Key: Language Code (e.g., "zh-CN")
 	// Value: Translated Text
 	Comments map[string]map[string]string `json:"comments"`
+
+	// Anchors records where each comment was last seen, keyed by Comment.ID.
+	// map update uses them to re-attach translations after renames and moves.
+	Anchors map[string]Anchor `json:"anchors,omitempty"`
+
+	// Archive keeps the translations of pruned comments, same layout as Comments.
+	// Archived entries are not translated or converted, but map update can revive them.
+	Archive map[string]map[string]string `json:"archive,omitempty"`
+}
+
+// Anchor is the location a mapped comment was last seen at
+type Anchor struct {
+	// File is the file path relative to the scanned directory
+	File string `json:"file"`
+
+	// Symbol is the semantic symbol path the comment was bound to
+	Symbol string `json:"symbol,omitempty"`
+
+	// Language is the language reported by the file's adapter; it selects the doc markup to protect
+	Language string `json:"language,omitempty"`
+
+	// OrphanedAt is when the comment was first found missing; zero while it exists
+	OrphanedAt time.Time `json:"orphanedAt,omitzero"`
 }
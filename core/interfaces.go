@@ -17,6 +17,14 @@ type LanguageAdapter interface {
 	Parse(file string, src []byte) ([]*domain.Comment, error)
 }
 
+// CommentFormatter is optionally implemented by adapters whose comment syntax
+// is not known to the convert command; it wraps translated text in comment markers
+type CommentFormatter interface {
+	// FormatComment returns text with the markers of the original comment c.
+	// ok is false if the adapter cannot format c and the built-in rules should apply.
+	FormatComment(c *domain.Comment, text string) (formatted string, ok bool)
+}
+
 // Translator defines the interface for translation services
 type Translator interface {
 	// Translate translates the text from source language to target language
@@ -25,3 +33,55 @@ type Translator interface {
 	// TranslateBatch translates a batch of texts (optional optimization)
 	TranslateBatch(ctx context.Context, texts []string, from, to string) ([]string, error)
 }
+
+// MappingStore defines the interface for comment mapping storage backends.
+// Implementations are safe for concurrent use.
+type MappingStore interface {
+	// Load opens the storage; Save persists the changes made since Load
+	Load() error
+	Save() error
+	// Close releases the files held open by the store
+	Close() error
+	// Path returns the file or directory the mapping is stored in
+	Path() string
+
+	// Get retrieves a translation for a given comment ID and language
+	Get(id, lang string) (string, bool)
+	// Set adds or updates a translation
+	Set(id, lang, text string)
+	// Delete removes a comment mapping, its anchor and any archived copy
+	Delete(id string)
+	// Iterate calls fn for every comment that is not archived, in no particular order,
+	// until fn returns false. fn must not call back into the store.
+	Iterate(fn func(id string, translations map[string]string) bool) error
+	// Transaction runs fn and saves the changes it made through the store together.
+	// If fn returns an error, those changes are discarded and nothing is saved.
+	Transaction(fn func() error) error
+
+	// SetAnchor records where the comment with the given ID was last seen
+	SetAnchor(id string, anchor domain.Anchor)
+	// GetAnchor returns where the comment with the given ID was last seen
+	GetAnchor(id string) (domain.Anchor, bool)
+	// Archive moves a comment mapping to the archive section, keeping its anchor
+	Archive(id string)
+	// Unarchive moves an archived comment mapping back to the comments section
+	Unarchive(id string)
+	// SetLanguages records the source and local language of the mapping
+	SetLanguages(source, target string)
+
+	// IDsByFile returns the IDs of the live and archived entries anchored to file; ""
+	// lists those without an anchor
+	IDsByFile(file string) ([]string, error)
+	// IDsBySymbol returns the IDs of the live and archived entries anchored to symbol
+	IDsBySymbol(symbol string) ([]string, error)
+	// IDsByLanguage returns the IDs of the live entries that have a text in lang
+	IDsByLanguage(lang string) ([]string, error)
+	// Files returns the files entries are anchored to, with "" if some have no anchor
+	Files() ([]string, error)
+
+	// GetMapping returns the whole mapping, reading all of it into memory if needed.
+	// It is meant for project-wide operations; modify entries through the store methods.
+	GetMapping() *domain.Mapping
+	// Replace swaps the whole mapping, e.g. to copy it into a store with another backend
+	Replace(m *domain.Mapping)
+}
@@ -0,0 +1,40 @@
+package mapping
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/studyzy/codei18n/core"
+)
+
+// Mapping storage backends, selected by the mappingStorage config
+const (
+	// BackendFile keeps every mapping in .codei18n/mappings.json (the default)
+	BackendFile = "file"
+	// BackendSharded keeps one mapping file per source file under .codei18n/mappings/
+	BackendSharded = "sharded"
+	// BackendBolt keeps the mapping in the embedded database .codei18n/mappings.db
+	BackendBolt = "bolt"
+)
+
+// Default locations of the mapping storage backends
+var (
+	DefaultFilePath   = filepath.Join(".codei18n", "mappings.json")
+	DefaultShardedDir = filepath.Join(".codei18n", "mappings")
+	DefaultBoltPath   = filepath.Join(".codei18n", "mappings.db")
+)
+
+// ForBackend returns an unloaded store for the named backend at its default location.
+// An empty name selects BackendFile.
+func ForBackend(name string) (core.MappingStore, error) {
+	switch name {
+	case "", BackendFile:
+		return NewStore(DefaultFilePath), nil
+	case BackendSharded:
+		return NewShardedStore(DefaultShardedDir), nil
+	case BackendBolt:
+		return NewBoltStore(DefaultBoltPath), nil
+	default:
+		return nil, fmt.Errorf("未知的映射存储后端: %s (可选: %s, %s, %s)", name, BackendFile, BackendSharded, BackendBolt)
+	}
+}
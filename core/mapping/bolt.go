@@ -0,0 +1,676 @@
+package mapping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/studyzy/codei18n/core"
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/internal/log"
+)
+
+// boltLockTimeout is how long opening the database waits for another process to release it
+const boltLockTimeout = 10 * time.Second
+
+// Buckets of the mapping database. Entries are JSON values keyed by comment ID; index keys
+// are "<value>\x00<comment ID>" with empty values, so that a prefix scan lists the IDs.
+var (
+	bucketMeta     = []byte("meta")
+	bucketComments = []byte("comments")
+	bucketArchive  = []byte("archive")
+	bucketAnchors  = []byte("anchors")
+	bucketByFile   = []byte("byFile")
+	bucketBySymbol = []byte("bySymbol")
+	bucketByLang   = []byte("byLanguage")
+
+	allBuckets = [][]byte{bucketMeta, bucketComments, bucketArchive, bucketAnchors, bucketByFile, bucketBySymbol, bucketByLang}
+)
+
+// Keys of the meta bucket
+var (
+	metaVersion        = []byte("version")
+	metaSourceLanguage = []byte("sourceLanguage")
+	metaTargetLanguage = []byte("targetLanguage")
+)
+
+// BoltStore keeps the mapping in an embedded bbolt database. Entries are read on demand and
+// cached; Save writes only the entries changed since the last save, in one crash-safe
+// transaction, and keeps indexes of comment IDs by file, symbol and language.
+//
+// The database is only open for the duration of each transaction, so other processes can
+// read and write it between them; a transaction waits up to boltLockTimeout for another
+// process to release the database.
+type BoltStore struct {
+	mu   sync.Mutex
+	path string
+
+	// mapping caches the entries read or changed since Load; it holds all of them once loaded is set
+	mapping *domain.Mapping
+	loaded  bool
+	fetched map[string]bool
+	dirty   map[string]bool
+	// base holds the dirty entries as they were before this store first changed them, so
+	// that saving applies only this store's changes to what other processes saved meanwhile
+	base map[string]entryState
+	// replaced is set by Replace: the next save rewrites the whole database
+	replaced bool
+
+	txMu    sync.Mutex
+	journal journal
+}
+
+var _ core.MappingStore = (*BoltStore)(nil)
+
+// NewBoltStore creates a mapping store backed by the bbolt database at path
+func NewBoltStore(path string) *BoltStore {
+	return &BoltStore{
+		path:    path,
+		mapping: emptyMapping(),
+		fetched: make(map[string]bool),
+		dirty:   make(map[string]bool),
+		base:    make(map[string]entryState),
+	}
+}
+
+func emptyMapping() *domain.Mapping {
+	return &domain.Mapping{
+		Version:        "1.0",
+		SourceLanguage: "en",
+		TargetLanguage: "zh-CN",
+		Comments:       make(map[string]map[string]string),
+		Anchors:        make(map[string]domain.Anchor),
+		Archive:        make(map[string]map[string]string),
+	}
+}
+
+// Path returns the database file
+func (s *BoltStore) Path() string {
+	return s.path
+}
+
+// Load reads the languages of the database; entries are read when first used
+func (s *BoltStore) Load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.view(func(tx *bolt.Tx) error {
+		meta := tx.Bucket(bucketMeta)
+		if meta == nil {
+			return nil
+		}
+		for key, field := range map[string]*string{
+			string(metaVersion):        &s.mapping.Version,
+			string(metaSourceLanguage): &s.mapping.SourceLanguage,
+			string(metaTargetLanguage): &s.mapping.TargetLanguage,
+		} {
+			if v := meta.Get([]byte(key)); v != nil {
+				*field = string(v)
+			}
+		}
+		return nil
+	})
+}
+
+// view opens the database read-only and runs fn in a read transaction; a database that does
+// not exist yet reads as empty
+func (s *BoltStore) view(fn func(tx *bolt.Tx) error) error {
+	if s.replaced {
+		return nil
+	}
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		return nil
+	}
+	db, err := bolt.Open(s.path, 0644, &bolt.Options{ReadOnly: true, Timeout: boltLockTimeout})
+	if err != nil {
+		return fmt.Errorf("打开映射数据库 %s 失败: %w", s.path, err)
+	}
+	defer db.Close()
+	return db.View(fn)
+}
+
+// Close is a no-op: the database is only open while a transaction runs
+func (s *BoltStore) Close() error {
+	return nil
+}
+
+// fetch reads the entry id into the cache unless it is there already
+func (s *BoltStore) fetch(id string) {
+	if s.loaded || s.fetched[id] {
+		return
+	}
+	s.fetched[id] = true
+	err := s.view(func(tx *bolt.Tx) error {
+		return readEntry(tx, id, s.mapping)
+	})
+	if err != nil {
+		// The interface has no error path for reads; treat the entry as missing
+		log.Warn("读取映射 %s 失败: %v", id, err)
+	}
+}
+
+// readEntry copies the stored entry id into m
+func readEntry(tx *bolt.Tx, id string, m *domain.Mapping) error {
+	key := []byte(id)
+	for _, section := range []struct {
+		bucket []byte
+		target *map[string]map[string]string
+	}{{bucketComments, &m.Comments}, {bucketArchive, &m.Archive}} {
+		if raw := getValue(tx, section.bucket, key); raw != nil {
+			var text map[string]string
+			if err := json.Unmarshal(raw, &text); err != nil {
+				return err
+			}
+			setText(section.target, id, text)
+		}
+	}
+	if raw := getValue(tx, bucketAnchors, key); raw != nil {
+		var anchor domain.Anchor
+		if err := json.Unmarshal(raw, &anchor); err != nil {
+			return err
+		}
+		m.Anchors[id] = anchor
+	}
+	return nil
+}
+
+func getValue(tx *bolt.Tx, bucket, key []byte) []byte {
+	if b := tx.Bucket(bucket); b != nil {
+		return b.Get(key)
+	}
+	return nil
+}
+
+// change prepares the entry id for modification
+func (s *BoltStore) change(id string) {
+	s.fetch(id)
+	s.journal.record(s.mapping, id)
+	if _, ok := s.base[id]; !ok {
+		s.base[id] = captureEntry(s.mapping, id)
+	}
+	s.dirty[id] = true
+}
+
+// Get retrieves a translation for a given comment ID and language
+func (s *BoltStore) Get(id, lang string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fetch(id)
+	text, ok := s.mapping.Comments[id][lang]
+	return text, ok
+}
+
+// Set adds or updates a translation
+func (s *BoltStore) Set(id, lang, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.change(id)
+	if _, ok := s.mapping.Comments[id]; !ok {
+		s.mapping.Comments[id] = make(map[string]string)
+	}
+	s.mapping.Comments[id][lang] = strings.TrimRight(text, "\r\n")
+}
+
+// Delete removes a comment mapping, its anchor and any archived copy
+func (s *BoltStore) Delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.change(id)
+	delete(s.mapping.Comments, id)
+	delete(s.mapping.Anchors, id)
+	delete(s.mapping.Archive, id)
+}
+
+// Archive moves a comment mapping to the archive section, keeping its anchor
+func (s *BoltStore) Archive(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.change(id)
+	if translations, ok := s.mapping.Comments[id]; ok {
+		s.mapping.Archive[id] = translations
+		delete(s.mapping.Comments, id)
+	}
+}
+
+// Unarchive moves an archived comment mapping back to the comments section
+func (s *BoltStore) Unarchive(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.change(id)
+	if translations, ok := s.mapping.Archive[id]; ok {
+		s.mapping.Comments[id] = translations
+		delete(s.mapping.Archive, id)
+	}
+}
+
+// SetAnchor records where the comment with the given ID was last seen
+func (s *BoltStore) SetAnchor(id string, anchor domain.Anchor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.change(id)
+	s.mapping.Anchors[id] = anchor
+}
+
+// GetAnchor returns where the comment with the given ID was last seen
+func (s *BoltStore) GetAnchor(id string) (domain.Anchor, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fetch(id)
+	anchor, ok := s.mapping.Anchors[id]
+	return anchor, ok
+}
+
+// SetLanguages records the source and local language of the mapping
+func (s *BoltStore) SetLanguages(source, target string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mapping.SourceLanguage = source
+	s.mapping.TargetLanguage = target
+}
+
+// Iterate calls fn for every comment that is not archived until fn returns false.
+// Cached entries are visited first, then the stored ones not read yet.
+func (s *BoltStore) Iterate(fn func(id string, translations map[string]string) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, translations := range s.mapping.Comments {
+		if !fn(id, translations) {
+			return nil
+		}
+	}
+	if s.loaded {
+		return nil
+	}
+
+	stop := errors.New("stop")
+	err := s.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketComments)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			if s.fetched[string(k)] {
+				return nil
+			}
+			var translations map[string]string
+			if err := json.Unmarshal(v, &translations); err != nil {
+				return fmt.Errorf("映射 %s: %w", k, err)
+			}
+			if !fn(string(k), translations) {
+				return stop
+			}
+			return nil
+		})
+	})
+	if errors.Is(err, stop) {
+		return nil
+	}
+	return err
+}
+
+// GetMapping reads every entry into memory and returns the live mapping
+func (s *BoltStore) GetMapping() *domain.Mapping {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.loaded {
+		err := s.view(func(tx *bolt.Tx) error {
+			for _, bucket := range [][]byte{bucketComments, bucketArchive, bucketAnchors} {
+				b := tx.Bucket(bucket)
+				if b == nil {
+					continue
+				}
+				err := b.ForEach(func(k, _ []byte) error {
+					if s.fetched[string(k)] {
+						return nil
+					}
+					s.fetched[string(k)] = true
+					return readEntry(tx, string(k), s.mapping)
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			log.Warn("读取映射数据库失败: %v", err)
+		}
+		s.loaded = true
+	}
+	return s.mapping
+}
+
+// Replace swaps the whole mapping; the next save rewrites the database
+func (s *BoltStore) Replace(m *domain.Mapping) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mapping = emptyMapping()
+	s.mapping.Version = m.Version
+	s.mapping.SourceLanguage = m.SourceLanguage
+	s.mapping.TargetLanguage = m.TargetLanguage
+	for id, text := range m.Comments {
+		s.mapping.Comments[id] = text
+	}
+	for id, text := range m.Archive {
+		s.mapping.Archive[id] = text
+	}
+	for id, anchor := range m.Anchors {
+		s.mapping.Anchors[id] = anchor
+	}
+	s.loaded = true
+	s.replaced = true
+}
+
+// Transaction runs fn and saves its changes in one database transaction once it succeeds;
+// if fn fails, the entries it changed are restored and nothing is written.
+func (s *BoltStore) Transaction(fn func() error) error {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.Lock()
+	s.journal = make(journal)
+	dirtyBefore := make(map[string]bool, len(s.dirty))
+	for id := range s.dirty {
+		dirtyBefore[id] = true
+	}
+	s.mu.Unlock()
+
+	err := fn()
+
+	s.mu.Lock()
+	if err != nil {
+		s.journal.rollback(s.mapping)
+		s.dirty = dirtyBefore
+	}
+	s.journal = nil
+	s.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	return s.Save()
+}
+
+// Save writes the entries changed since the last save. Each entry is re-read inside the
+// write transaction and only the translations and anchor changed through this store are
+// applied to it, so changes other processes saved to the same entry are kept; where both
+// changed the same translation, this store wins.
+func (s *BoltStore) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	db, err := bolt.Open(s.path, 0644, &bolt.Options{Timeout: boltLockTimeout})
+	if err != nil {
+		return fmt.Errorf("打开映射数据库 %s 失败: %w", s.path, err)
+	}
+	defer db.Close()
+
+	conflicts := 0
+	err = db.Update(func(tx *bolt.Tx) error {
+		conflicts = 0
+		ids := s.dirty
+		if s.replaced {
+			for _, bucket := range allBuckets {
+				if tx.Bucket(bucket) != nil {
+					if err := tx.DeleteBucket(bucket); err != nil {
+						return err
+					}
+				}
+			}
+			ids = s.cachedIDs()
+		}
+		for _, bucket := range allBuckets {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+
+		meta := tx.Bucket(bucketMeta)
+		for key, value := range map[string]string{
+			string(metaVersion):        s.mapping.Version,
+			string(metaSourceLanguage): s.mapping.SourceLanguage,
+			string(metaTargetLanguage): s.mapping.TargetLanguage,
+		} {
+			if err := meta.Put([]byte(key), []byte(value)); err != nil {
+				return err
+			}
+		}
+
+		for id := range ids {
+			n, err := s.writeEntry(tx, id)
+			if err != nil {
+				return err
+			}
+			conflicts += n
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("写入映射数据库失败: %w", err)
+	}
+	if conflicts > 0 {
+		log.Warn("映射数据库已被其他进程修改，合并时有 %d 处冲突，以本次修改为准", conflicts)
+	}
+
+	s.dirty = make(map[string]bool)
+	s.base = make(map[string]entryState)
+	s.replaced = false
+	return nil
+}
+
+// writeEntry applies the changes made to id through this store to the stored entry, or
+// writes the cached entry as is after Replace, and replaces its old index keys. The merged
+// entry is copied back into the cache. Returns the number of translations both changed.
+func (s *BoltStore) writeEntry(tx *bolt.Tx, id string) (int, error) {
+	key := []byte(id)
+
+	stored := emptyMapping()
+	if err := readEntry(tx, id, stored); err != nil {
+		return 0, err
+	}
+	// Drop the index keys of the stored version
+	for _, k := range indexKeys(stored, id) {
+		if err := tx.Bucket(k.bucket).Delete(k.key); err != nil {
+			return 0, err
+		}
+	}
+
+	ours := emptyMapping()
+	captureEntry(s.mapping, id).restore(ours, id)
+	merged, conflicts := ours, 0
+	if !s.replaced {
+		base := emptyMapping()
+		s.base[id].restore(base, id)
+		conflicts = mergeInto(stored, takeSnapshot(base), takeSnapshot(ours))
+		merged = stored
+		captureEntry(merged, id).restore(s.mapping, id)
+	}
+
+	for _, section := range []struct {
+		bucket []byte
+		text   map[string]string
+	}{{bucketComments, merged.Comments[id]}, {bucketArchive, merged.Archive[id]}} {
+		if err := putJSON(tx.Bucket(section.bucket), key, section.text, section.text != nil); err != nil {
+			return 0, err
+		}
+	}
+	anchor, hasAnchor := merged.Anchors[id]
+	if err := putJSON(tx.Bucket(bucketAnchors), key, anchor, hasAnchor); err != nil {
+		return 0, err
+	}
+
+	for _, k := range indexKeys(merged, id) {
+		if err := tx.Bucket(k.bucket).Put(k.key, nil); err != nil {
+			return 0, err
+		}
+	}
+	return conflicts, nil
+}
+
+// putJSON stores v under key, or deletes key if present is false
+func putJSON(b *bolt.Bucket, key []byte, v any, present bool) error {
+	if !present {
+		return b.Delete(key)
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.Put(key, raw)
+}
+
+// indexKey is one entry of an index bucket
+type indexKey struct {
+	bucket []byte
+	key    []byte
+}
+
+// indexKeys returns the index entries of id in m: its file and symbol (live or archived)
+// and the languages it has a text in (live only)
+func indexKeys(m *domain.Mapping, id string) []indexKey {
+	var keys []indexKey
+	_, live := m.Comments[id]
+	_, archived := m.Archive[id]
+	if live || archived {
+		// Entries without an anchor are listed under the empty file
+		anchor := m.Anchors[id]
+		keys = append(keys, indexKey{bucketByFile, joinIndexKey(anchor.File, id)})
+		if anchor.Symbol != "" {
+			keys = append(keys, indexKey{bucketBySymbol, joinIndexKey(anchor.Symbol, id)})
+		}
+	}
+	for lang := range m.Comments[id] {
+		keys = append(keys, indexKey{bucketByLang, joinIndexKey(lang, id)})
+	}
+	return keys
+}
+
+func joinIndexKey(value, id string) []byte {
+	return []byte(value + "\x00" + id)
+}
+
+// IDsByFile returns the IDs of the live and archived entries anchored to file; "" lists
+// those without an anchor
+func (s *BoltStore) IDsByFile(file string) ([]string, error) {
+	return s.lookup(bucketByFile, file)
+}
+
+// IDsBySymbol returns the IDs of the live and archived entries anchored to symbol
+func (s *BoltStore) IDsBySymbol(symbol string) ([]string, error) {
+	return s.lookup(bucketBySymbol, symbol)
+}
+
+// IDsByLanguage returns the IDs of the live entries that have a text in lang
+func (s *BoltStore) IDsByLanguage(lang string) ([]string, error) {
+	return s.lookup(bucketByLang, lang)
+}
+
+// Files returns the files entries are anchored to, with "" if some have no anchor
+func (s *BoltStore) Files() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files := make(map[string]bool)
+	err := s.scanIndex(bucketByFile, "", func(value, _ string) {
+		files[value] = true
+	})
+	return sortedKeys(files), err
+}
+
+// lookup lists the IDs indexed under value in bucket
+func (s *BoltStore) lookup(bucket []byte, value string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make(map[string]bool)
+	err := s.scanIndex(bucket, value+"\x00", func(_, id string) {
+		ids[id] = true
+	})
+	return sortedKeys(ids), err
+}
+
+// scanIndex calls fn with the value and comment ID of every key of the index bucket that
+// starts with prefix. Entries changed since the last save are indexed from the cache, so
+// the result includes unsaved changes. The caller holds s.mu.
+func (s *BoltStore) scanIndex(bucket []byte, prefix string, fn func(value, id string)) error {
+	pending := s.dirty
+	if s.replaced {
+		pending = s.cachedIDs()
+	}
+	emit := func(key []byte) {
+		value, id, _ := strings.Cut(string(key), "\x00")
+		fn(value, id)
+	}
+
+	err := s.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for k, _ := c.Seek([]byte(prefix)); k != nil && bytes.HasPrefix(k, []byte(prefix)); k, _ = c.Next() {
+			if _, id, _ := strings.Cut(string(k), "\x00"); !pending[id] {
+				emit(k)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for id := range pending {
+		for _, k := range indexKeys(s.mapping, id) {
+			if bytes.Equal(k.bucket, bucket) && bytes.HasPrefix(k.key, []byte(prefix)) {
+				emit(k.key)
+			}
+		}
+	}
+	return nil
+}
+
+// cachedIDs returns the IDs of every cached entry
+func (s *BoltStore) cachedIDs() map[string]bool {
+	ids := make(map[string]bool)
+	for _, section := range []map[string]map[string]string{s.mapping.Comments, s.mapping.Archive} {
+		for id := range section {
+			ids[id] = true
+		}
+	}
+	for id := range s.mapping.Anchors {
+		ids[id] = true
+	}
+	return ids
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,274 @@
+package mapping
+
+import (
+	"errors"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func newTestBoltStore(t *testing.T) *BoltStore {
+	t.Helper()
+	store := NewBoltStore(filepath.Join(t.TempDir(), "mappings.db"))
+	require.NoError(t, store.Load())
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func reopen(t *testing.T, store *BoltStore) *BoltStore {
+	t.Helper()
+	require.NoError(t, store.Close())
+	reopened := NewBoltStore(store.Path())
+	require.NoError(t, reopened.Load())
+	t.Cleanup(func() { reopened.Close() })
+	return reopened
+}
+
+func TestBoltStore_RoundTrip(t *testing.T) {
+	store := newTestBoltStore(t)
+	store.SetLanguages("en", "ja")
+	store.Set("a", "en", "// Adds numbers\n")
+	store.Set("a", "ja", "// 数を足す")
+	store.SetAnchor("a", domain.Anchor{File: "math.go", Symbol: "math.Add"})
+	store.Set("b", "en", "// Removed later")
+	store.SetAnchor("b", domain.Anchor{File: "math.go", Symbol: "math.Sub"})
+	store.Archive("b")
+	require.NoError(t, store.Save())
+
+	loaded := reopen(t, store)
+	text, ok := loaded.Get("a", "en")
+	assert.True(t, ok)
+	assert.Equal(t, "// Adds numbers", text)
+	_, ok = loaded.Get("b", "en")
+	assert.False(t, ok, "archived entries are not live")
+	anchor, ok := loaded.GetAnchor("b")
+	assert.True(t, ok)
+	assert.Equal(t, "math.Sub", anchor.Symbol)
+
+	m := loaded.GetMapping()
+	assert.Equal(t, "ja", m.TargetLanguage)
+	assert.Equal(t, map[string]map[string]string{"a": {"en": "// Adds numbers", "ja": "// 数を足す"}}, m.Comments)
+	assert.Equal(t, map[string]map[string]string{"b": {"en": "// Removed later"}}, m.Archive)
+}
+
+func TestBoltStore_IncrementalSaveKeepsUntouchedEntries(t *testing.T) {
+	store := newTestBoltStore(t)
+	for _, id := range []string{"a", "b", "c"} {
+		store.Set(id, "en", "// "+id)
+	}
+	require.NoError(t, store.Save())
+
+	// A fresh store only reads and writes what it touches
+	loaded := reopen(t, store)
+	loaded.Set("b", "zh-CN", "// 乙")
+	loaded.Delete("c")
+	assert.Len(t, loaded.dirty, 2)
+	require.NoError(t, loaded.Save())
+	assert.Empty(t, loaded.dirty)
+
+	final := reopen(t, loaded)
+	var ids []string
+	require.NoError(t, final.Iterate(func(id string, translations map[string]string) bool {
+		ids = append(ids, id)
+		return true
+	}))
+	sort.Strings(ids)
+	assert.Equal(t, []string{"a", "b"}, ids)
+	text, _ := final.Get("b", "zh-CN")
+	assert.Equal(t, "// 乙", text)
+}
+
+func TestBoltStore_ReaderDoesNotBlockWriters(t *testing.T) {
+	store := newTestBoltStore(t)
+	store.Set("a", "en", "// a")
+	require.NoError(t, store.Save())
+
+	// A loaded store that has read entries must not keep the database locked
+	reader := NewBoltStore(store.Path())
+	require.NoError(t, reader.Load())
+	_, ok := reader.Get("a", "en")
+	require.True(t, ok)
+
+	writer := NewBoltStore(store.Path())
+	require.NoError(t, writer.Load())
+	writer.Set("b", "en", "// b")
+	start := time.Now()
+	require.NoError(t, writer.Save())
+	assert.Less(t, time.Since(start), boltLockTimeout/2)
+
+	_, ok = reader.Get("b", "en")
+	assert.True(t, ok, "entries saved by others are read on demand")
+}
+
+func TestBoltStore_SaveMergesConcurrentChanges(t *testing.T) {
+	store := newTestBoltStore(t)
+	store.Set("a", "en", "// a")
+	store.Set("a", "zh-CN", "// 甲")
+	store.SetAnchor("a", domain.Anchor{File: "a.go"})
+	require.NoError(t, store.Save())
+
+	// Both read the entry before either saves
+	first, second := reopen(t, store), reopen(t, store)
+	first.Get("a", "en")
+	second.Get("a", "en")
+
+	first.Set("a", "ja", "// イ")
+	first.Set("a", "zh-CN", "// 甲一")
+	require.NoError(t, first.Save())
+
+	second.Set("a", "fr", "// a fr")
+	second.Set("a", "zh-CN", "// 甲二")
+	second.SetAnchor("a", domain.Anchor{File: "b.go"})
+	require.NoError(t, second.Save())
+
+	final := reopen(t, second)
+	assert.Equal(t, map[string]map[string]string{
+		"a": {"en": "// a", "ja": "// イ", "fr": "// a fr", "zh-CN": "// 甲二"},
+	}, final.GetMapping().Comments)
+	assert.Equal(t, "b.go", final.GetMapping().Anchors["a"].File)
+
+	text, _ := second.Get("a", "ja")
+	assert.Equal(t, "// イ", text, "the cache holds the merged entry after saving")
+}
+
+func TestBoltStore_IterateOverlaysUnsavedChanges(t *testing.T) {
+	store := newTestBoltStore(t)
+	store.Set("a", "en", "// a")
+	store.Set("b", "en", "// b")
+	require.NoError(t, store.Save())
+
+	loaded := reopen(t, store)
+	loaded.Set("a", "zh-CN", "// 甲")
+	loaded.Delete("b")
+	loaded.Set("c", "en", "// c")
+
+	seen := make(map[string]map[string]string)
+	require.NoError(t, loaded.Iterate(func(id string, translations map[string]string) bool {
+		seen[id] = translations
+		return true
+	}))
+	assert.Equal(t, map[string]map[string]string{
+		"a": {"en": "// a", "zh-CN": "// 甲"},
+		"c": {"en": "// c"},
+	}, seen)
+
+	count := 0
+	require.NoError(t, loaded.Iterate(func(string, map[string]string) bool {
+		count++
+		return false
+	}))
+	assert.Equal(t, 1, count, "iteration stops when fn returns false")
+}
+
+func TestBoltStore_Indexes(t *testing.T) {
+	store := newTestBoltStore(t)
+	store.Set("a", "en", "// a")
+	store.Set("a", "zh-CN", "// 甲")
+	store.SetAnchor("a", domain.Anchor{File: "x.go", Symbol: "pkg.A"})
+	store.Set("b", "en", "// b")
+	store.SetAnchor("b", domain.Anchor{File: "x.go", Symbol: "pkg.B"})
+	require.NoError(t, store.Save())
+
+	ids, err := store.IDsByFile("x.go")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, ids)
+	ids, err = store.IDsByLanguage("zh-CN")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, ids)
+
+	// Moving a comment updates the index; archived entries leave the language index
+	store.SetAnchor("a", domain.Anchor{File: "y.go", Symbol: "pkg.A"})
+	store.Archive("a")
+	require.NoError(t, store.Save())
+
+	ids, err = store.IDsByFile("x.go")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, ids)
+	ids, err = store.IDsByFile("y.go")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, ids)
+	ids, err = store.IDsBySymbol("pkg.A")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, ids)
+	ids, err = store.IDsByLanguage("zh-CN")
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestBoltStore_Transaction(t *testing.T) {
+	store := newTestBoltStore(t)
+	store.Set("a", "en", "// a")
+	require.NoError(t, store.Save())
+
+	err := store.Transaction(func() error {
+		store.Set("a", "zh-CN", "// 甲")
+		store.Set("b", "en", "// b")
+		return errors.New("translator failed")
+	})
+	assert.EqualError(t, err, "translator failed")
+	_, ok := store.Get("a", "zh-CN")
+	assert.False(t, ok, "failed transactions are rolled back")
+	_, ok = store.Get("b", "en")
+	assert.False(t, ok)
+
+	require.NoError(t, store.Transaction(func() error {
+		store.Set("a", "zh-CN", "// 甲")
+		return nil
+	}))
+	loaded := reopen(t, store)
+	text, ok := loaded.Get("a", "zh-CN")
+	assert.True(t, ok, "successful transactions are saved")
+	assert.Equal(t, "// 甲", text)
+}
+
+func TestBoltStore_Replace(t *testing.T) {
+	store := newTestBoltStore(t)
+	store.Set("old", "en", "// old")
+	require.NoError(t, store.Save())
+
+	store.Replace(&domain.Mapping{
+		Version:        "1.0",
+		SourceLanguage: "en",
+		TargetLanguage: "zh-CN",
+		Comments:       map[string]map[string]string{"new": {"en": "// new"}},
+		Anchors:        map[string]domain.Anchor{"new": {File: "n.go"}},
+	})
+	require.NoError(t, store.Save())
+
+	loaded := reopen(t, store)
+	assert.Equal(t, map[string]map[string]string{"new": {"en": "// new"}}, loaded.GetMapping().Comments)
+	ids, err := loaded.IDsByFile("n.go")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new"}, ids)
+}
+
+func TestStore_Transaction(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "mappings.json"))
+	store.Set("a", "en", "// a")
+	store.SetAnchor("a", domain.Anchor{File: "a.go"})
+
+	err := store.Transaction(func() error {
+		store.Set("a", "zh-CN", "// 甲")
+		store.Delete("a")
+		store.Set("b", "en", "// b")
+		return errors.New("failed")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, map[string]map[string]string{"a": {"en": "// a"}}, store.GetMapping().Comments)
+	anchor, ok := store.GetAnchor("a")
+	assert.True(t, ok)
+	assert.Equal(t, "a.go", anchor.File)
+	assert.NoFileExists(t, store.Path(), "nothing is saved")
+
+	require.NoError(t, store.Transaction(func() error {
+		store.Set("a", "zh-CN", "// 甲")
+		return nil
+	}))
+	assert.FileExists(t, store.Path())
+}
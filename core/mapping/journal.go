@@ -0,0 +1,78 @@
+package mapping
+
+import (
+	"maps"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+// entryState is a copy of everything a mapping holds for one comment ID
+type entryState struct {
+	comment, archived map[string]string
+	anchor            domain.Anchor
+	hasAnchor         bool
+}
+
+// captureEntry copies the state of id in m
+func captureEntry(m *domain.Mapping, id string) entryState {
+	anchor, hasAnchor := m.Anchors[id]
+	return entryState{
+		comment:   cloneText(m.Comments[id]),
+		archived:  cloneText(m.Archive[id]),
+		anchor:    anchor,
+		hasAnchor: hasAnchor,
+	}
+}
+
+// restore puts the captured state of id back into m
+func (e entryState) restore(m *domain.Mapping, id string) {
+	setText(&m.Comments, id, e.comment)
+	setText(&m.Archive, id, e.archived)
+	if e.hasAnchor {
+		if m.Anchors == nil {
+			m.Anchors = make(map[string]domain.Anchor)
+		}
+		m.Anchors[id] = e.anchor
+	} else {
+		delete(m.Anchors, id)
+	}
+}
+
+// journal records the state entries had before the running transaction first changed them
+type journal map[string]entryState
+
+// record captures id unless it was already captured in this transaction
+func (j journal) record(m *domain.Mapping, id string) {
+	if j == nil {
+		return
+	}
+	if _, ok := j[id]; !ok {
+		j[id] = captureEntry(m, id)
+	}
+}
+
+// rollback restores every recorded entry
+func (j journal) rollback(m *domain.Mapping) {
+	for id, state := range j {
+		state.restore(m, id)
+	}
+}
+
+func cloneText(text map[string]string) map[string]string {
+	if text == nil {
+		return nil
+	}
+	return maps.Clone(text)
+}
+
+// setText stores text under id in *section, or removes id if text is nil
+func setText(section *map[string]map[string]string, id string, text map[string]string) {
+	if text == nil {
+		delete(*section, id)
+		return
+	}
+	if *section == nil {
+		*section = make(map[string]map[string]string)
+	}
+	(*section)[id] = text
+}
@@ -0,0 +1,91 @@
+package mapping
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// lockTimeout is how long Save waits for another process to finish writing the mapping
+const lockTimeout = 10 * time.Second
+
+// lockRetryInterval is how often a held lock is polled
+const lockRetryInterval = 50 * time.Millisecond
+
+// fileLock is an exclusive advisory lock on a file next to the mapping. Every codei18n
+// process takes it before writing, so saves from the IDE plugin and Git hooks never interleave.
+type fileLock struct {
+	file *os.File
+}
+
+// acquireLock locks path, creating it if needed, and gives up after timeout
+func acquireLock(path string, timeout time.Duration) (*fileLock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		locked, err := tryLock(file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		if locked {
+			return &fileLock{file: file}, nil
+		}
+		if time.Now().After(deadline) {
+			file.Close()
+			return nil, fmt.Errorf("等待映射文件锁超时 (%s)，可能有其他 codei18n 进程正在写入", path)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// release unlocks and closes the lock file. The file itself is left in place: removing it
+// would let a process still waiting on the old file race with one creating a new file.
+func (l *fileLock) release() error {
+	if err := unlock(l.file); err != nil {
+		l.file.Close()
+		return err
+	}
+	return l.file.Close()
+}
+
+// writeFileAtomic replaces p with data through a temporary file in the same directory,
+// so readers and crashes only ever see the old or the new content
+func writeFileAtomic(p string, data []byte) (err error) {
+	dir := filepath.Dir(p)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(p)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), p)
+}
@@ -0,0 +1,131 @@
+package mapping
+
+import (
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+// snapshot is a flat copy of a mapping: one value per translation and one per anchor,
+// so that concurrent changes to different languages of the same comment merge cleanly
+type snapshot struct {
+	text    map[textKey]string
+	anchors map[string]domain.Anchor
+}
+
+type textKey struct {
+	archived bool
+	id, lang string
+}
+
+func takeSnapshot(m *domain.Mapping) *snapshot {
+	s := &snapshot{
+		text:    make(map[textKey]string),
+		anchors: make(map[string]domain.Anchor, len(m.Anchors)),
+	}
+	for id, translations := range m.Comments {
+		for lang, text := range translations {
+			s.text[textKey{id: id, lang: lang}] = text
+		}
+	}
+	for id, translations := range m.Archive {
+		for lang, text := range translations {
+			s.text[textKey{archived: true, id: id, lang: lang}] = text
+		}
+	}
+	for id, anchor := range m.Anchors {
+		s.anchors[id] = anchor
+	}
+	return s
+}
+
+// equal reports whether s and o hold the same translations and anchors
+func (s *snapshot) equal(o *snapshot) bool {
+	if len(s.text) != len(o.text) || len(s.anchors) != len(o.anchors) {
+		return false
+	}
+	for k, text := range s.text {
+		if other, ok := o.text[k]; !ok || other != text {
+			return false
+		}
+	}
+	for id, anchor := range s.anchors {
+		if other, ok := o.anchors[id]; !ok || !sameAnchor(anchor, other) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameAnchor(a, b domain.Anchor) bool {
+	return a.File == b.File && a.Symbol == b.Symbol && a.Language == b.Language && a.OrphanedAt.Equal(b.OrphanedAt)
+}
+
+// mergeInto applies the changes made from base to ours onto theirs, the mapping another
+// process saved meanwhile. Values changed on both sides take ours. Returns the number of
+// such conflicts.
+func mergeInto(theirs *domain.Mapping, base, ours *snapshot) int {
+	current := takeSnapshot(theirs)
+	conflicts := 0
+
+	for k := range unionKeys(base.text, ours.text) {
+		was, inBase := base.text[k]
+		now, inOurs := ours.text[k]
+		if inBase == inOurs && was == now {
+			continue
+		}
+		if cur, inTheirs := current.text[k]; inTheirs != inBase || cur != was {
+			if inTheirs != inOurs || cur != now {
+				conflicts++
+			}
+		}
+		section := &theirs.Comments
+		if k.archived {
+			section = &theirs.Archive
+		}
+		if inOurs {
+			translations := (*section)[k.id]
+			if translations == nil {
+				translations = make(map[string]string)
+			}
+			translations[k.lang] = now
+			setText(section, k.id, translations)
+		} else if translations, ok := (*section)[k.id]; ok {
+			delete(translations, k.lang)
+			if len(translations) == 0 {
+				delete(*section, k.id)
+			}
+		}
+	}
+
+	for id := range unionKeys(base.anchors, ours.anchors) {
+		was, inBase := base.anchors[id]
+		now, inOurs := ours.anchors[id]
+		if inBase == inOurs && sameAnchor(was, now) {
+			continue
+		}
+		if cur, inTheirs := current.anchors[id]; inTheirs != inBase || !sameAnchor(cur, was) {
+			if inTheirs != inOurs || !sameAnchor(cur, now) {
+				conflicts++
+			}
+		}
+		if inOurs {
+			if theirs.Anchors == nil {
+				theirs.Anchors = make(map[string]domain.Anchor)
+			}
+			theirs.Anchors[id] = now
+		} else {
+			delete(theirs.Anchors, id)
+		}
+	}
+	return conflicts
+}
+
+func unionKeys[K comparable, V any](a, b map[K]V) map[K]struct{} {
+	keys := make(map[K]struct{}, len(a)+len(b))
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+	return keys
+}
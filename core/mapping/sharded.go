@@ -0,0 +1,240 @@
+package mapping
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+// Files of the sharded layout that do not belong to a source file
+const (
+	shardMetaFile       = "_meta.json"
+	shardUnanchoredFile = "_unanchored.json"
+	shardExt            = ".json"
+)
+
+// shardedBackend keeps one JSON file per source file, <dir>/<source path>.json, so that
+// changes to a source file only touch its own mapping file. Entries are placed by their
+// anchor; entries without one go to _unanchored.json. The version and languages are
+// stored in _meta.json.
+type shardedBackend struct {
+	dir string
+}
+
+// shardMeta is the content of _meta.json
+type shardMeta struct {
+	Version        string `json:"version"`
+	SourceLanguage string `json:"sourceLanguage"`
+	TargetLanguage string `json:"targetLanguage"`
+}
+
+// shard is the content of one per-file mapping
+type shard struct {
+	// File is the source file the entries belong to, empty for _unanchored.json
+	File     string       `json:"file,omitempty"`
+	Comments []shardEntry `json:"comments"`
+	Archive  []shardEntry `json:"archive,omitempty"`
+}
+
+// shardEntry is one comment with its source text and translations side by side
+type shardEntry struct {
+	ID         string            `json:"id"`
+	Symbol     string            `json:"symbol,omitempty"`
+	Language   string            `json:"language,omitempty"`
+	OrphanedAt time.Time         `json:"orphanedAt,omitzero"`
+	Text       map[string]string `json:"text"`
+}
+
+func (b *shardedBackend) location() string {
+	return b.dir
+}
+
+func (b *shardedBackend) load(m *domain.Mapping) error {
+	raw, err := os.ReadFile(filepath.Join(b.dir, shardMetaFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var meta shardMeta
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return fmt.Errorf("%s: %w", shardMetaFile, err)
+	}
+	m.Version = meta.Version
+	m.SourceLanguage = meta.SourceLanguage
+	m.TargetLanguage = meta.TargetLanguage
+
+	return filepath.WalkDir(b.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, shardExt) || p == filepath.Join(b.dir, shardMetaFile) {
+			return nil
+		}
+
+		raw, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		var sh shard
+		if err := json.Unmarshal(raw, &sh); err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+		for _, section := range []struct {
+			entries []shardEntry
+			target  *map[string]map[string]string
+		}{{sh.Comments, &m.Comments}, {sh.Archive, &m.Archive}} {
+			for _, e := range section.entries {
+				if *section.target == nil {
+					*section.target = make(map[string]map[string]string)
+				}
+				(*section.target)[e.ID] = e.Text
+				if sh.File != "" {
+					if m.Anchors == nil {
+						m.Anchors = make(map[string]domain.Anchor)
+					}
+					m.Anchors[e.ID] = domain.Anchor{File: sh.File, Symbol: e.Symbol, Language: e.Language, OrphanedAt: e.OrphanedAt}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (b *shardedBackend) save(m *domain.Mapping) error {
+	shards := make(map[string]*shard)
+	add := func(id string, text map[string]string, archived bool) {
+		anchor := m.Anchors[id]
+		name := shardUnanchoredFile
+		if shardable(anchor.File) {
+			name = filepath.FromSlash(anchor.File) + shardExt
+		} else {
+			anchor = domain.Anchor{}
+		}
+
+		sh, ok := shards[name]
+		if !ok {
+			sh = &shard{File: anchor.File, Comments: []shardEntry{}}
+			shards[name] = sh
+		}
+		e := shardEntry{ID: id, Symbol: anchor.Symbol, Language: anchor.Language, OrphanedAt: anchor.OrphanedAt, Text: text}
+		if archived {
+			sh.Archive = append(sh.Archive, e)
+		} else {
+			sh.Comments = append(sh.Comments, e)
+		}
+	}
+	for id, text := range m.Comments {
+		add(id, text, false)
+	}
+	for id, text := range m.Archive {
+		add(id, text, true)
+	}
+
+	written := map[string]bool{shardMetaFile: true}
+	if err := writeJSONIfChanged(filepath.Join(b.dir, shardMetaFile), shardMeta{
+		Version:        m.Version,
+		SourceLanguage: m.SourceLanguage,
+		TargetLanguage: m.TargetLanguage,
+	}); err != nil {
+		return err
+	}
+	for name, sh := range shards {
+		sortEntries(sh.Comments)
+		sortEntries(sh.Archive)
+		if err := writeJSONIfChanged(filepath.Join(b.dir, name), sh); err != nil {
+			return err
+		}
+		written[name] = true
+	}
+
+	return b.removeStaleShards(written)
+}
+
+// removeStaleShards deletes the shards of source files that no longer have entries,
+// and the directories left empty
+func (b *shardedBackend) removeStaleShards(written map[string]bool) error {
+	var stale, dirs []string
+	err := filepath.WalkDir(b.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(b.dir, p)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if rel != "." {
+				dirs = append(dirs, p)
+			}
+			return nil
+		}
+		if strings.HasSuffix(p, shardExt) && !written[rel] {
+			stale = append(stale, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, p := range stale {
+		if err := os.Remove(p); err != nil {
+			return err
+		}
+	}
+	// Deepest first, so that parents are empty by the time they are reached
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if entries, err := os.ReadDir(dirs[i]); err == nil && len(entries) == 0 {
+			_ = os.Remove(dirs[i])
+		}
+	}
+	return nil
+}
+
+// shardable reports whether file can name a shard inside the mapping directory
+func shardable(file string) bool {
+	if file == "" || path.IsAbs(file) || filepath.IsAbs(file) {
+		return false
+	}
+	clean := path.Clean(file)
+	return clean != "." && clean != ".." && !strings.HasPrefix(clean, "../") &&
+		clean != strings.TrimSuffix(shardMetaFile, shardExt) &&
+		clean != strings.TrimSuffix(shardUnanchoredFile, shardExt)
+}
+
+// sortEntries orders entries by symbol, then ID, so that shards are stable across saves
+func sortEntries(entries []shardEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Symbol != entries[j].Symbol {
+			return entries[i].Symbol < entries[j].Symbol
+		}
+		return entries[i].ID < entries[j].ID
+	})
+}
+
+// writeJSONIfChanged writes v as indented JSON, leaving the file untouched when its
+// content is already the same
+func writeJSONIfChanged(p string, v any) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+
+	if existing, err := os.ReadFile(p); err == nil && bytes.Equal(existing, buf.Bytes()) {
+		return nil
+	}
+	return writeFileAtomic(p, buf.Bytes())
+}
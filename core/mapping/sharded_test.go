@@ -0,0 +1,87 @@
+package mapping
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestShardedStore_RoundTrip(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mappings")
+	orphaned := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	store := NewShardedStore(dir)
+	store.Set("b1", "en", "// Balance sums the ledger")
+	store.Set("b1", "zh-CN", "// Balance 汇总账本")
+	store.SetAnchor("b1", domain.Anchor{File: "billing/ledger.go", Symbol: "billing.Balance", Language: "go"})
+	store.Set("a1", "en", "// Reset clears the ledger")
+	store.SetAnchor("a1", domain.Anchor{File: "billing/ledger.go", Symbol: "billing.Reset", OrphanedAt: orphaned})
+	store.Archive("a1")
+	store.Set("u1", "en", "// written before anchors existed")
+	require.NoError(t, store.Save())
+
+	assert.FileExists(t, filepath.Join(dir, "_meta.json"))
+	assert.FileExists(t, filepath.Join(dir, "billing", "ledger.go.json"))
+	assert.FileExists(t, filepath.Join(dir, "_unanchored.json"))
+
+	loaded := NewShardedStore(dir)
+	require.NoError(t, loaded.Load())
+	m := loaded.GetMapping()
+	assert.Equal(t, "1.0", m.Version)
+	assert.Equal(t, map[string]string{"en": "// Balance sums the ledger", "zh-CN": "// Balance 汇总账本"}, m.Comments["b1"])
+	assert.Equal(t, map[string]string{"en": "// written before anchors existed"}, m.Comments["u1"])
+	assert.Equal(t, map[string]string{"en": "// Reset clears the ledger"}, m.Archive["a1"])
+	assert.Equal(t, domain.Anchor{File: "billing/ledger.go", Symbol: "billing.Balance", Language: "go"}, m.Anchors["b1"])
+	assert.Equal(t, domain.Anchor{File: "billing/ledger.go", Symbol: "billing.Reset", OrphanedAt: orphaned}, m.Anchors["a1"])
+	assert.NotContains(t, m.Anchors, "u1")
+}
+
+func TestShardedStore_StableAndRemovesStaleShards(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mappings")
+
+	store := NewShardedStore(dir)
+	for _, id := range []string{"c", "a", "b"} {
+		store.Set(id, "en", "// comment "+id)
+		store.SetAnchor(id, domain.Anchor{File: "pkg/" + id + ".go", Symbol: "pkg." + id})
+	}
+	store.Set("d", "en", "// comment d")
+	store.SetAnchor("d", domain.Anchor{File: "pkg/a.go", Symbol: "pkg.a"})
+	require.NoError(t, store.Save())
+
+	shard := filepath.Join(dir, "pkg", "a.go.json")
+	first, err := os.ReadFile(shard)
+	require.NoError(t, err)
+	assert.Regexp(t, `(?s)"id": "a".*"id": "d"`, string(first), "entries are ordered by symbol, then ID")
+
+	// Saving again without changes rewrites nothing
+	info, err := os.Stat(shard)
+	require.NoError(t, err)
+	past := info.ModTime().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(shard, past, past))
+	require.NoError(t, store.Save())
+	info, err = os.Stat(shard)
+	require.NoError(t, err)
+	assert.Equal(t, past.Unix(), info.ModTime().Unix())
+
+	// Shards of files without entries are removed
+	store.Delete("b")
+	store.Delete("c")
+	require.NoError(t, store.Save())
+	assert.NoFileExists(t, filepath.Join(dir, "pkg", "b.go.json"))
+	assert.NoFileExists(t, filepath.Join(dir, "pkg", "c.go.json"))
+	assert.FileExists(t, shard)
+}
+
+func TestShardable(t *testing.T) {
+	assert.True(t, shardable("main.go"))
+	assert.True(t, shardable("src/app/index.ts"))
+	for _, file := range []string{"", ".", "..", "../outside.go", "/abs/path.go", "_meta", "_unanchored"} {
+		assert.False(t, shardable(file), file)
+	}
+}
@@ -1,82 +1,235 @@
 package mapping
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
-	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/studyzy/codei18n/core"
 	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/internal/log"
 )
 
-// Store manages the persistence and concurrent access of mappings
+// Store manages the persistence and concurrent access of mappings kept in JSON files.
+// The whole mapping is held in memory and rewritten by Save. Saves from several processes
+// are serialized by a lock file, and changes another process saved since Load are merged
+// in rather than overwritten.
 type Store struct {
 	mu      sync.RWMutex
 	mapping *domain.Mapping
-	path    string
+	backend backend
+
+	// base is the mapping as last loaded or saved, nil if Save should overwrite the disk
+	base        *snapshot
+	lockTimeout time.Duration
+
+	// index lists the entry IDs by file, symbol and language; it is built on first use
+	// and dropped by every change. indexMu guards building it under the read lock.
+	indexMu sync.Mutex
+	index   map[string]map[string][]string
+
+	// txMu serializes transactions; journal is non-nil while one runs
+	txMu    sync.Mutex
+	journal journal
+}
+
+var _ core.MappingStore = (*Store)(nil)
+
+// backend reads and writes a whole mapping
+type backend interface {
+	load(m *domain.Mapping) error
+	save(m *domain.Mapping) error
+	// location is the file or directory the mapping is kept in
+	location() string
 }
 
-// NewStore creates a new mapping store
+// NewStore creates a new mapping store backed by a single JSON file
 func NewStore(path string) *Store {
+	return newStore(&fileBackend{path: path})
+}
+
+// NewShardedStore creates a mapping store that keeps one JSON file per source file under dir
+func NewShardedStore(dir string) *Store {
+	return newStore(&shardedBackend{dir: dir})
+}
+
+func newStore(b backend) *Store {
 	return &Store{
-		path: path,
-		mapping: &domain.Mapping{
-			Version:        "1.0",
-			SourceLanguage: "en",
-			TargetLanguage: "zh-CN", // Default, should be updated from config
-			Comments:       make(map[string]map[string]string),
-		},
+		backend:     b,
+		mapping:     newMapping(),
+		lockTimeout: lockTimeout,
 	}
 }
 
-// Load reads the mapping file from disk
+func newMapping() *domain.Mapping {
+	return &domain.Mapping{
+		Version:        "1.0",
+		SourceLanguage: "en",
+		TargetLanguage: "zh-CN", // Default, should be updated from config
+		Comments:       make(map[string]map[string]string),
+	}
+}
+
+// Path returns the file or directory the mapping is stored in
+func (s *Store) Path() string {
+	return s.backend.location()
+}
+
+// Load reads the mapping from disk
 func (s *Store) Load() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.index = nil
 
-	file, err := os.Open(s.path)
+	if err := s.backend.load(s.mapping); err != nil {
+		return err
+	}
+
+	// Ensure map is initialized if file had null
+	if s.mapping.Comments == nil {
+		s.mapping.Comments = make(map[string]map[string]string)
+	}
+	s.base = takeSnapshot(s.mapping)
+
+	return nil
+}
+
+// Save writes the mapping to disk while holding the lock file. If another process saved
+// the mapping after Load, its version is reloaded and the changes made through this store
+// are applied on top of it; where both changed the same translation, this store wins.
+func (s *Store) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.index = nil
+
+	lock, err := acquireLock(s.backend.location()+".lock", s.lockTimeout)
 	if err != nil {
-		if os.IsNotExist(err) {
-			// If file doesn't exist, we start with empty mapping (initialized in NewStore)
-			return nil
+		return err
+	}
+	defer lock.release()
+
+	if s.base != nil {
+		if err := s.mergeFromDisk(); err != nil {
+			return err
 		}
+	}
+	if err := s.backend.save(s.mapping); err != nil {
 		return err
 	}
-	defer file.Close()
+	s.base = takeSnapshot(s.mapping)
+	return nil
+}
 
-	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(s.mapping); err != nil {
+// mergeFromDisk reconciles the in-memory mapping with the one on disk if it changed since
+// it was loaded or saved. The caller holds s.mu and the lock file.
+func (s *Store) mergeFromDisk() error {
+	disk := newMapping()
+	if err := s.backend.load(disk); err != nil {
 		return err
 	}
+	if disk.Comments == nil {
+		disk.Comments = make(map[string]map[string]string)
+	}
+	if takeSnapshot(disk).equal(s.base) {
+		return nil
+	}
 
-	// Ensure map is initialized if file had null
-	if s.mapping.Comments == nil {
-		s.mapping.Comments = make(map[string]map[string]string)
+	conflicts := mergeInto(disk, s.base, takeSnapshot(s.mapping))
+	if conflicts > 0 {
+		log.Warn("映射文件已被其他进程修改，合并时有 %d 处冲突，以本次修改为准", conflicts)
+	} else {
+		log.Debug("映射文件已被其他进程修改，已合并双方的变更")
 	}
+	disk.Version = s.mapping.Version
+	disk.SourceLanguage = s.mapping.SourceLanguage
+	disk.TargetLanguage = s.mapping.TargetLanguage
+	// Keep the pointer handed out by GetMapping valid
+	*s.mapping = *disk
+	return nil
+}
 
+// Close is a no-op: JSON files are only open while they are read or written
+func (s *Store) Close() error {
 	return nil
 }
 
-// Save writes the mapping to disk
-func (s *Store) Save() error {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// Transaction runs fn and saves once it succeeds; if fn fails, the entries it changed are
+// restored. Changes made concurrently by other goroutines are not isolated from fn.
+func (s *Store) Transaction(fn func() error) error {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.Lock()
+	s.journal = make(journal)
+	s.mu.Unlock()
+
+	err := fn()
+
+	s.mu.Lock()
+	if err != nil {
+		s.journal.rollback(s.mapping)
+		s.index = nil
+	}
+	s.journal = nil
+	s.mu.Unlock()
 
-	dir := filepath.Dir(s.path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err != nil {
 		return err
 	}
+	return s.Save()
+}
+
+// fileBackend keeps the whole mapping in one JSON file
+type fileBackend struct {
+	path string
+}
+
+func (b *fileBackend) location() string {
+	return b.path
+}
 
-	file, err := os.Create(s.path)
+func (b *fileBackend) load(m *domain.Mapping) error {
+	file, err := os.Open(b.path)
 	if err != nil {
+		if os.IsNotExist(err) {
+			// If file doesn't exist, we start with empty mapping (initialized in NewStore)
+			return nil
+		}
 		return err
 	}
 	defer file.Close()
 
-	encoder := json.NewEncoder(file)
+	decoder := json.NewDecoder(file)
+	return decoder.Decode(m)
+}
+
+func (b *fileBackend) save(m *domain.Mapping) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(s.mapping)
+	if err := encoder.Encode(m); err != nil {
+		return err
+	}
+	return writeFileAtomic(b.path, buf.Bytes())
+}
+
+// Replace swaps the whole mapping, e.g. to copy it into a store with another backend.
+// The next Save overwrites whatever is on disk.
+func (s *Store) Replace(m *domain.Mapping) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.index = nil
+
+	s.mapping = m
+	s.base = nil
+	if s.mapping.Comments == nil {
+		s.mapping.Comments = make(map[string]map[string]string)
+	}
 }
 
 // Get retrieves a translation for a given comment ID and language
@@ -96,6 +249,8 @@ func (s *Store) Get(id, lang string) (string, bool) {
 func (s *Store) Set(id, lang, text string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.index = nil
+	s.journal.record(s.mapping, id)
 
 	if _, ok := s.mapping.Comments[id]; !ok {
 		s.mapping.Comments[id] = make(map[string]string)
@@ -108,8 +263,74 @@ func (s *Store) Set(id, lang, text string) {
 func (s *Store) Delete(id string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.index = nil
+	s.journal.record(s.mapping, id)
 
 	delete(s.mapping.Comments, id)
+	delete(s.mapping.Anchors, id)
+	delete(s.mapping.Archive, id)
+}
+
+// Archive moves a comment mapping to the archive section, keeping its anchor
+func (s *Store) Archive(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.index = nil
+	s.journal.record(s.mapping, id)
+
+	translations, ok := s.mapping.Comments[id]
+	if !ok {
+		return
+	}
+	if s.mapping.Archive == nil {
+		s.mapping.Archive = make(map[string]map[string]string)
+	}
+	s.mapping.Archive[id] = translations
+	delete(s.mapping.Comments, id)
+}
+
+// SetAnchor records where the comment with the given ID was last seen
+func (s *Store) SetAnchor(id string, anchor domain.Anchor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.index = nil
+	s.journal.record(s.mapping, id)
+
+	if s.mapping.Anchors == nil {
+		s.mapping.Anchors = make(map[string]domain.Anchor)
+	}
+	s.mapping.Anchors[id] = anchor
+}
+
+// GetAnchor returns where the comment with the given ID was last seen
+func (s *Store) GetAnchor(id string) (domain.Anchor, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	anchor, ok := s.mapping.Anchors[id]
+	return anchor, ok
+}
+
+// Iterate calls fn for every comment that is not archived until fn returns false
+func (s *Store) Iterate(fn func(id string, translations map[string]string) bool) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for id, translations := range s.mapping.Comments {
+		if !fn(id, translations) {
+			break
+		}
+	}
+	return nil
+}
+
+// SetLanguages records the source and local language of the mapping
+func (s *Store) SetLanguages(source, target string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mapping.SourceLanguage = source
+	s.mapping.TargetLanguage = target
 }
 
 // GetMapping returns the underlying mapping object (read-only copy recommended for complex ops)
@@ -119,3 +340,87 @@ func (s *Store) GetMapping() *domain.Mapping {
 	defer s.mu.RUnlock()
 	return s.mapping
 }
+
+// Unarchive moves an archived comment mapping back to the comments section
+func (s *Store) Unarchive(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.index = nil
+	s.journal.record(s.mapping, id)
+
+	translations, ok := s.mapping.Archive[id]
+	if !ok {
+		return
+	}
+	s.mapping.Comments[id] = translations
+	delete(s.mapping.Archive, id)
+}
+
+// IDsByFile returns the IDs of the live and archived entries anchored to file; "" lists
+// those without an anchor
+func (s *Store) IDsByFile(file string) ([]string, error) {
+	return s.lookup(bucketByFile, file), nil
+}
+
+// IDsBySymbol returns the IDs of the live and archived entries anchored to symbol
+func (s *Store) IDsBySymbol(symbol string) ([]string, error) {
+	return s.lookup(bucketBySymbol, symbol), nil
+}
+
+// IDsByLanguage returns the IDs of the live entries that have a text in lang
+func (s *Store) IDsByLanguage(lang string) ([]string, error) {
+	return s.lookup(bucketByLang, lang), nil
+}
+
+// Files returns the files entries are anchored to, with "" if some have no anchor
+func (s *Store) Files() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	files := make([]string, 0, len(s.indexed()[string(bucketByFile)]))
+	for file := range s.indexed()[string(bucketByFile)] {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// lookup lists the IDs indexed under value, keyed like the buckets of BoltStore
+func (s *Store) lookup(bucket []byte, value string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Clone(s.indexed()[string(bucket)][value])
+}
+
+// indexed returns the index, building it if a change dropped it. The caller holds s.mu.
+func (s *Store) indexed() map[string]map[string][]string {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+
+	if s.index != nil {
+		return s.index
+	}
+	s.index = make(map[string]map[string][]string)
+	ids := make(map[string]bool)
+	for _, section := range []map[string]map[string]string{s.mapping.Comments, s.mapping.Archive} {
+		for id := range section {
+			ids[id] = true
+		}
+	}
+	for id := range ids {
+		for _, k := range indexKeys(s.mapping, id) {
+			value, _, _ := strings.Cut(string(k.key), "\x00")
+			if s.index[string(k.bucket)] == nil {
+				s.index[string(k.bucket)] = make(map[string][]string)
+			}
+			s.index[string(k.bucket)][value] = append(s.index[string(k.bucket)][value], id)
+		}
+	}
+	for _, values := range s.index {
+		for _, list := range values {
+			sort.Strings(list)
+		}
+	}
+	return s.index
+}
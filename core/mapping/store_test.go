@@ -0,0 +1,218 @@
+package mapping
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core"
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func loadStore(t *testing.T, path string) *Store {
+	t.Helper()
+	store := NewStore(path)
+	require.NoError(t, store.Load())
+	return store
+}
+
+func TestStore_SaveMergesConcurrentChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mappings.json")
+	seed := NewStore(path)
+	seed.Set("a", "en", "// Adds numbers")
+	seed.Set("b", "en", "// Subtracts numbers")
+	seed.Set("c", "en", "// Obsolete")
+	require.NoError(t, seed.Save())
+
+	// Both processes load the same version
+	translate := loadStore(t, path)
+	update := loadStore(t, path)
+
+	translate.Set("a", "zh-CN", "// 数字相加")
+	translate.Set("b", "zh-CN", "// 数字相减")
+	require.NoError(t, translate.Save())
+
+	update.SetAnchor("a", domain.Anchor{File: "math.go", Symbol: "math.Add"})
+	update.Set("d", "en", "// Multiplies numbers")
+	update.Delete("c")
+	update.Set("b", "zh-CN", "// 两数相减")
+	require.NoError(t, update.Save())
+
+	m := loadStore(t, path).GetMapping()
+	assert.Equal(t, map[string]map[string]string{
+		"a": {"en": "// Adds numbers", "zh-CN": "// 数字相加"},
+		"b": {"en": "// Subtracts numbers", "zh-CN": "// 两数相减"},
+		"d": {"en": "// Multiplies numbers"},
+	}, m.Comments, "the later save wins only where both changed the same translation")
+	assert.Equal(t, "math.Add", m.Anchors["a"].Symbol)
+
+	// The saving store sees the merged mapping as well
+	text, ok := update.Get("a", "zh-CN")
+	assert.True(t, ok)
+	assert.Equal(t, "// 数字相加", text)
+}
+
+func TestStore_SaveMergesArchive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mappings.json")
+	seed := NewStore(path)
+	seed.Set("a", "en", "// Removed")
+	seed.Set("b", "en", "// Kept")
+	require.NoError(t, seed.Save())
+
+	prune := loadStore(t, path)
+	translate := loadStore(t, path)
+
+	prune.Archive("a")
+	require.NoError(t, prune.Save())
+	translate.Set("b", "zh-CN", "// 保留")
+	require.NoError(t, translate.Save())
+
+	m := loadStore(t, path).GetMapping()
+	assert.Equal(t, map[string]map[string]string{"b": {"en": "// Kept", "zh-CN": "// 保留"}}, m.Comments)
+	assert.Equal(t, map[string]map[string]string{"a": {"en": "// Removed"}}, m.Archive)
+}
+
+func TestStore_ReplaceOverwrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mappings.json")
+	seed := NewStore(path)
+	seed.Set("a", "en", "// Old")
+	require.NoError(t, seed.Save())
+
+	store := loadStore(t, path)
+	store.Replace(&domain.Mapping{Comments: map[string]map[string]string{"b": {"en": "// New"}}})
+	require.NoError(t, store.Save())
+
+	m := loadStore(t, path).GetMapping()
+	assert.Equal(t, map[string]map[string]string{"b": {"en": "// New"}}, m.Comments)
+}
+
+func TestStore_ConcurrentSaves(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mappings.json")
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store := NewStore(path)
+			if !assert.NoError(t, store.Load()) {
+				return
+			}
+			for j := range 5 {
+				store.Set(fmt.Sprintf("%d-%d", i, j), "en", "// Comment")
+				assert.NoError(t, store.Save())
+			}
+		}()
+	}
+	wg.Wait()
+
+	m := loadStore(t, path).GetMapping()
+	assert.Len(t, m.Comments, 40, "no save may drop entries written by another")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.False(t, strings.HasSuffix(e.Name(), ".tmp"), "temporary file %s left behind", e.Name())
+	}
+}
+
+func TestStore_SaveLockTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mappings.json")
+	lock, err := acquireLock(path+".lock", time.Second)
+	require.NoError(t, err)
+
+	store := NewStore(path)
+	store.lockTimeout = 100 * time.Millisecond
+	store.Set("a", "en", "// Waits")
+	err = store.Save()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "超时")
+	_, statErr := os.Stat(path)
+	assert.True(t, os.IsNotExist(statErr), "nothing is written without the lock")
+
+	require.NoError(t, lock.release())
+	assert.NoError(t, store.Save())
+}
+
+func TestShardedStore_SaveMergesConcurrentChanges(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mappings")
+	seed := NewShardedStore(dir)
+	seed.Set("a", "en", "// Adds numbers")
+	seed.SetAnchor("a", domain.Anchor{File: "math.go", Symbol: "math.Add"})
+	require.NoError(t, seed.Save())
+
+	first := NewShardedStore(dir)
+	require.NoError(t, first.Load())
+	second := NewShardedStore(dir)
+	require.NoError(t, second.Load())
+
+	first.Set("a", "zh-CN", "// 数字相加")
+	require.NoError(t, first.Save())
+	second.Set("b", "en", "// Parses input")
+	second.SetAnchor("b", domain.Anchor{File: "parse.go", Symbol: "Parse"})
+	require.NoError(t, second.Save())
+
+	loaded := NewShardedStore(dir)
+	require.NoError(t, loaded.Load())
+	assert.Equal(t, map[string]map[string]string{
+		"a": {"en": "// Adds numbers", "zh-CN": "// 数字相加"},
+		"b": {"en": "// Parses input"},
+	}, loaded.GetMapping().Comments)
+}
+
+func TestStores_IndexesIncludeUnsavedChanges(t *testing.T) {
+	for name, open := range map[string]func(dir string) core.MappingStore{
+		"file":    func(dir string) core.MappingStore { return NewStore(filepath.Join(dir, "mappings.json")) },
+		"sharded": func(dir string) core.MappingStore { return NewShardedStore(filepath.Join(dir, "mappings")) },
+		"bolt":    func(dir string) core.MappingStore { return NewBoltStore(filepath.Join(dir, "mappings.db")) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			store := open(dir)
+			require.NoError(t, store.Load())
+			store.Set("a", "en", "// a")
+			store.SetAnchor("a", domain.Anchor{File: "x.go", Symbol: "pkg.A"})
+			store.Set("b", "en", "// b")
+			store.SetAnchor("b", domain.Anchor{File: "x.go", Symbol: "pkg.B"})
+			store.Set("legacy", "en", "// written before anchors existed")
+			require.NoError(t, store.Save())
+
+			reloaded := open(dir)
+			require.NoError(t, reloaded.Load())
+			defer reloaded.Close()
+			files, err := reloaded.Files()
+			require.NoError(t, err)
+			assert.Equal(t, []string{"", "x.go"}, files)
+
+			// Changes show up before they are saved
+			reloaded.SetAnchor("a", domain.Anchor{File: "y.go", Symbol: "pkg.A"})
+			reloaded.Set("b", "zh-CN", "// 乙")
+			ids, err := reloaded.IDsByFile("x.go")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"b"}, ids)
+			ids, err = reloaded.IDsByFile("y.go")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"a"}, ids)
+			ids, err = reloaded.IDsByFile("")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"legacy"}, ids)
+			ids, err = reloaded.IDsBySymbol("pkg.B")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"b"}, ids)
+			ids, err = reloaded.IDsByLanguage("zh-CN")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"b"}, ids)
+			files, err = reloaded.Files()
+			require.NoError(t, err)
+			assert.Equal(t, []string{"", "x.go", "y.go"}, files)
+		})
+	}
+}
@@ -21,7 +21,7 @@ func FromStdin(filename string) ([]*domain.Comment, error) {
 		return nil, fmt.Errorf("读取 stdin 失败: %w", err)
 	}
 
-	adapter, err := adapters.GetAdapter(filename)
+	adapter, err := adapters.GetAdapterForContent(filename, src)
 	if err != nil {
 		return nil, err
 	}
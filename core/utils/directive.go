@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// directivePattern matches the body of comments that carry instructions for compilers,
+// linters, formatters or bundlers rather than prose: eslint / tslint / prettier / istanbul
+// controls, TypeScript pragmas and triple-slash references, clang-tidy NOLINT, golangci nolint,
+// Python noqa / type comments / pylint / PEP 263 coding cookies, RuboCop, ShellCheck, SwiftLint, dartdoc ignore,
+// Lua diagnostics, PHPCS / PHPStan and bundler magic comments.
+var directivePattern = regexp.MustCompile(`^(?:` + strings.Join([]string{
+	`eslint-(?:disable|enable)(?:-next-line|-line)?\b`,
+	`eslint-env\s`,
+	`eslint\s+[\w/@-]+\s*:`,
+	`tslint:(?:disable|enable)`,
+	`jshint\s+\w+\s*:`,
+	`prettier-ignore\b`,
+	`(?:istanbul|c8|v8) ignore\b`,
+	`@ts-(?:ignore|expect-error|nocheck|check)\b`,
+	`<(?:reference|amd-module|amd-dependency)\b`,
+	`@jsx(?:ImportSource|Runtime|Frag)?\s`,
+	`@flow\b`,
+	`[@#]__(?:PURE|NO_SIDE_EFFECTS)__`,
+	`webpack[A-Z]\w*:`,
+	`@vite-ignore\b`,
+	`NOLINT(?:NEXTLINE|BEGIN|END)?\b`,
+	`clang-format (?:on|off)\b`,
+	`@formatter:(?:on|off)\b`,
+	`noinspection\b`,
+	`nolint\b`,
+	`lint:(?:ignore|file-ignore)\b`,
+	`#?nosec\b`,
+	`rustfmt::skip\b`,
+	`noqa\b`,
+	`type:\s*ignore\b`,
+	`type:\s*(?:\(.*\)\s*->\s*\S.*|[\w.]+(?:\[.*\])?)\s*(?:#.*)?$`,
+	`-\*-.*\bcoding[:=]\s*[-\w.]+`,
+	`(?:en)?coding[:=]\s*[-\w.]+\s*$`,
+	`vim?:.*\bfileencoding=`,
+	`pylint:\s*(?:(?:disable|enable|disable-next)=|skip-file\b)`,
+	`pyright:\s*(?:basic|standard|strict|ignore\b|\w+=)`,
+	`mypy:\s*[a-z]+(?:-[a-z]+)+\b`,
+	`fmt:\s*(?:on|off|skip)\b`,
+	`pragma:\s*no\s*cover\b`,
+	`rubocop:(?:disable|enable|todo)\b`,
+	`shellcheck\s+(?:disable|enable|source|shell)=`,
+	`yamllint (?:disable|enable)`,
+	`swiftlint:(?:disable|enable)`,
+	`ignore_for_file:\s*\w`,
+	`ignore:\s*[a-z][a-z0-9]*(?:_[a-z0-9]+)+(?:\s*,\s*[a-z][a-z0-9_]*)*\s*$`,
+	`luacheck:`,
+	`@diagnostic\s+(?:disable|enable)`,
+	`phpcs:(?:ignore|disable|enable)`,
+	`@phpstan-ignore`,
+	`@psalm-suppress\b`,
+	`NOSONAR\b`,
+	`CHECKSTYLE:(?:OFF|ON)`,
+	`cspell:`,
+	`yaml-language-server:`,
+}, "|") + `)`)
+
+// IsDirective reports whether a comment is a tool directive that must never be translated.
+// Directives that only mean something in one language, such as Go's //go:generate, are
+// recognized by the adapters themselves.
+func IsDirective(text string) bool {
+	t := strings.TrimSpace(text)
+	for _, marker := range []string{"<!--", "/*", "///", "//", "---", "--", "#", ";"} {
+		if strings.HasPrefix(t, marker) {
+			t = strings.TrimPrefix(t, marker)
+			break
+		}
+	}
+	t = strings.TrimLeft(t, "*! \t")
+	return directivePattern.MatchString(t)
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDirective(t *testing.T) {
+	directives := []string{
+		"// eslint-disable-next-line no-console",
+		"/* eslint-disable */",
+		"// @ts-expect-error legacy API",
+		"/// <reference types=\"node\" />",
+		"// prettier-ignore",
+		"/* istanbul ignore next */",
+		"// NOLINT(bugprone-branch-clone)",
+		"//nolint:errcheck",
+		"# noqa: E501",
+		"# type: ignore[attr-defined]",
+		"# pylint: disable=invalid-name",
+		"# -*- coding: utf-8 -*-",
+		"# -*- mode: python; coding: latin-1 -*-",
+		"# coding=utf-8",
+		"# vim: set fileencoding=utf-8 :",
+		"# type: List[int]",
+		"# type: (int, str) -> bool",
+		"# mypy: disable-error-code=\"no-untyped-def\"",
+		"# mypy: ignore-errors",
+		"# pyright: strict",
+		"/* eslint no-console: \"off\" */",
+		"/* eslint-env node */",
+		"/* jshint esversion: 6 */",
+		"// ignore: avoid_print, unused_local_variable",
+		"# fmt: off",
+		"# rubocop:disable Metrics/MethodLength",
+		"# shellcheck disable=SC2086",
+		"// swiftlint:disable:next force_cast",
+		"// ignore_for_file: avoid_print",
+		"---@diagnostic disable-next-line: undefined-global",
+		"// phpcs:ignore",
+		"/* webpackChunkName: \"admin\" */",
+		"/*#__PURE__*/",
+		"# yaml-language-server: $schema=./schema.json",
+	}
+	for _, text := range directives {
+		assert.True(t, IsDirective(text), text)
+	}
+
+	prose := []string{
+		"// go: see the docs",
+		"// Ignore errors from close",
+		"# Format the output",
+		"// TODO: remove eslint once migrated",
+		"/* Returns the number of items */",
+		"// eslint is configured in the root package",
+		"// ignore: whitespace differences are not significant",
+		"# mypy: we rely on duck typing here",
+		"# pylint: the checker is too strict for tests",
+		"# coding: keep functions short and focused",
+		"# type: the kind of record to create",
+		"// jshint is no longer used",
+	}
+	for _, text := range prose {
+		assert.False(t, IsDirective(text), text)
+	}
+}
@@ -4,15 +4,19 @@ import (
 	"crypto/sha1"
 	"encoding/hex"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/studyzy/codei18n/core/domain"
 )
 
+// LuaLongBracket matches the opening of a Lua long comment, capturing its level: --[[ or --[==[
+var LuaLongBracket = regexp.MustCompile(`^--\[(=*)\[`)
+
 // GenerateCommentID calculates a stable ID for a comment
 // Rule: SHA1(file_path + language + parent_symbol + normalized_text)
 func GenerateCommentID(c *domain.Comment) string {
-	normalizedText := NormalizeCommentText(c.SourceText)
+	normalizedText := NormalizeLanguageText(c.Language, c.SourceText)
 
 	// Create the content to hash
 	// Separator | is used to avoid collisions
@@ -29,12 +33,55 @@ func GenerateCommentID(c *domain.Comment) string {
 	return hex.EncodeToString(hasher.Sum(nil))
 }
 
+// stringLiteralLanguages document code with string literals: GraphQL descriptions and
+// SQL COMMENT clauses
+var stringLiteralLanguages = map[string]bool{
+	"graphql": true,
+	"sql":     true,
+}
+
 // NormalizeCommentText removes comment markers and whitespace to ensure stability
 func NormalizeCommentText(text string) string {
+	return normalizeText(text, false)
+}
+
+// NormalizeLanguageText normalizes text like NormalizeCommentText. For languages that
+// document with string literals, the quotes around a literal are removed as well; in other
+// languages they are part of the text.
+func NormalizeLanguageText(language, text string) string {
+	return normalizeText(text, stringLiteralLanguages[language])
+}
+
+func normalizeText(text string, literals bool) string {
 	t := strings.TrimSpace(text)
 
-	// Remove single line markers
-	t = strings.TrimPrefix(t, "//")
+	// Remove Python/shell style markers and docstring quotes
+	if strings.HasPrefix(t, "#") {
+		t = strings.TrimLeft(t, "#")
+	} else if q := docstringQuote(t); q != "" {
+		t = strings.TrimSuffix(strings.TrimPrefix(t, q), q)
+	} else if literals && len(t) >= 2 && (t[0] == '"' || t[0] == '\'') && t[len(t)-1] == t[0] {
+		t = t[1 : len(t)-1]
+	}
+
+	// Remove Ruby =begin/=end and Lua --[[ ]] block markers
+	if strings.HasPrefix(t, "=begin") {
+		t = strings.TrimSuffix(strings.TrimPrefix(t, "=begin"), "=end")
+	} else if m := LuaLongBracket.FindStringSubmatch(t); m != nil {
+		t = strings.TrimSuffix(t[len(m[0]):], "]"+m[1]+"]")
+	}
+
+	// Remove HTML comment markers
+	if strings.HasPrefix(t, "<!--") {
+		t = strings.TrimSuffix(strings.TrimPrefix(t, "<!--"), "-->")
+	}
+
+	// Remove single line markers (// or SQL/Lua-style --, including --- doc lines)
+	if strings.HasPrefix(t, "--") {
+		t = strings.TrimLeft(t, "-")
+	} else {
+		t = strings.TrimPrefix(t, "//")
+	}
 
 	// Remove block markers
 	t = strings.TrimPrefix(t, "/*")
@@ -43,3 +90,13 @@ func NormalizeCommentText(text string) string {
 	// Normalize whitespace: replace sequences of whitespace with single space
 	return strings.Join(strings.Fields(t), " ")
 }
+
+// docstringQuote returns the triple quote delimiter if the text is a docstring literal
+func docstringQuote(text string) string {
+	for _, q := range []string{`"""`, "'''"} {
+		if strings.HasPrefix(text, q) && strings.HasSuffix(text, q) && len(text) >= 2*len(q) {
+			return q
+		}
+	}
+	return ""
+}
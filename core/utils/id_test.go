@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLanguageText(t *testing.T) {
+	// String literals are documentation in GraphQL and SQL
+	assert.Equal(t, "A registered customer", NormalizeLanguageText("graphql", `"A registered customer"`))
+	assert.Equal(t, "Order total", NormalizeLanguageText("sql", `'Order total'`))
+
+	// Elsewhere the quotes belong to the text
+	assert.Equal(t, `"Quoted" value is "returned"`, NormalizeLanguageText("csharp", `"Quoted" value is "returned"`))
+	assert.Equal(t, `'raw mode'`, NormalizeLanguageText("cpp", `'raw mode'`))
+	assert.Equal(t, "Page title", NormalizeLanguageText("vue", "// Page title"))
+}
@@ -0,0 +1,14 @@
+package utils
+
+import "regexp"
+
+// taskMarker matches the source markers editors collect into task lists and jump bars,
+// such as "// MARK: - ", "// TODO: ", "// TODO(alice): " or "// FIXME: ".
+var taskMarker = regexp.MustCompile(`^//\s*(?:MARK|TODO|FIXME|HACK|WARNING|NOTE)(?:\([^)]*\))?\s*:\s*(?:-\s*)?`)
+
+// TaskMarkerPrefix returns the task marker text starts with, including the space after it,
+// or "" if there is none. Only the description after the marker is translated; the marker
+// itself must stay intact for the editor.
+func TaskMarkerPrefix(text string) string {
+	return taskMarker.FindString(text)
+}
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// lineMarkers are the line comment prefixes recognized when grouping comments,
+// longest first so that "///" wins over "//".
+var lineMarkers = []string{"///", "//!", "//", "---", "--", "##", "#", ";;", ";", "%"}
+
+// LineCommentMarker returns the line comment marker text starts with ("//", "///", "#", "--"),
+// or "" if text is not a line comment.
+func LineCommentMarker(text string) string {
+	for _, marker := range lineMarkers {
+		if strings.HasPrefix(text, marker) {
+			return marker
+		}
+	}
+	return ""
+}
+
+// IsWide reports whether r is a CJK character that occupies two columns and is written
+// without spaces between words.
+func IsWide(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		(r >= 0x3000 && r <= 0x303F) || // CJK symbols and punctuation
+		(r >= 0xFF00 && r <= 0xFF60) || (r >= 0xFFE0 && r <= 0xFFE6) // fullwidth forms
+}
+
+// DisplayWidth returns the number of terminal columns s occupies
+func DisplayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		if IsWide(r) {
+			width += 2
+		} else {
+			width++
+		}
+	}
+	return width
+}
+
+// JoinParagraph joins wrapped lines into one paragraph. Lines are separated by a space,
+// except between two CJK characters, so that JoinParagraph reverses WrapParagraph.
+func JoinParagraph(lines []string) string {
+	var b strings.Builder
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			last, _ := utf8.DecodeLastRuneInString(b.String())
+			first, _ := utf8.DecodeRuneInString(line)
+			if !IsWide(last) || !IsWide(first) {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// wrapAtom is an unbreakable run of text and whether a space separates it from the previous one
+type wrapAtom struct {
+	text        string
+	spaceBefore bool
+}
+
+// WrapParagraph breaks text into lines of at most width columns. Lines break at spaces
+// or between two CJK characters; a single word wider than width gets a line of its own.
+// Newlines in text are kept as hard breaks.
+func WrapParagraph(text string, width int) []string {
+	var lines []string
+	for _, para := range strings.Split(text, "\n") {
+		lines = append(lines, wrapLine(para, width)...)
+	}
+	return lines
+}
+
+func wrapLine(text string, width int) []string {
+	atoms := splitAtoms(text)
+	if len(atoms) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	var line strings.Builder
+	lineWidth := 0
+	for _, atom := range atoms {
+		sep := ""
+		if atom.spaceBefore {
+			sep = " "
+		}
+		atomWidth := DisplayWidth(atom.text)
+		if line.Len() > 0 && lineWidth+len(sep)+atomWidth > width {
+			lines = append(lines, line.String())
+			line.Reset()
+			lineWidth = 0
+			sep = ""
+		}
+		line.WriteString(sep + atom.text)
+		lineWidth += len(sep) + atomWidth
+	}
+	return append(lines, line.String())
+}
+
+// splitAtoms splits text at the positions WrapParagraph may break: spaces, unless both
+// neighbours are CJK (joining would lose the space), and between two adjacent CJK characters.
+func splitAtoms(text string) []wrapAtom {
+	var atoms []wrapAtom
+	var current []rune
+	spaceBefore := false // a space separates current from the previous atom
+	space := false       // spaces were skipped since the last rune
+
+	flush := func() {
+		if len(current) > 0 {
+			atoms = append(atoms, wrapAtom{text: string(current), spaceBefore: spaceBefore})
+			current = nil
+		}
+	}
+
+	for _, r := range strings.TrimSpace(text) {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if len(current) > 0 {
+			prev := current[len(current)-1]
+			switch {
+			case space && IsWide(prev) && IsWide(r):
+				// "中 文" must keep its space, so it cannot be a break point
+				current = append(current, ' ')
+			case space:
+				flush()
+				spaceBefore = true
+			case IsWide(prev) && IsWide(r):
+				flush()
+				spaceBefore = false
+			}
+		}
+		space = false
+		current = append(current, r)
+	}
+	flush()
+	return atoms
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineCommentMarker(t *testing.T) {
+	assert.Equal(t, "///", LineCommentMarker("/// doc"))
+	assert.Equal(t, "//", LineCommentMarker("// note"))
+	assert.Equal(t, "#", LineCommentMarker("# note"))
+	assert.Equal(t, "---", LineCommentMarker("--- LuaDoc"))
+	assert.Equal(t, "", LineCommentMarker("/* block */"))
+	assert.Equal(t, "", LineCommentMarker(`"""docstring"""`))
+}
+
+func TestWrapParagraph(t *testing.T) {
+	assert.Equal(t, []string{"the quick brown", "fox jumps over", "the lazy dog"},
+		WrapParagraph("the quick brown fox jumps over the lazy dog", 15))
+	assert.Equal(t, []string{"这是一段用于测", "试的中文注释"},
+		WrapParagraph("这是一段用于测试的中文注释", 14))
+	assert.Equal(t, []string{"supercalifragilistic", "word"}, WrapParagraph("supercalifragilistic word", 8))
+	assert.Equal(t, []string{"first", "second"}, WrapParagraph("first\nsecond", 80))
+}
+
+func TestJoinParagraphReversesWrap(t *testing.T) {
+	texts := []string{
+		"the quick brown fox jumps over the lazy dog",
+		"这是一段用于测试的中文注释，包含 API 调用与 HTTP 请求说明。",
+		"混合 mixed 文本 text，中 文 spaced",
+	}
+	for _, text := range texts {
+		for _, width := range []int{4, 10, 20, 80} {
+			lines := WrapParagraph(text, width)
+			for _, line := range lines {
+				assert.Equal(t, line, strings.TrimSpace(line))
+			}
+			assert.Equal(t, text, JoinParagraph(lines), "width %d", width)
+		}
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	assert.Equal(t, 5, DisplayWidth("hello"))
+	assert.Equal(t, 4, DisplayWidth("中文"))
+	assert.Equal(t, 2, DisplayWidth("，"))
+}
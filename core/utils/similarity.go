@@ -0,0 +1,43 @@
+package utils
+
+// Similarity returns how alike a and b are, from 0 (nothing in common) to 1 (equal),
+// as one minus their rune-level edit distance divided by the longer length.
+func Similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	return 1 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+// MaxSimilarity returns an upper bound of Similarity(a, b) computed from the lengths alone,
+// so that hopeless pairs can be skipped without computing the edit distance.
+func MaxSimilarity(a, b string) float64 {
+	la, lb := len([]rune(a)), len([]rune(b))
+	if la == lb {
+		return 1
+	}
+	return float64(min(la, lb)) / float64(max(la, lb))
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
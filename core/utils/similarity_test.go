@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity("", ""))
+	assert.Equal(t, 1.0, Similarity("same text", "same text"))
+	assert.Equal(t, 0.0, Similarity("abc", ""))
+	assert.Equal(t, 0.0, Similarity("abc", "xyz"))
+
+	// One typo in a 20 character sentence
+	assert.InDelta(t, 0.95, Similarity("Adds the two numbers", "Adds the two numbrs"), 0.001)
+	// Runes, not bytes, are compared
+	assert.InDelta(t, 0.75, Similarity("计算余额", "计算总额"), 0.001)
+}
+
+func TestMaxSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, MaxSimilarity("abc", "xyz"))
+	assert.Equal(t, 0.5, MaxSimilarity("ab", "abcd"))
+	for _, pair := range [][2]string{{"kitten", "sitting"}, {"flaw", "lawn"}, {"中文注释", "注释"}} {
+		assert.GreaterOrEqual(t, MaxSimilarity(pair[0], pair[1]), Similarity(pair[0], pair[1]))
+	}
+}
@@ -0,0 +1,65 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/studyzy/codei18n/core/config"
+	"github.com/studyzy/codei18n/core/mapping"
+	"github.com/studyzy/codei18n/internal/log"
+)
+
+// MigrateResult holds the result of map migrate operation
+type MigrateResult struct {
+	From      string
+	To        string
+	Count     int
+	StorePath string
+}
+
+// MapMigrate copies the mapping from the configured storage backend to the backend named to.
+// Unless keepSource is set, the old storage is removed once the new one is written.
+// Updating the mappingStorage config is left to the caller.
+func MapMigrate(cfg *config.Config, to string, keepSource bool) (*MigrateResult, error) {
+	from := cfg.MappingStorage
+	if from == "" {
+		from = mapping.BackendFile
+	}
+	if from == to {
+		return nil, fmt.Errorf("映射已使用 %s 存储", to)
+	}
+
+	src, err := mapping.ForBackend(from)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := mapping.ForBackend(to)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := src.Load(); err != nil {
+		return nil, fmt.Errorf("加载映射文件失败: %w", err)
+	}
+	if err := dst.Load(); err == nil && len(dst.GetMapping().Comments) > 0 {
+		log.Warn("目标存储 %s 已有 %d 条映射，将被覆盖", dst.Path(), len(dst.GetMapping().Comments))
+	}
+
+	m := src.GetMapping()
+	dst.Replace(m)
+	if err := dst.Save(); err != nil {
+		return nil, fmt.Errorf("保存映射文件失败: %w", err)
+	}
+	if err := errors.Join(src.Close(), dst.Close()); err != nil {
+		return nil, err
+	}
+
+	if !keepSource {
+		if err := os.RemoveAll(src.Path()); err != nil {
+			return nil, fmt.Errorf("删除旧映射存储失败: %w", err)
+		}
+	}
+
+	return &MigrateResult{From: from, To: to, Count: len(m.Comments), StorePath: dst.Path()}, nil
+}
@@ -0,0 +1,39 @@
+package workflow
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/config"
+	"github.com/studyzy/codei18n/core/mapping"
+)
+
+func TestMapMigrate(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := config.DefaultConfig()
+	require.NoError(t, os.WriteFile("ledger.go", []byte(pruneSource), 0644))
+	_, err := MapUpdate(cfg, ".", false)
+	require.NoError(t, err)
+
+	_, err = MapMigrate(cfg, mapping.BackendFile, false)
+	assert.Error(t, err, "migrating to the backend in use must fail")
+
+	result, err := MapMigrate(cfg, mapping.BackendSharded, true)
+	require.NoError(t, err)
+	assert.Equal(t, mapping.BackendFile, result.From)
+	assert.Equal(t, 2, result.Count)
+	assert.FileExists(t, mapping.DefaultFilePath, "keepSource must keep the old storage")
+
+	result, err = MapMigrate(cfg, mapping.BackendBolt, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Count)
+	assert.NoFileExists(t, mapping.DefaultFilePath)
+
+	cfg.MappingStorage = mapping.BackendBolt
+	store := loadMapping(t, cfg)
+	assert.Len(t, store.GetMapping().Comments, 2)
+	assert.Len(t, store.GetMapping().Anchors, 2)
+}
@@ -0,0 +1,103 @@
+package workflow
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/studyzy/codei18n/core/config"
+	"github.com/studyzy/codei18n/core/mapping"
+)
+
+// PruneOptions configures the map prune operation
+type PruneOptions struct {
+	DryRun bool
+	// Grace keeps entries whose comment went missing less than Grace ago
+	Grace time.Duration
+	// Archive moves pruned entries to the archive section instead of deleting them,
+	// so that map update can still revive them by re-anchoring
+	Archive bool
+}
+
+// PruneResult holds the result of map prune operation
+type PruneResult struct {
+	// StaleCount is the number of entries whose comment no longer exists
+	StaleCount int
+	// Pruned lists the IDs deleted or archived
+	Pruned []string
+	// PendingCount is the number of stale entries still within the grace period
+	PendingCount int
+	// UnsyncedCount is the number of scanned comments not yet in the mapping
+	UnsyncedCount int
+	StorePath     string
+}
+
+// MapPrune removes mapping entries whose comments no longer exist in scanDir. Entries in
+// files that exist but were not scanned are kept, as are entries that went missing less
+// than opts.Grace ago; the first prune or map update that misses an entry starts its clock.
+// Without opts.Archive, archived entries past the grace period are deleted as well.
+func MapPrune(cfg *config.Config, scanDir string, opts PruneOptions) (*PruneResult, error) {
+	comments, err := scanForMapping(cfg, scanDir)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := mapping.ForBackend(cfg.MappingStorage)
+	if err != nil {
+		return nil, err
+	}
+	storePath := store.Path()
+	if err := store.Load(); err != nil {
+		return nil, fmt.Errorf("加载映射文件失败: %w", err)
+	}
+	defer store.Close()
+	m := store.GetMapping()
+
+	seen, scannedFiles := trackComments(comments)
+	result := &PruneResult{StorePath: storePath}
+	for id := range seen {
+		_, live := m.Comments[id]
+		_, archived := m.Archive[id]
+		if !live && !archived {
+			result.UnsyncedCount++
+		}
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	stale, err := staleEntries(store, seen, scannedFiles, scanDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range stale {
+		_, archived := m.Archive[id]
+		if archived && opts.Archive {
+			continue
+		}
+		result.StaleCount++
+
+		anchor, _ := store.GetAnchor(id)
+		if anchor.OrphanedAt.IsZero() {
+			anchor.OrphanedAt = now
+			store.SetAnchor(id, anchor)
+		}
+		if now.Sub(anchor.OrphanedAt) < opts.Grace {
+			result.PendingCount++
+			continue
+		}
+
+		if opts.Archive {
+			store.Archive(id)
+		} else {
+			store.Delete(id)
+		}
+		result.Pruned = append(result.Pruned, id)
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if err := store.Save(); err != nil {
+		return nil, fmt.Errorf("保存映射文件失败: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package workflow
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core"
+	"github.com/studyzy/codei18n/core/config"
+	"github.com/studyzy/codei18n/core/mapping"
+)
+
+const pruneSource = `package billing
+
+// Balance sums the ledger entries
+func Balance() int { return 0 }
+
+// Reset clears the ledger
+func Reset() {}
+`
+
+// setupPrune maps pruneSource, then deletes the Reset comment. It returns the ID of the
+// entry left stale.
+func setupPrune(t *testing.T) (*config.Config, string) {
+	t.Chdir(t.TempDir())
+	cfg := config.DefaultConfig()
+	require.NoError(t, os.WriteFile("ledger.go", []byte(pruneSource), 0644))
+	_, err := MapUpdate(cfg, ".", false)
+	require.NoError(t, err)
+
+	before := loadMapping(t, cfg).GetMapping()
+	require.NoError(t, os.WriteFile("ledger.go", []byte("package billing\n\n// Balance sums the ledger entries\nfunc Balance() int { return 0 }\n"), 0644))
+	for id, translations := range before.Comments {
+		if translations["en"] == "// Reset clears the ledger" {
+			return cfg, id
+		}
+	}
+	t.Fatal("Reset comment not mapped")
+	return nil, ""
+}
+
+// loadMapping loads the configured mapping store, closed when the test ends
+func loadMapping(t *testing.T, cfg *config.Config) core.MappingStore {
+	t.Helper()
+	store, err := mapping.ForBackend(cfg.MappingStorage)
+	require.NoError(t, err)
+	require.NoError(t, store.Load())
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestMapPrune_DeletesStaleEntries(t *testing.T) {
+	cfg, stale := setupPrune(t)
+
+	result, err := MapPrune(cfg, ".", PruneOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{stale}, result.Pruned)
+	assert.Contains(t, loadMapping(t, cfg).GetMapping().Comments, stale, "a dry run must not save")
+
+	result, err = MapPrune(cfg, ".", PruneOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.StaleCount)
+	assert.Equal(t, []string{stale}, result.Pruned)
+	m := loadMapping(t, cfg).GetMapping()
+	assert.Len(t, m.Comments, 1)
+	assert.NotContains(t, m.Comments, stale)
+	assert.NotContains(t, m.Anchors, stale)
+}
+
+func TestMapPrune_GraceAndArchive(t *testing.T) {
+	cfg, stale := setupPrune(t)
+
+	// The first prune starts the clock
+	result, err := MapPrune(cfg, ".", PruneOptions{Grace: time.Hour, Archive: true})
+	require.NoError(t, err)
+	assert.Empty(t, result.Pruned)
+	assert.Equal(t, 1, result.PendingCount)
+	assert.False(t, loadMapping(t, cfg).GetMapping().Anchors[stale].OrphanedAt.IsZero())
+
+	result, err = MapPrune(cfg, ".", PruneOptions{Archive: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{stale}, result.Pruned)
+	m := loadMapping(t, cfg).GetMapping()
+	assert.NotContains(t, m.Comments, stale)
+	assert.Contains(t, m.Archive, stale)
+
+	// Archived entries are left alone by later archiving prunes
+	result, err = MapPrune(cfg, ".", PruneOptions{Archive: true})
+	require.NoError(t, err)
+	assert.Zero(t, result.StaleCount)
+}
@@ -2,9 +2,10 @@ package workflow
 
 import (
 	"fmt"
-	"path/filepath"
+	"time"
 
 	"github.com/studyzy/codei18n/core/config"
+	"github.com/studyzy/codei18n/core/domain"
 	"github.com/studyzy/codei18n/core/mapping"
 	"github.com/studyzy/codei18n/core/scanner"
 	"github.com/studyzy/codei18n/core/utils"
@@ -16,27 +17,26 @@ type MapUpdateResult struct {
 	TotalComments int
 	AddedCount    int
 	StorePath     string
+	// Migrated lists the translations re-attached to comments whose ID changed
+	// because the file, symbol or text changed
+	Migrated []Migration
 }
 
 // MapUpdate executes the logic for updating the mapping file
 func MapUpdate(cfg *config.Config, scanDir string, dryRun bool) (*MapUpdateResult, error) {
 	// 1. Scan for comments
-	log.Info("正在扫描目录: %s", scanDir)
-	comments, err := scanner.Directory(scanDir, cfg.ExcludePatterns...)
-	if err != nil {
-		return nil, fmt.Errorf("扫描失败: %w", err)
-	}
-
 	// 2. Generate IDs
-	for _, c := range comments {
-		if c.ID == "" {
-			c.ID = utils.GenerateCommentID(c)
-		}
+	comments, err := scanForMapping(cfg, scanDir)
+	if err != nil {
+		return nil, err
 	}
 
 	// 3. Load Store
-	storePath := filepath.Join(".codei18n", "mappings.json")
-	store := mapping.NewStore(storePath)
+	store, err := mapping.ForBackend(cfg.MappingStorage)
+	if err != nil {
+		return nil, err
+	}
+	storePath := store.Path()
 	if err := store.Load(); err != nil {
 		// Just warn if load fails (might be new file), but for robustness we should probably check if file exists
 		// logic in original map.go was: log.Fatal("加载映射文件失败: %v", err)
@@ -45,44 +45,122 @@ func MapUpdate(cfg *config.Config, scanDir string, dryRun bool) (*MapUpdateResul
 		return nil, fmt.Errorf("加载映射文件失败: %w", err)
 	}
 
+	defer store.Close()
+
 	// 4. Update
+	store.SetLanguages(cfg.SourceLanguage, cfg.LocalLanguage)
 	m := store.GetMapping()
-	m.SourceLanguage = cfg.SourceLanguage
-	m.TargetLanguage = cfg.LocalLanguage
 
-	addedCount := 0
+	seen, scannedFiles := trackComments(comments)
+	var fresh []*domain.Comment
+	var revived []Migration
+	queued := make(map[string]bool)
 	for _, c := range comments {
-		// Check if ID exists
+		// Directives are not in seen; a comment repeated with the same ID is handled once
+		if !seen[c.ID] || queued[c.ID] {
+			continue
+		}
+		queued[c.ID] = true
+		if _, archived := m.Archive[c.ID]; archived {
+			// The comment came back unchanged after being pruned
+			store.Unarchive(c.ID)
+			revived = append(revived, Migration{OldID: c.ID, NewID: c.ID, File: c.File, Symbol: c.Symbol, Similarity: 1})
+			continue
+		}
 		if _, exists := m.Comments[c.ID]; !exists {
-			// [MOCK zh-CN->en] // Intelligently detect comment language
-			detectedLang := utils.DetectLanguage(c.SourceText)
-
-			if detectedLang == cfg.LocalLanguage {
-				// The comment is in the local language, stored as LocalLanguage
-				store.Set(c.ID, cfg.LocalLanguage, c.SourceText)
-				log.Info("检测到中文注释: ID=%s, Text=%s", c.ID, c.SourceText)
-			} else {
-				// The comment is in the source language, stored as SourceLanguage
-				store.Set(c.ID, cfg.SourceLanguage, c.SourceText)
+			fresh = append(fresh, c)
+		}
+	}
+
+	// 5. Re-anchor translations whose comment got a new ID through a rename, move or edit.
+	// Archived entries take part too, so that pruned translations can be revived.
+	stale, err := staleEntries(store, seen, scannedFiles, scanDir)
+	if err != nil {
+		return nil, err
+	}
+	migrations := reanchor(findOrphans(m, stale), fresh, gitRenames(scanDir))
+	migrated := make(map[string]string, len(migrations))
+	for _, mig := range migrations {
+		migrated[mig.NewID] = mig.OldID
+	}
+
+	addedCount := 0
+	for _, c := range fresh {
+		// [MOCK zh-CN->en] // Intelligently detect comment language
+		detectedLang := utils.DetectLanguage(c.SourceText)
+
+		if oldID, ok := migrated[c.ID]; ok {
+			// Carry the translations over; the comment's own text replaces the old one
+			for lang, text := range entryTranslations(m, oldID) {
+				if lang != detectedLang {
+					store.Set(c.ID, lang, text)
+				}
 			}
+			store.Delete(oldID)
+		}
+
+		if detectedLang == cfg.LocalLanguage {
+			// The comment is in the local language, stored as LocalLanguage
+			store.Set(c.ID, cfg.LocalLanguage, c.SourceText)
+			log.Info("检测到中文注释: ID=%s, Text=%s", c.ID, c.SourceText)
+		} else {
+			// The comment is in the source language, stored as SourceLanguage
+			store.Set(c.ID, cfg.SourceLanguage, c.SourceText)
+		}
+		if _, ok := migrated[c.ID]; !ok {
 			addedCount++
 		}
 	}
 
+	for _, c := range comments {
+		if seen[c.ID] {
+			store.SetAnchor(c.ID, domain.Anchor{File: c.File, Symbol: c.Symbol, Language: c.Language})
+		}
+	}
+
+	// Remember when entries went missing, for the grace period of map prune
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, id := range stale {
+		if _, live := m.Comments[id]; !live {
+			continue
+		}
+		if anchor, _ := store.GetAnchor(id); anchor.OrphanedAt.IsZero() {
+			anchor.OrphanedAt = now
+			store.SetAnchor(id, anchor)
+		}
+	}
+
 	result := &MapUpdateResult{
 		TotalComments: len(comments),
 		AddedCount:    addedCount,
 		StorePath:     storePath,
+		Migrated:      append(revived, migrations...),
 	}
 
 	if dryRun {
 		return result, nil
 	}
 
-	// 5. Save
+	// 6. Save
 	if err := store.Save(); err != nil {
 		return nil, fmt.Errorf("保存映射文件失败: %w", err)
 	}
 
 	return result, nil
 }
+
+// scanForMapping scans scanDir and assigns every comment its ID
+func scanForMapping(cfg *config.Config, scanDir string) ([]*domain.Comment, error) {
+	log.Info("正在扫描目录: %s", scanDir)
+	comments, err := scanner.Directory(scanDir, cfg.ExcludePatterns...)
+	if err != nil {
+		return nil, fmt.Errorf("扫描失败: %w", err)
+	}
+
+	for _, c := range comments {
+		if c.ID == "" {
+			c.ID = utils.GenerateCommentID(c)
+		}
+	}
+	return comments, nil
+}
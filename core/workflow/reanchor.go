@@ -0,0 +1,369 @@
+package workflow
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/studyzy/codei18n/core"
+	"github.com/studyzy/codei18n/core/domain"
+	"github.com/studyzy/codei18n/core/utils"
+)
+
+// Re-anchoring weights and thresholds. A pair is matched when the texts are identical,
+// or when the weighted score reaches reanchorThreshold and the texts are at least
+// minTextSimilarity alike. Entries from mapping files without anchors can only be
+// matched by text, so they need legacyThreshold.
+const (
+	textWeight        = 0.6
+	symbolWeight      = 0.25
+	fileWeight        = 0.15
+	reanchorThreshold = 0.8
+	minTextSimilarity = 0.7
+	legacyThreshold   = 0.9
+)
+
+// gitRenameHistory is how many recent commits are searched for renames
+const gitRenameHistory = 200
+
+// Migration records a translation carried over from an orphaned mapping entry to a new comment
+type Migration struct {
+	OldID  string
+	NewID  string
+	File   string
+	Symbol string
+	// Similarity is the text similarity of the old and new comment, 1 if unchanged
+	Similarity float64
+	// Renamed is set when git reported the old file as renamed to the new one
+	Renamed bool
+}
+
+// TextChanged reports whether the comment text changed, so the carried translation should be reviewed
+func (m Migration) TextChanged() bool {
+	return m.Similarity < 1
+}
+
+// orphan is a mapping entry whose comment was not found by the scan
+type orphan struct {
+	id     string
+	text   string
+	anchor domain.Anchor
+	// anchored is false for entries written before anchors were recorded
+	anchored bool
+}
+
+// candidate is a possible orphan -> comment match
+type candidate struct {
+	orphan  int
+	comment int
+	score   float64
+	text    float64
+	renamed bool
+}
+
+// maxFuzzyCandidates caps how many comments each orphan is compared with by edit distance
+const maxFuzzyCandidates = 50
+
+// reanchor matches orphaned mapping entries to fresh comments by text similarity, symbol
+// similarity and git rename detection. Comments with the same normalized text are matched
+// first through a hash lookup; the orphans left over are compared by edit distance with the
+// comments in their file (or the file it was renamed to) and with the same symbol name only.
+// Within each pass every orphan and every comment is used at most once, best scores first.
+func reanchor(orphans []orphan, fresh []*domain.Comment, renames map[string]string) []Migration {
+	if len(orphans) == 0 || len(fresh) == 0 {
+		return nil
+	}
+
+	texts := make([]string, len(fresh))
+	exact := make(map[string]map[string][]int)
+	byFile := make(map[string][]int)
+	bySymbol := make(map[string][]int)
+	var languages []string
+	all := make([]int, len(fresh))
+	for i, c := range fresh {
+		all[i] = i
+		texts[i] = utils.NormalizeLanguageText(c.Language, c.SourceText)
+		if exact[c.Language] == nil {
+			exact[c.Language] = make(map[string][]int)
+			languages = append(languages, c.Language)
+		}
+		exact[c.Language][texts[i]] = append(exact[c.Language][texts[i]], i)
+		byFile[c.File] = append(byFile[c.File], i)
+		if name := symbolName(c.Symbol); name != "" {
+			bySymbol[name] = append(bySymbol[name], i)
+		}
+	}
+
+	// The orphan's text is normalized like each comment it is compared to, as its
+	// language may have changed with its file
+	oldTexts := make([]map[string]string, len(orphans))
+	oldText := func(oi int, language string) string {
+		if oldTexts[oi] == nil {
+			oldTexts[oi] = make(map[string]string)
+		}
+		text, ok := oldTexts[oi][language]
+		if !ok {
+			text = utils.NormalizeLanguageText(language, orphans[oi].text)
+			oldTexts[oi][language] = text
+		}
+		return text
+	}
+
+	usedOrphans := make([]bool, len(orphans))
+	usedComments := make([]bool, len(fresh))
+
+	// Pass 1: identical texts
+	var candidates []candidate
+	for oi := range orphans {
+		for _, language := range languages {
+			for _, ci := range exact[language][oldText(oi, language)] {
+				if cand, ok := scoreCandidate(orphans[oi], fresh[ci], 1, renames); ok {
+					cand.orphan, cand.comment = oi, ci
+					candidates = append(candidates, cand)
+				}
+			}
+		}
+	}
+	migrations := assignCandidates(candidates, orphans, fresh, usedOrphans, usedComments)
+
+	// Pass 2: similar texts among the comments near each orphan left over
+	candidates = nil
+	for oi, o := range orphans {
+		if usedOrphans[oi] {
+			continue
+		}
+		compared := 0
+		for _, ci := range nearbyComments(o, all, byFile, bySymbol, renames) {
+			if compared == maxFuzzyCandidates {
+				break
+			}
+			if usedComments[ci] {
+				continue
+			}
+			old := oldText(oi, fresh[ci].Language)
+			if utils.MaxSimilarity(old, texts[ci]) < minTextSimilarity {
+				continue
+			}
+			compared++
+			text := utils.Similarity(old, texts[ci])
+			if text < minTextSimilarity {
+				continue
+			}
+			if cand, ok := scoreCandidate(o, fresh[ci], text, renames); ok {
+				cand.orphan, cand.comment = oi, ci
+				candidates = append(candidates, cand)
+			}
+		}
+	}
+	migrations = append(migrations, assignCandidates(candidates, orphans, fresh, usedOrphans, usedComments)...)
+
+	sort.Slice(migrations, func(i, j int) bool {
+		if migrations[i].File != migrations[j].File {
+			return migrations[i].File < migrations[j].File
+		}
+		return migrations[i].NewID < migrations[j].NewID
+	})
+	return migrations
+}
+
+// scoreCandidate scores an orphan -> comment pair whose texts are text alike and reports
+// whether it is good enough to be matched
+func scoreCandidate(o orphan, c *domain.Comment, text float64, renames map[string]string) (candidate, bool) {
+	cand := candidate{text: text}
+	if !o.anchored {
+		cand.score = text
+		return cand, text >= legacyThreshold
+	}
+
+	file := 0.0
+	if o.anchor.File == c.File {
+		file = 1
+	} else if renames[o.anchor.File] == c.File {
+		file = 1
+		cand.renamed = true
+	}
+	symbol := utils.Similarity(o.anchor.Symbol, c.Symbol)
+	cand.score = textWeight*text + symbolWeight*symbol + fileWeight*file
+	return cand, text == 1 || cand.score >= reanchorThreshold
+}
+
+// nearbyComments returns the indexes of the comments worth comparing with the orphan by edit
+// distance: those in its file or the file it was renamed to, then those with the same symbol
+// name. Orphans without an anchor have nothing to go by and get all of them, in scan order;
+// the caller stops after maxFuzzyCandidates comparisons.
+func nearbyComments(o orphan, all []int, byFile, bySymbol map[string][]int, renames map[string]string) []int {
+	if !o.anchored {
+		return all
+	}
+
+	var nearby []int
+	added := make(map[int]bool)
+	for _, bucket := range [][]int{byFile[o.anchor.File], byFile[renames[o.anchor.File]], bySymbol[symbolName(o.anchor.Symbol)]} {
+		for _, ci := range bucket {
+			if !added[ci] {
+				added[ci] = true
+				nearby = append(nearby, ci)
+			}
+		}
+	}
+	return nearby
+}
+
+// symbolName returns the last component of a symbol path, which survives a package or
+// class rename: "billing.Ledger.Balance" -> "Balance"
+func symbolName(symbol string) string {
+	return symbol[strings.LastIndexAny(symbol, ".:#/")+1:]
+}
+
+// assignCandidates matches the candidates best scores first, skipping orphans and comments
+// already used, and marks the ones it uses
+func assignCandidates(candidates []candidate, orphans []orphan, fresh []*domain.Comment, usedOrphans, usedComments []bool) []Migration {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if a.comment != b.comment {
+			return a.comment < b.comment
+		}
+		return orphans[a.orphan].id < orphans[b.orphan].id
+	})
+
+	var migrations []Migration
+	for _, cand := range candidates {
+		if usedOrphans[cand.orphan] || usedComments[cand.comment] {
+			continue
+		}
+		usedOrphans[cand.orphan] = true
+		usedComments[cand.comment] = true
+
+		c := fresh[cand.comment]
+		migrations = append(migrations, Migration{
+			OldID:      orphans[cand.orphan].id,
+			NewID:      c.ID,
+			File:       c.File,
+			Symbol:     c.Symbol,
+			Similarity: cand.text,
+			Renamed:    cand.renamed,
+		})
+	}
+	return migrations
+}
+
+// staleEntries returns the IDs of mapping entries (live or archived) whose comment was not
+// seen by the scan. Entries anchored to a file that still exists but was not scanned
+// (excluded, or outside a partial scan) are not stale. Entries are listed per file through
+// the store's file index, so each anchored file is checked once.
+func staleEntries(store core.MappingStore, seen, scannedFiles map[string]bool, scanDir string) ([]string, error) {
+	files, err := store.Files()
+	if err != nil {
+		return nil, fmt.Errorf("读取映射索引失败: %w", err)
+	}
+
+	var ids []string
+	for _, file := range files {
+		if file != "" && !scannedFiles[file] {
+			if _, err := os.Stat(filepath.Join(scanDir, file)); err == nil {
+				continue
+			}
+		}
+		fileIDs, err := store.IDsByFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("读取映射索引失败: %w", err)
+		}
+		for _, id := range fileIDs {
+			if !seen[id] {
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// findOrphans returns the stale entries that can be re-anchored: those with a source or
+// local language text that is not a directive.
+func findOrphans(m *domain.Mapping, stale []string) []orphan {
+	var orphans []orphan
+	for _, id := range stale {
+		translations := entryTranslations(m, id)
+		text, ok := translations[m.SourceLanguage]
+		if !ok {
+			text, ok = translations[m.TargetLanguage]
+		}
+		if !ok || isDirectiveEntry(translations) {
+			continue
+		}
+
+		anchor, anchored := m.Anchors[id]
+		anchored = anchored && anchor.File != ""
+		orphans = append(orphans, orphan{id: id, text: text, anchor: anchor, anchored: anchored})
+	}
+	return orphans
+}
+
+// entryTranslations returns the translations of a live or archived entry
+func entryTranslations(m *domain.Mapping, id string) map[string]string {
+	if translations, ok := m.Comments[id]; ok {
+		return translations
+	}
+	return m.Archive[id]
+}
+
+// trackComments returns the IDs of the trackable comments and the files they are in.
+// Directives are instructions for tools, not prose, so they are never tracked.
+func trackComments(comments []*domain.Comment) (seen, files map[string]bool) {
+	seen = make(map[string]bool)
+	files = make(map[string]bool)
+	for _, c := range comments {
+		if c.Type == domain.CommentTypeDirective {
+			continue
+		}
+		seen[c.ID] = true
+		files[c.File] = true
+	}
+	return seen, files
+}
+
+// gitRenames returns the files git reports as renamed, old path -> current path, relative to
+// dir. Renames from recent commits and uncommitted (tracked) changes are chained, so a file
+// renamed twice maps to its latest name. Outside a git repository the result is empty.
+func gitRenames(dir string) map[string]string {
+	renames := make(map[string]string)
+	record := func(from, to string) {
+		for old, current := range renames {
+			if current == from {
+				renames[old] = to
+			}
+		}
+		renames[from] = to
+	}
+
+	for _, args := range [][]string{
+		// Oldest first so that later renames extend earlier ones
+		{"log", "-M", "--diff-filter=R", "--name-status", "--relative", "--format=", "--reverse", "-n", strconv.Itoa(gitRenameHistory)},
+		{"diff", "-M", "--diff-filter=R", "--name-status", "--relative", "HEAD"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(out))
+		for scanner.Scan() {
+			// R096	old/path.go	new/path.go
+			fields := strings.Split(scanner.Text(), "\t")
+			if len(fields) == 3 && strings.HasPrefix(fields[0], "R") {
+				record(filepath.ToSlash(fields[1]), filepath.ToSlash(fields[2]))
+			}
+		}
+	}
+	return renames
+}
@@ -0,0 +1,121 @@
+package workflow
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+// comment returns a fresh Go comment whose ID is derived from file, symbol and text
+func comment(file, symbol, text string) *domain.Comment {
+	return &domain.Comment{
+		ID:         fmt.Sprintf("%s|%s|%s", file, symbol, text),
+		File:       file,
+		Symbol:     symbol,
+		Language:   "go",
+		SourceText: text,
+	}
+}
+
+func anchored(id, file, symbol, text string) orphan {
+	return orphan{id: id, text: text, anchor: domain.Anchor{File: file, Symbol: symbol}, anchored: true}
+}
+
+func TestReanchor_ExactTextWinsOverNearbyEdit(t *testing.T) {
+	orphans := []orphan{anchored("old", "billing/ledger.go", "billing.Balance", "// Balance sums the ledger entries")}
+	fresh := []*domain.Comment{
+		// Same file and symbol, edited text
+		comment("billing/ledger.go", "billing.Balance", "// Balance sums the ledger entry"),
+		// Moved to another package, unchanged text
+		comment("accounts/ledger.go", "accounts.Balance", "// Balance sums the ledger entries"),
+	}
+
+	migrations := reanchor(orphans, fresh, nil)
+	require.Len(t, migrations, 1)
+	assert.Equal(t, fresh[1].ID, migrations[0].NewID)
+	assert.False(t, migrations[0].TextChanged())
+}
+
+func TestReanchor_FuzzyWithinFileAndRenames(t *testing.T) {
+	orphans := []orphan{
+		anchored("edited", "a.go", "pkg.Run", "// Run starts the worker pool"),
+		anchored("renamed", "old/b.go", "pkg.Stop", "// Stop drains the worker pool"),
+	}
+	fresh := []*domain.Comment{
+		comment("a.go", "pkg.Run", "// Run starts the worker pools"),
+		comment("new/b.go", "pkg.Stop", "// Stop drains the worker pools"),
+	}
+
+	migrations := reanchor(orphans, fresh, map[string]string{"old/b.go": "new/b.go"})
+	require.Len(t, migrations, 2)
+	assert.Equal(t, "edited", migrations[0].OldID)
+	assert.True(t, migrations[0].TextChanged())
+	assert.Equal(t, "renamed", migrations[1].OldID)
+	assert.True(t, migrations[1].Renamed)
+}
+
+func TestReanchor_FuzzyIgnoresUnrelatedFiles(t *testing.T) {
+	orphans := []orphan{anchored("old", "a.go", "pkg.Run", "// Run starts the worker pool")}
+	fresh := []*domain.Comment{comment("b.go", "other.Start", "// Run starts the worker pools")}
+
+	assert.Empty(t, reanchor(orphans, fresh, nil))
+}
+
+func TestReanchor_LegacyEntries(t *testing.T) {
+	orphans := []orphan{
+		{id: "same", text: "// Close releases the file handle"},
+		{id: "edited", text: "// Flush writes the buffered data to disk"},
+		{id: "rewritten", text: "// Open opens the file"},
+	}
+	fresh := []*domain.Comment{
+		comment("a.go", "pkg.Close", "// Close releases the file handle"),
+		comment("a.go", "pkg.Flush", "// Flush writes the buffered data to disk."),
+		comment("a.go", "pkg.Open", "// Open acquires a new descriptor"),
+	}
+
+	migrations := reanchor(orphans, fresh, nil)
+	require.Len(t, migrations, 2)
+	byOld := map[string]string{}
+	for _, m := range migrations {
+		byOld[m.OldID] = m.NewID
+	}
+	assert.Equal(t, map[string]string{"same": fresh[0].ID, "edited": fresh[1].ID}, byOld)
+}
+
+func TestReanchor_PackageRename(t *testing.T) {
+	// Every comment of a large package gets a new ID when the package is renamed
+	const n = 4000
+	orphans := make([]orphan, n)
+	fresh := make([]*domain.Comment, n)
+	for i := range n {
+		text := fmt.Sprintf("// Handler%d validates the request and writes the response for route %d", i, i)
+		orphans[i] = anchored(fmt.Sprintf("old%d", i), fmt.Sprintf("legacy/h%d.go", i/10), fmt.Sprintf("legacy.Handler%d", i), text)
+		fresh[i] = comment(fmt.Sprintf("api/h%d.go", i/10), fmt.Sprintf("api.Handler%d", i), text)
+	}
+
+	migrations := reanchor(orphans, fresh, nil)
+	require.Len(t, migrations, n)
+	byOld := make(map[string]string, n)
+	for _, m := range migrations {
+		byOld[m.OldID] = m.NewID
+	}
+	for i := range n {
+		assert.Equal(t, fresh[i].ID, byOld[orphans[i].id])
+	}
+}
+
+func TestSymbolName(t *testing.T) {
+	for symbol, name := range map[string]string{
+		"billing.Ledger.Balance": "Balance",
+		"Foo::bar":               "bar",
+		"Widget#render":          "render",
+		"main":                   "main",
+		"":                       "",
+	} {
+		assert.Equal(t, name, symbolName(symbol), symbol)
+	}
+}
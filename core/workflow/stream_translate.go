@@ -6,10 +6,13 @@ import (
 
 	"github.com/studyzy/codei18n/adapters/translator"
 	"github.com/studyzy/codei18n/core/config"
+	"github.com/studyzy/codei18n/core/doccomment"
 )
 
-// TranslateText translates a single text string with options
-func TranslateText(cfg *config.Config, opts TranslateOptions, text string) (string, error) {
+// TranslateText translates a single text string with options. language is the programming
+// language of the comment as reported by its adapter, which selects the doc markup to
+// protect; "" protects the markup shared by all languages only.
+func TranslateText(cfg *config.Config, opts TranslateOptions, language, text string) (string, error) {
 	// Apply overrides
 	if opts.Provider != "" {
 		cfg.TranslationProvider = opts.Provider
@@ -27,7 +30,15 @@ func TranslateText(cfg *config.Config, opts TranslateOptions, text string) (stri
 		return "", fmt.Errorf("初始化翻译引擎失败: %w", err)
 	}
 
-	// Translate
+	// Translate the prose only; doc tags, links and code are re-inserted verbatim
 	// Default direction: Source -> Local
-	return trans.Translate(context.Background(), text, cfg.SourceLanguage, cfg.LocalLanguage)
+	doc := doccomment.ParseLanguage(language, text)
+	if !doc.HasProse() {
+		return text, nil
+	}
+	translated, err := trans.Translate(context.Background(), doc.Masked(), cfg.SourceLanguage, cfg.LocalLanguage)
+	if err != nil {
+		return "", err
+	}
+	return doc.Restore(translated)
 }
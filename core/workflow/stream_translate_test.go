@@ -0,0 +1,38 @@
+package workflow
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/config"
+)
+
+func TestTranslateText_ProtectsLanguageMarkup(t *testing.T) {
+	var prompt string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		prompt = req.Messages[len(req.Messages)-1].Content
+		json.NewEncoder(w).Encode(map[string]any{"message": map[string]string{"role": "assistant", "content": "/// 返回 ⟦1⟧。"}})
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.TranslationProvider = "ollama"
+	cfg.TranslationConfig = map[string]string{"endpoint": server.URL}
+
+	translated, err := TranslateText(cfg, TranslateOptions{}, "csharp", `/// Returns the <see cref="Balance"/>.`)
+	require.NoError(t, err)
+	assert.Equal(t, `/// 返回 <see cref="Balance"/>。`, translated)
+	assert.Contains(t, prompt, "/// Returns the ⟦1⟧.")
+	assert.NotContains(t, prompt, "<see cref")
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 	"sync"
 	"time"
 
@@ -12,7 +11,9 @@ import (
 
 	"github.com/studyzy/codei18n/adapters/translator"
 	"github.com/studyzy/codei18n/core/config"
+	"github.com/studyzy/codei18n/core/doccomment"
 	"github.com/studyzy/codei18n/core/mapping"
+	"github.com/studyzy/codei18n/core/utils"
 	"github.com/studyzy/codei18n/internal/log"
 )
 
@@ -54,13 +55,14 @@ func Translate(cfg *config.Config, opts TranslateOptions) (*TranslateResult, err
 	}
 
 	// 3. Load Mapping
-	storePath := filepath.Join(".codei18n", "mappings.json")
-	store := mapping.NewStore(storePath)
+	store, err := mapping.ForBackend(cfg.MappingStorage)
+	if err != nil {
+		return nil, err
+	}
 	if err := store.Load(); err != nil {
 		return nil, fmt.Errorf("加载映射文件失败: %w", err)
 	}
-
-	m := store.GetMapping()
+	defer store.Close()
 
 	// 4. Identify missing translations
 	type task struct {
@@ -68,10 +70,17 @@ func Translate(cfg *config.Config, opts TranslateOptions) (*TranslateResult, err
 		text     string
 		fromLang string
 		toLang   string
+		// language selects the doc markup to protect, "" for entries without one
+		language string
 	}
 	var tasks []task
 
-	for id, translations := range m.Comments {
+	err = store.Iterate(func(id string, translations map[string]string) bool {
+		// Mappings written before directives were classified may still contain them
+		if isDirectiveEntry(translations) {
+			return true
+		}
+
 		// Case 1: EN exists, ZH missing -> Translate EN to ZH
 		if enText, hasEn := translations[cfg.SourceLanguage]; hasEn && enText != "" {
 			if zhText, hasZh := translations[cfg.LocalLanguage]; !hasZh || zhText == "" {
@@ -95,10 +104,42 @@ func Translate(cfg *config.Config, opts TranslateOptions) (*TranslateResult, err
 				})
 			}
 		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("读取映射失败: %w", err)
 	}
 
+	for i := range tasks {
+		anchor, _ := store.GetAnchor(tasks[i].id)
+		tasks[i].language = anchor.Language
+	}
+
+	// Comments made only of doc markup and code have nothing to translate
+	var pending, verbatim []task
+	for _, t := range tasks {
+		if doccomment.ParseLanguage(t.language, t.text).HasProse() {
+			pending = append(pending, t)
+		} else {
+			verbatim = append(verbatim, t)
+		}
+	}
+	if len(verbatim) > 0 {
+		err := store.Transaction(func() error {
+			for _, t := range verbatim {
+				store.Set(t.id, t.toLang, t.text)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("保存映射文件失败: %w", err)
+		}
+	}
+	tasks = pending
+	copied := len(verbatim)
+
 	if len(tasks) == 0 {
-		return &TranslateResult{TotalTasks: 0}, nil
+		return &TranslateResult{SuccessCount: copied, TotalTasks: copied}, nil
 	}
 
 	log.Info("发现 %d 条待翻译注释，开始批量翻译 (BatchSize=%d, Concurrency=%d)...", len(tasks), cfg.BatchSize, opts.Concurrency)
@@ -138,10 +179,13 @@ func Translate(cfg *config.Config, opts TranslateOptions) (*TranslateResult, err
 			defer wg.Done()
 			defer func() { <-sem }() // Release token
 
-			// Prepare batch input
+			// Prepare batch input: only the prose goes to the translator, doc tags,
+			// links and code are masked and re-inserted afterwards
+			docs := make([]*doccomment.Doc, len(currentBatch))
 			texts := make([]string, len(currentBatch))
 			for i, t := range currentBatch {
-				texts[i] = t.text
+				docs[i] = doccomment.ParseLanguage(t.language, t.text)
+				texts[i] = docs[i].Masked()
 			}
 
 			// Group by direction
@@ -166,7 +210,7 @@ func Translate(cfg *config.Config, opts TranslateOptions) (*TranslateResult, err
 				// Mixed batch, fallback to sequential loop manually here
 				results = make([]string, len(currentBatch))
 				for i, t := range currentBatch {
-					res, e := trans.Translate(context.Background(), t.text, t.fromLang, t.toLang)
+					res, e := trans.Translate(context.Background(), texts[i], t.fromLang, t.toLang)
 					if e != nil {
 						err = e // Capture last error
 						break
@@ -180,16 +224,26 @@ func Translate(cfg *config.Config, opts TranslateOptions) (*TranslateResult, err
 			if err != nil {
 				failCount += len(currentBatch)
 			} else {
-				// Save results
-				for i, res := range results {
-					t := currentBatch[i]
-					store.Set(t.id, t.toLang, res)
-					successCount++
-				}
-				// Save progress immediately
-				if err := store.Save(); err != nil {
+				// Save results, and the progress immediately
+				saved := 0
+				err := store.Transaction(func() error {
+					for i, res := range results {
+						t := currentBatch[i]
+						restored, err := docs[i].Restore(res)
+						if err != nil {
+							log.Warn("译文破坏了受保护的文档标记，已丢弃 (ID=%s): %v", t.id, err)
+							failCount++
+							continue
+						}
+						store.Set(t.id, t.toLang, restored)
+						saved++
+					}
+					return nil
+				})
+				if err != nil {
 					log.Warn("保存进度失败: %v", err)
 				}
+				successCount += saved
 			}
 
 			countMu.Unlock()
@@ -207,8 +261,18 @@ func Translate(cfg *config.Config, opts TranslateOptions) (*TranslateResult, err
 	}
 
 	return &TranslateResult{
-		SuccessCount: successCount,
+		SuccessCount: successCount + copied,
 		FailCount:    failCount,
-		TotalTasks:   len(tasks),
+		TotalTasks:   len(tasks) + copied,
 	}, nil
 }
+
+// isDirectiveEntry reports whether any text of a mapping entry is a tool directive.
+func isDirectiveEntry(translations map[string]string) bool {
+	for _, text := range translations {
+		if utils.IsDirective(text) {
+			return true
+		}
+	}
+	return false
+}
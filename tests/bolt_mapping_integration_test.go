@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const boltSource = `package billing
+
+// Balance sums every entry of the ledger.
+func Balance() int {
+	return 0
+}
+
+// Reset clears the ledger.
+func Reset() {}
+`
+
+func TestBoltMappingStorage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	tempDir := t.TempDir()
+	srcFile := CreateFile(t, tempDir, "ledger.go", boltSource)
+
+	RunCLI(t, tempDir, "init")
+	out := RunCLI(t, tempDir, "map", "migrate", "--to", "bolt")
+	assert.Contains(t, out, "已将 2 条映射从 file 迁移到 bolt")
+	assert.FileExists(t, filepath.Join(tempDir, ".codei18n", "mappings.db"))
+	assert.NoFileExists(t, filepath.Join(tempDir, ".codei18n", "mappings.json"))
+
+	RunCLI(t, tempDir, "translate", "--provider", "mock")
+
+	// New comments are added to the database incrementally
+	require.NoError(t, os.WriteFile(srcFile, []byte(boltSource+"\n// Close releases the ledger.\nfunc Close() {}\n"), 0644))
+	out = RunCLI(t, tempDir, "map", "update")
+	assert.Contains(t, out, "新增 1 条映射")
+	RunCLI(t, tempDir, "translate", "--provider", "mock")
+
+	out = RunCLI(t, tempDir, "scan", "--file", "ledger.go", "--with-translations", "--format", "json")
+	assert.Equal(t, 3, strings.Count(out, `"localizedText": "[MOCK en-`))
+
+	RunCLI(t, tempDir, "convert", "--to", "zh-CN", "--dir", ".")
+	converted, err := os.ReadFile(srcFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(converted), "// [MOCK en->zh-CN] // Close releases the ledger.")
+
+	RunCLI(t, tempDir, "convert", "--to", "en", "--dir", ".")
+	restored, err := os.ReadFile(srcFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(restored), "// Close releases the ledger.\n")
+	assert.NotContains(t, string(restored), "MOCK")
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// convertToLocal creates file in dir, runs the init / translate / convert cycle on it and
+// returns its new content
+func convertToLocal(t *testing.T, dir, file, content string) string {
+	t.Helper()
+	path := CreateFile(t, dir, file, content)
+	RunCLI(t, dir, "init")
+	RunCLI(t, dir, "translate", "--provider", "mock")
+	RunCLI(t, dir, "convert", "--to", "zh-CN", "--file", file)
+	converted, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(converted)
+}
+
+const doxygenHashSource = `/**
+ * @brief #1 priority handler
+ */
+void handle();
+`
+
+func TestConvertLeadingHashOutsideHashLanguages(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	converted := convertToLocal(t, t.TempDir(), "handler.hpp", doxygenHashSource)
+	assert.Contains(t, converted, "@brief [MOCK en->zh-CN] #1 priority handler\n")
+	assert.NotContains(t, converted, "# [MOCK")
+}
+
+const xmlDocQuoteSource = `namespace Shop
+{
+    public class Catalog
+    {
+        /// <summary>"Quoted" value is returned</summary>
+        public string Name() { return ""; }
+    }
+}
+`
+
+func TestConvertLeadingQuoteOutsideStringLiteralLanguages(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	converted := convertToLocal(t, t.TempDir(), "Catalog.cs", xmlDocQuoteSource)
+	assert.Contains(t, converted, `/// <summary>[MOCK en->zh-CN] "Quoted" value is returned</summary>`)
+}
+
+const doxygenDashSource = `/**
+ * @brief --verbose enables logging
+ */
+void configure();
+
+/**
+ * @brief 'raw' mode skips escaping
+ */
+void escape();
+`
+
+func TestConvertLeadingDashOrQuoteOutsideSQLAndLua(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	converted := convertToLocal(t, t.TempDir(), "options.hpp", doxygenDashSource)
+	assert.Contains(t, converted, "@brief [MOCK en->zh-CN] --verbose enables logging\n")
+	assert.Contains(t, converted, "@brief [MOCK en->zh-CN] 'raw' mode skips escaping\n")
+}
+
+const rubyBlockSource = `class Invoice
+=begin
+Computes the total
+=end
+  def total; end
+end
+`
+
+func TestConvertRubyBlockKeepsSingleTerminator(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	converted := convertToLocal(t, t.TempDir(), "invoice.rb", rubyBlockSource)
+	assert.Contains(t, converted, "[MOCK en->zh-CN] =begin\nComputes the total\n=end\n  def total; end\n")
+	assert.Equal(t, 1, strings.Count(converted, "\n=end"), "an =end inside the translation would close the block early")
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+const concurrentSource = `package queue
+
+// Push appends an item to the queue.
+func Push() {}
+
+// Pop removes the oldest item.
+func Pop() {}
+`
+
+// TestConcurrentMappingWrites runs translate and map update side by side, as the IDE plugin
+// and the Git hooks do, and checks that no process loses the entries another one saved
+func TestConcurrentMappingWrites(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+	srcFile := CreateFile(t, tempDir, "queue.go", concurrentSource)
+
+	RunCLI(t, tempDir, "init")
+	// Two comments the mapping does not know yet
+	require.NoError(t, os.WriteFile(srcFile, []byte(concurrentSource+"\n// Len counts the items.\nfunc Len() int { return 0 }\n\n// Clear drops every item.\nfunc Clear() {}\n"), 0644))
+
+	var wg sync.WaitGroup
+	for range 4 {
+		for _, args := range [][]string{{"translate", "--provider", "mock"}, {"map", "update"}} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				cmd := exec.Command(bin, args...)
+				cmd.Dir = tempDir
+				out, err := cmd.CombinedOutput()
+				assert.NoError(t, err, "%v: %s", args, string(out))
+			}()
+		}
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(filepath.Join(tempDir, ".codei18n", "mappings.json"))
+	require.NoError(t, err)
+	var m domain.Mapping
+	require.NoError(t, json.Unmarshal(data, &m), "the mapping must never be left half written")
+	assert.Len(t, m.Comments, 4, "entries added by map update survive the translate saves")
+
+	translated := 0
+	for _, translations := range m.Comments {
+		if strings.HasPrefix(translations["zh-CN"], "[MOCK en->zh-CN]") {
+			translated++
+		}
+	}
+	assert.GreaterOrEqual(t, translated, 2, "translations survive the map update saves")
+
+	entries, err := os.ReadDir(filepath.Join(tempDir, ".codei18n"))
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.False(t, strings.HasSuffix(e.Name(), ".tmp"), "temporary file %s left behind", e.Name())
+	}
+}
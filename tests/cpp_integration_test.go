@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCppScan(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	CreateFile(t, tempDir, "server.hpp", LoadFixture(t, "sample.hpp"))
+
+	cmd := exec.Command(bin, "scan", "--file", "server.hpp", "--format", "json")
+	cmd.Dir = tempDir
+	output, err := cmd.Output()
+	require.NoError(t, err, "C++ scan failed: %s", string(output))
+
+	var result struct {
+		Comments []map[string]interface{} `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal(output, &result))
+	require.Len(t, result.Comments, 4)
+
+	symbols := make(map[string]string)
+	for _, c := range result.Comments {
+		AssertValidComment(t, c)
+		assert.Equal(t, "cpp", c["language"])
+		symbols[c["sourceText"].(string)] = c["symbol"].(string)
+	}
+
+	assert.Equal(t, "net::Server", symbols["Network server."])
+	assert.Equal(t, "net::Server", symbols["Port to listen on"])
+	assert.Equal(t, "net::Server::close", symbols["// Close all connections"])
+	assert.Equal(t, "net::Server::port", symbols["Listening port"])
+}
+
+func TestCppConvertKeepsDoxygenCommands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	sampleFile := CreateFile(t, tempDir, "server.hpp", LoadFixture(t, "sample.hpp"))
+
+	for _, args := range [][]string{
+		{"init"},
+		{"map", "update"},
+		{"translate", "--provider", "mock"},
+		{"convert", "--to", "zh-CN", "--file", "server.hpp"},
+	} {
+		cmd := exec.Command(bin, args...)
+		cmd.Dir = tempDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%v: %s", args, string(out))
+	}
+
+	content, err := os.ReadFile(sampleFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), " * @brief [MOCK en->zh-CN] Network server.\n")
+	assert.Contains(t, string(content), " * @param port [MOCK en->zh-CN] Port to listen on\n")
+	assert.Contains(t, string(content), "int port; ///< [MOCK en->zh-CN] Listening port\n")
+}
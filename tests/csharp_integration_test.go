@@ -0,0 +1,39 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSharpConvertKeepsXMLTags(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	sampleFile := CreateFile(t, tempDir, "Account.cs", LoadFixture(t, "Sample.cs"))
+
+	for _, args := range [][]string{
+		{"init"},
+		{"map", "update"},
+		{"translate", "--provider", "mock"},
+		{"convert", "--to", "zh-CN", "--file", "Account.cs"},
+	} {
+		cmd := exec.Command(bin, args...)
+		cmd.Dir = tempDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%v: %s", args, string(out))
+	}
+
+	content, err := os.ReadFile(sampleFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "// [MOCK en->zh-CN] // Account entity")
+	assert.Contains(t, string(content), `/// [MOCK en->zh-CN] Adds money to the <see cref="Account"/>.`)
+	assert.Contains(t, string(content), `/// <param name="amount">[MOCK en->zh-CN] Amount to add</param>`)
+	assert.Contains(t, string(content), "/// <summary>\n")
+}
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTerraformLanguage writes a query-driven HCL language definition into .codei18n/languages
+func setupTerraformLanguage(t *testing.T, dir string) {
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".codei18n", "languages"), 0755))
+	CreateFile(t, dir, ".codei18n/languages/terraform.json", `{
+  "grammar": "hcl",
+  "extensions": [".tf"],
+  "symbols": [
+    {"node": "block", "nameChildren": ["identifier", "string_lit"]},
+    {"node": "attribute", "nameChildren": ["identifier"]}
+  ],
+  "markers": {"line": ["#", "//"], "block": [{"start": "/*", "end": "*/"}]}
+}`)
+	CreateFile(t, dir, ".codei18n/languages/terraform.scm", "(comment) @comment\n")
+	CreateFile(t, dir, "main.tf", LoadFixture(t, "main.tf"))
+}
+
+func TestDeclarativeLanguageScan(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+	setupTerraformLanguage(t, tempDir)
+
+	cmd := exec.Command(bin, "scan", "--dir", ".", "--format", "json")
+	cmd.Dir = tempDir
+	output, err := cmd.Output()
+	require.NoError(t, err, "scan failed: %s", string(output))
+
+	var comments []map[string]interface{}
+	require.NoError(t, json.Unmarshal(output, &comments))
+	require.Len(t, comments, 3)
+
+	symbols := make(map[string]string)
+	for _, c := range comments {
+		AssertValidComment(t, c)
+		assert.Equal(t, "terraform", c["language"])
+		symbols[c["sourceText"].(string)] = c["symbol"].(string)
+	}
+	assert.Equal(t, "resource.aws_s3_bucket.logs", symbols["# Log bucket"])
+	assert.Equal(t, "resource.aws_s3_bucket.logs.bucket", symbols["// Globally unique name"])
+	assert.Equal(t, "resource.aws_s3_bucket.logs.force_destroy", symbols["/* Allow cleanup */"])
+}
+
+func TestDeclarativeLanguageConvertApply(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+	setupTerraformLanguage(t, tempDir)
+
+	for _, args := range [][]string{
+		{"map", "update"},
+		{"translate", "--provider", "mock"},
+		{"convert", "--to", "zh-CN", "--dir", "."},
+	} {
+		cmd := exec.Command(bin, args...)
+		cmd.Dir = tempDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%v: %s", args, string(out))
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "main.tf"))
+	require.NoError(t, err)
+	tf := string(content)
+	assert.Contains(t, tf, "# [MOCK en->zh-CN] # Log bucket\n")
+	assert.Contains(t, tf, "  // [MOCK en->zh-CN] // Globally unique name\n")
+	assert.Contains(t, tf, `force_destroy = true /* [MOCK en->zh-CN] /* Allow cleanup  */`)
+}
@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const directiveGo = `//go:build !windows
+
+package tool
+
+//go:generate stringer -type=Mode
+// Mode selects output.
+type Mode int
+`
+
+const directiveTS = `// eslint-disable-next-line no-console
+console.log("hi"); // prints a greeting
+`
+
+func TestDirectivesAreNeverTranslated(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	CreateFile(t, tempDir, "tool.go", directiveGo)
+	CreateFile(t, tempDir, "app.ts", directiveTS)
+
+	for _, args := range [][]string{
+		{"init"},
+		{"map", "update"},
+		{"translate", "--provider", "mock"},
+		{"convert", "--to", "zh-CN", "--dir", "."},
+	} {
+		cmd := exec.Command(bin, args...)
+		cmd.Dir = tempDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%v: %s", args, string(out))
+	}
+
+	raw, err := os.ReadFile(filepath.Join(tempDir, ".codei18n", "mappings.json"))
+	require.NoError(t, err)
+	var m struct {
+		Comments map[string]map[string]string `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &m))
+	for _, translations := range m.Comments {
+		for _, text := range translations {
+			assert.False(t, strings.Contains(text, "go:") || strings.Contains(text, "eslint"), "directive in mapping: %s", text)
+		}
+	}
+
+	goSrc, err := os.ReadFile(filepath.Join(tempDir, "tool.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(goSrc), "//go:build !windows\n")
+	assert.Contains(t, string(goSrc), "//go:generate stringer -type=Mode\n")
+	assert.Contains(t, string(goSrc), "// [MOCK en->zh-CN] // Mode selects output.\n")
+
+	tsSrc, err := os.ReadFile(filepath.Join(tempDir, "app.ts"))
+	require.NoError(t, err)
+	assert.Contains(t, string(tsSrc), "// eslint-disable-next-line no-console\n")
+	assert.Contains(t, string(tsSrc), "// [MOCK en->zh-CN] // prints a greeting\n")
+}
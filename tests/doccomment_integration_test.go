@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const docCommentTS = `/**
+ * Adds two numbers, see {@link Calculator#add} and https://example.com/math.
+ * @param a first operand
+ * @returns {number} the sum
+ */
+export function add(a: number, b: number): number {
+  return a + b;
+}
+
+// ` + "`add(1, 2)`" + `
+export const three = add(1, 2);
+`
+
+func TestDocCommentMarkupSurvivesTranslation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+	CreateFile(t, tempDir, "math.ts", docCommentTS)
+
+	for _, args := range [][]string{
+		{"init"},
+		{"map", "update"},
+		{"translate", "--provider", "mock"},
+	} {
+		cmd := exec.Command(bin, args...)
+		cmd.Dir = tempDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%v: %s", args, string(out))
+	}
+
+	raw, err := os.ReadFile(filepath.Join(tempDir, ".codei18n", "mappings.json"))
+	require.NoError(t, err)
+	var m struct {
+		Comments map[string]map[string]string `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &m))
+
+	var translated []string
+	for _, translations := range m.Comments {
+		if text, ok := translations["zh-CN"]; ok {
+			translated = append(translated, text)
+		}
+	}
+	require.Len(t, translated, 2)
+
+	all := strings.Join(translated, "\n")
+	assert.NotContains(t, all, "⟦", "placeholders leaked into the mapping")
+	for _, markup := range []string{"{@link Calculator#add}", "https://example.com/math", "@param a", "@returns {number}"} {
+		assert.Contains(t, all, markup)
+	}
+	// A comment that is only code is copied without asking the translator
+	assert.Contains(t, translated, "// `add(1, 2)`")
+}
@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildSidecar compiles the fake external adapter in testdata/sidecar
+func buildSidecar(t *testing.T) string {
+	name := "sidecar"
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	path := filepath.Join(t.TempDir(), name)
+	out, err := exec.Command("go", "build", "-o", path, "./testdata/sidecar").CombinedOutput()
+	require.NoError(t, err, "build sidecar: %s", string(out))
+	return path
+}
+
+func TestExternalAdapterScanAndConvert(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	sidecar := buildSidecar(t)
+	tempDir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, ".codei18n"), 0755))
+	commandJSON, err := json.Marshal(sidecar)
+	require.NoError(t, err)
+	CreateFile(t, tempDir, ".codei18n/config.json", fmt.Sprintf(`{
+  "sourceLanguage": "en",
+  "localLanguage": "zh-CN",
+  "externalAdapters": [{"command": %s, "language": "lisp", "extensions": [".lisp"]}]
+}`, commandJSON))
+	CreateFile(t, tempDir, "math.lisp", LoadFixture(t, "sample.lisp"))
+
+	cmd := exec.Command(bin, "scan", "--dir", ".", "--format", "json")
+	cmd.Dir = tempDir
+	output, err := cmd.Output()
+	require.NoError(t, err, "scan failed: %s", string(output))
+
+	var comments []map[string]interface{}
+	require.NoError(t, json.Unmarshal(output, &comments))
+	require.Len(t, comments, 1)
+	AssertValidComment(t, comments[0])
+	assert.Equal(t, "lisp", comments[0]["language"])
+	assert.Equal(t, "add", comments[0]["symbol"])
+
+	for _, args := range [][]string{
+		{"map", "update"},
+		{"translate", "--provider", "mock"},
+		{"convert", "--to", "zh-CN", "--dir", "."},
+	} {
+		cmd := exec.Command(bin, args...)
+		cmd.Dir = tempDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%v: %s", args, string(out))
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "math.lisp"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), ";; [MOCK en->zh-CN] ;; Adds two numbers\n(defun add")
+}
+
+func TestExternalAdapterRunsOnlyWhenParsing(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	sidecar := buildSidecar(t)
+	tempDir := t.TempDir()
+	logPath := filepath.Join(t.TempDir(), "calls.log")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, ".codei18n"), 0755))
+	commandJSON, err := json.Marshal(sidecar)
+	require.NoError(t, err)
+	CreateFile(t, tempDir, ".codei18n/config.json", fmt.Sprintf(`{
+  "sourceLanguage": "en",
+  "localLanguage": "zh-CN",
+  "externalAdapters": [
+    {"command": %s, "language": "lisp", "extensions": [".lisp"]},
+    {"command": %s}
+  ]
+}`, commandJSON, commandJSON))
+	CreateFile(t, tempDir, "math.lisp", LoadFixture(t, "sample.lisp"))
+
+	command := func(args ...string) *exec.Cmd {
+		cmd := exec.Command(bin, args...)
+		cmd.Dir = tempDir
+		cmd.Env = append(os.Environ(), "SIDECAR_LOG="+logPath)
+		return cmd
+	}
+
+	// The lookup fails as the mapping is empty; it must not run the adapter either way
+	out, _ := command("map", "get", "unknown").CombinedOutput()
+	assert.Contains(t, string(out), "language and extensions are required", "an incomplete adapter is reported")
+	_, err = os.Stat(logPath)
+	assert.True(t, os.IsNotExist(err), "registering the adapter must not run it")
+
+	out, err = command("scan", "--dir", ".", "--format", "json").CombinedOutput()
+	require.NoError(t, err, "scan failed: %s", string(out))
+	calls, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Equal(t, "handshake\nparse\n", string(calls))
+}
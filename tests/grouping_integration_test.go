@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const groupedGo = `package pool
+
+// Run starts the worker pool and blocks until
+// every job has been processed or the context
+// is cancelled.
+func Run() {
+	// Drain whatever is left in the queue
+	// before the workers are stopped.
+	stop()
+}
+
+func stop() {}
+`
+
+func TestCommentGroupingConvertRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+	goFile := CreateFile(t, tempDir, "pool.go", groupedGo)
+
+	RunCLI(t, tempDir, "init")
+	configPath := filepath.Join(tempDir, ".codei18n", "config.json")
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	var cfg map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &cfg))
+	cfg["commentGrouping"] = "paragraph"
+	data, err = json.Marshal(cfg)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(configPath, data, 0644))
+
+	// Each paragraph is one comment, so it is translated in one piece
+	cmd := exec.Command(bin, "scan", "--file", "pool.go", "--format", "json")
+	cmd.Dir = tempDir
+	out, err := cmd.Output()
+	require.NoError(t, err)
+	var scanned struct {
+		Comments []map[string]interface{} `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal(out, &scanned))
+	require.Len(t, scanned.Comments, 2)
+	assert.Equal(t, "// Run starts the worker pool and blocks until every job has been processed or the context is cancelled.",
+		scanned.Comments[0]["sourceText"])
+
+	RunCLI(t, tempDir, "map", "update")
+	RunCLI(t, tempDir, "translate", "--provider", "mock")
+	RunCLI(t, tempDir, "convert", "--to", "zh-CN", "--dir", ".")
+
+	content, err := os.ReadFile(goFile)
+	require.NoError(t, err)
+	translated := string(content)
+	assert.Contains(t, translated, "// [MOCK en->zh-CN] // Run starts the worker\n// pool and blocks until every job has been\n// processed or the context is cancelled.\nfunc Run() {")
+	assert.Contains(t, translated, "\n\t// [MOCK en->zh-CN] // Drain whatever\n\t// is left in the queue before the\n\t// workers are stopped.\n\tstop()")
+	for _, line := range strings.Split(translated, "\n") {
+		assert.LessOrEqual(t, len(strings.TrimLeft(line, "\t")), 50, line)
+	}
+
+	RunCLI(t, tempDir, "convert", "--to", "en", "--dir", ".")
+	content, err = os.ReadFile(goFile)
+	require.NoError(t, err)
+	restored := string(content)
+	assert.Contains(t, restored, "// Run starts the worker pool and blocks\n// until every job has been processed or the\n// context is cancelled.\nfunc Run() {")
+	assert.Contains(t, restored, "\t// Drain whatever is left in the\n\t// queue before the workers are\n\t// stopped.\n\tstop()")
+}
@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashCommentScan(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	CreateFile(t, tempDir, "ci.yml", LoadFixture(t, "ci.yml"))
+	CreateFile(t, tempDir, "Makefile", LoadFixture(t, "Makefile.sample"))
+
+	cmd := exec.Command(bin, "scan", "--dir", ".", "--format", "json")
+	cmd.Dir = tempDir
+	output, err := cmd.Output()
+	require.NoError(t, err, "scan failed: %s", string(output))
+
+	var comments []map[string]interface{}
+	require.NoError(t, json.Unmarshal(output, &comments))
+	require.Len(t, comments, 6)
+
+	symbols := make(map[string]string)
+	for _, c := range comments {
+		AssertValidComment(t, c)
+		symbols[c["sourceText"].(string)] = c["language"].(string) + ":" + c["symbol"].(string)
+	}
+
+	assert.Equal(t, "yaml:name", symbols["# Continuous integration pipeline"])
+	assert.Equal(t, "yaml:jobs.test.steps", symbols["# Run the unit tests"])
+	assert.Equal(t, "yaml:jobs.test.steps.run", symbols["# verbose output"])
+	assert.Equal(t, "makefile:GOFLAGS", symbols["# Go compiler flags"])
+	assert.Equal(t, "makefile:test", symbols["# Run all tests"])
+	assert.Equal(t, "makefile:test", symbols["# Race detector is slow on CI"])
+}
+
+func TestHashCommentConvertApply(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	ciFile := CreateFile(t, tempDir, "ci.yml", LoadFixture(t, "ci.yml"))
+	makeFile := CreateFile(t, tempDir, "Makefile", LoadFixture(t, "Makefile.sample"))
+
+	for _, args := range [][]string{
+		{"init"},
+		{"map", "update"},
+		{"translate", "--provider", "mock"},
+		{"convert", "--to", "zh-CN", "--file", "ci.yml"},
+		{"convert", "--to", "zh-CN", "--file", "Makefile"},
+	} {
+		cmd := exec.Command(bin, args...)
+		cmd.Dir = tempDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%v: %s", args, string(out))
+	}
+
+	content, err := os.ReadFile(ciFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "      # [MOCK en->zh-CN] # Run the unit tests\n")
+	assert.Contains(t, string(content), "      - run: make test # [MOCK en->zh-CN] # verbose output\n")
+
+	content, err = os.ReadFile(makeFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "\t# [MOCK en->zh-CN] # Race detector is slow on CI\n\tgo test ./...\n")
+}
@@ -72,6 +72,24 @@ func GetBinaryPath(t *testing.T) string {
 	return binaryPath
 }
 
+// RunCLI runs the test binary with args in dir and returns its combined output,
+// failing the test if the command fails
+func RunCLI(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	return RunCommand(t, dir, GetBinaryPath(t), args...)
+}
+
+// RunCommand runs name with args in dir and returns its combined output,
+// failing the test if the command fails
+func RunCommand(t *testing.T, dir, name string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "%s %v: %s", filepath.Base(name), args, string(out))
+	return string(out)
+}
+
 // CreateFile creates a file with content in the specified directory
 func CreateFile(t *testing.T, dir, name, content string) string {
 	require.NotEmpty(t, content, "CreateFile called with empty content for %s", name)
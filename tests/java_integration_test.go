@@ -41,7 +41,7 @@ public class Calculator {
 		}
 		if c.SourceText == "// Add two numbers" {
 			foundMethod = true
-			assert.Equal(t, "com.example.Calculator#add", c.Symbol)
+			assert.Equal(t, "com.example.Calculator#add(int,int)", c.Symbol)
 			assert.Equal(t, domain.CommentTypeLine, c.Type)
 		}
 	}
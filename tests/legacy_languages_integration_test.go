@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegacyLanguagesScan(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	CreateFile(t, tempDir, "invoice.rb", LoadFixture(t, "sample.rb"))
+	CreateFile(t, tempDir, "User.php", LoadFixture(t, "sample.php"))
+	CreateFile(t, tempDir, "m.lua", LoadFixture(t, "sample.lua"))
+
+	cmd := exec.Command(bin, "scan", "--dir", ".", "--format", "json")
+	cmd.Dir = tempDir
+	output, err := cmd.Output()
+	require.NoError(t, err, "scan failed: %s", string(output))
+
+	var comments []map[string]interface{}
+	require.NoError(t, json.Unmarshal(output, &comments))
+	require.Len(t, comments, 9)
+
+	symbols := make(map[string]string)
+	types := make(map[string]string)
+	for _, c := range comments {
+		AssertValidComment(t, c)
+		symbols[c["sourceText"].(string)] = c["language"].(string) + ":" + c["symbol"].(string)
+		types[c["sourceText"].(string)] = c["type"].(string)
+	}
+
+	assert.Equal(t, "directive", types["# frozen_string_literal: true"])
+	assert.Equal(t, "ruby:Billing::Invoice", symbols["# Monthly invoice"])
+	assert.Equal(t, "ruby:Billing::Invoice#total", symbols["# @return [Integer] total in cents"])
+	assert.Equal(t, `php:App\Models\User::save`, symbols["// Always succeeds"])
+	assert.Equal(t, "lua:M.add", symbols["---@param a number"])
+	assert.Equal(t, "lua:helper", symbols["--[==[ Legacy helper ]==]"])
+}
+
+func TestLegacyLanguagesConvertApply(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"invoice.rb": "sample.rb",
+		"User.php":   "sample.php",
+		"m.lua":      "sample.lua",
+	}
+	for name, fixture := range files {
+		CreateFile(t, tempDir, name, LoadFixture(t, fixture))
+	}
+
+	for _, args := range [][]string{
+		{"init"},
+		{"map", "update"},
+		{"translate", "--provider", "mock"},
+		{"convert", "--to", "zh-CN", "--dir", "."},
+	} {
+		cmd := exec.Command(bin, args...)
+		cmd.Dir = tempDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%v: %s", args, string(out))
+	}
+
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(tempDir, name))
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	ruby := read("invoice.rb")
+	assert.Contains(t, ruby, "# frozen_string_literal: true\n", "magic comments must not be translated")
+	assert.Contains(t, ruby, "  # [MOCK en->zh-CN] # Monthly invoice\n")
+
+	assert.Contains(t, read("User.php"), "} // [MOCK en->zh-CN] // Always succeeds\n")
+
+	lua := read("m.lua")
+	assert.Contains(t, lua, "--- [MOCK en->zh-CN] --- Add two numbers\n")
+	assert.Contains(t, lua, "--[==[ [MOCK en->zh-CN] --[==[ Legacy helper  ]==]\nlocal function helper()")
+}
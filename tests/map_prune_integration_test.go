@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pruneSource = `package shop
+
+// Checkout charges the cart and clears it.
+func Checkout() {}
+
+// Refund returns the money for an order.
+func Refund() {}
+`
+
+type pruneMapping struct {
+	Comments map[string]map[string]string `json:"comments"`
+	Anchors  map[string]struct {
+		File       string `json:"file"`
+		OrphanedAt string `json:"orphanedAt"`
+	} `json:"anchors"`
+	Archive map[string]map[string]string `json:"archive"`
+}
+
+func TestMapPrune(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	tempDir := t.TempDir()
+	srcFile := CreateFile(t, tempDir, "shop.go", pruneSource)
+
+	readMapping := func() pruneMapping {
+		raw, err := os.ReadFile(filepath.Join(tempDir, ".codei18n", "mappings.json"))
+		require.NoError(t, err)
+		var m pruneMapping
+		require.NoError(t, json.Unmarshal(raw, &m))
+		return m
+	}
+
+	RunCLI(t, tempDir, "init")
+	RunCLI(t, tempDir, "translate", "--provider", "mock")
+	require.Len(t, readMapping().Comments, 2)
+
+	// Drop the Refund function
+	withoutRefund := pruneSource[:strings.Index(pruneSource, "// Refund")]
+	require.NoError(t, os.WriteFile(srcFile, []byte(withoutRefund), 0644))
+
+	out := RunCLI(t, tempDir, "map", "prune", "--dry-run")
+	assert.Contains(t, out, "发现 1 条失效映射，删除 1 条")
+	assert.Len(t, readMapping().Comments, 2, "dry run must not write")
+
+	out = RunCLI(t, tempDir, "map", "prune", "--grace", "30d")
+	assert.Contains(t, out, "1 条仍在宽限期内")
+	m := readMapping()
+	require.Len(t, m.Comments, 2)
+	var refundID string
+	for id, anchor := range m.Anchors {
+		if anchor.OrphanedAt != "" {
+			refundID = id
+		}
+	}
+	require.NotEmpty(t, refundID, "the missing comment is timestamped")
+
+	RunCLI(t, tempDir, "map", "prune", "--archive")
+	m = readMapping()
+	assert.Len(t, m.Comments, 1)
+	assert.Equal(t, "[MOCK en->zh-CN] // Refund returns the money for an order.", m.Archive[refundID]["zh-CN"])
+
+	// Bringing the comment back revives its archived translation
+	require.NoError(t, os.WriteFile(srcFile, []byte(pruneSource), 0644))
+	RunCLI(t, tempDir, "map", "update")
+	m = readMapping()
+	assert.Len(t, m.Comments, 2)
+	assert.Empty(t, m.Archive)
+	assert.Equal(t, "[MOCK en->zh-CN] // Refund returns the money for an order.", m.Comments[refundID]["zh-CN"])
+	assert.Empty(t, m.Anchors[refundID].OrphanedAt)
+
+	// Without --archive the entry and its anchor are deleted
+	require.NoError(t, os.WriteFile(srcFile, []byte(withoutRefund), 0644))
+	RunCLI(t, tempDir, "map", "prune")
+	m = readMapping()
+	assert.Len(t, m.Comments, 1)
+	assert.NotContains(t, m.Anchors, refundID)
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMobileLanguagesScan(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	CreateFile(t, tempDir, "Home.swift", LoadFixture(t, "Home.swift"))
+	CreateFile(t, tempDir, "counter.dart", LoadFixture(t, "counter.dart"))
+
+	cmd := exec.Command(bin, "scan", "--dir", ".", "--format", "json")
+	cmd.Dir = tempDir
+	output, err := cmd.Output()
+	require.NoError(t, err, "scan failed: %s", string(output))
+
+	var comments []map[string]interface{}
+	require.NoError(t, json.Unmarshal(output, &comments))
+	require.Len(t, comments, 9)
+
+	symbols := make(map[string]string)
+	for _, c := range comments {
+		AssertValidComment(t, c)
+		symbols[c["sourceText"].(string)] = c["language"].(string) + ":" + c["symbol"].(string)
+	}
+
+	assert.Equal(t, "swift:HomeViewController", symbols["/// Home screen controller"])
+	assert.Equal(t, "swift:HomeViewController.viewDidLoad", symbols["Lifecycle"])
+	assert.Equal(t, "swift:HomeViewController.numberOfRows", symbols["/// - Returns: the row count"])
+	assert.Equal(t, "dart:Counter.increment", symbols["clamp the value"])
+	assert.Equal(t, "dart:Counter.reset", symbols["// Resets to zero"])
+}
+
+func TestMobileLanguagesConvertApply(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	CreateFile(t, tempDir, "Home.swift", LoadFixture(t, "Home.swift"))
+	CreateFile(t, tempDir, "counter.dart", LoadFixture(t, "counter.dart"))
+
+	for _, args := range [][]string{
+		{"init"},
+		{"map", "update"},
+		{"translate", "--provider", "mock"},
+		{"convert", "--to", "zh-CN", "--dir", "."},
+	} {
+		cmd := exec.Command(bin, args...)
+		cmd.Dir = tempDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%v: %s", args, string(out))
+	}
+
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(tempDir, name))
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	swift := read("Home.swift")
+	assert.Contains(t, swift, "    // MARK: - [MOCK en->zh-CN] Lifecycle\n", "the MARK prefix must be kept")
+	assert.Contains(t, swift, "super.viewDidLoad() // TODO: [MOCK en->zh-CN] load data\n")
+	assert.Contains(t, swift, "/// [MOCK en->zh-CN] /// - Returns: the row count\n")
+
+	dart := read("counter.dart")
+	assert.Contains(t, dart, "    // TODO(alice): [MOCK en->zh-CN] clamp the value\n")
+	assert.Contains(t, dart, "  /// [MOCK en->zh-CN] /// Increments the value, see [reset]\n")
+}
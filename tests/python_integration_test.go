@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPythonScan(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	CreateFile(t, tempDir, "account.py", LoadFixture(t, "sample.py"))
+
+	cmd := exec.Command(bin, "scan", "--file", "account.py", "--format", "json")
+	cmd.Dir = tempDir
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "Python scan failed: %s", string(output))
+
+	var result struct {
+		File     string                   `json:"file"`
+		Comments []map[string]interface{} `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal(output, &result))
+	require.Len(t, result.Comments, 5)
+
+	symbols := make(map[string]string)
+	for _, c := range result.Comments {
+		AssertValidComment(t, c)
+		assert.Equal(t, "python", c["language"])
+		symbols[c["sourceText"].(string)] = c["symbol"].(string)
+	}
+
+	assert.Equal(t, "account", symbols[`"""Utilities for account handling."""`])
+	assert.Equal(t, "account", symbols["# Default account balance"])
+	assert.Equal(t, "account.Account", symbols[`"""Bank account model."""`])
+	assert.Equal(t, "account.Account.deposit", symbols[`"""Add money to the account."""`])
+	assert.Equal(t, "account.Account.deposit", symbols["# Update the balance"])
+}
+
+func TestPythonConvertApply(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	sampleFile := CreateFile(t, tempDir, "account.py", LoadFixture(t, "sample.py"))
+
+	for _, args := range [][]string{
+		{"init"},
+		{"map", "update"},
+		{"translate", "--provider", "mock"},
+		{"convert", "--to", "zh-CN", "--file", "account.py"},
+	} {
+		cmd := exec.Command(bin, args...)
+		cmd.Dir = tempDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%v: %s", args, string(out))
+	}
+
+	content, err := os.ReadFile(sampleFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# [MOCK en->zh-CN] # Default account balance")
+	assert.Contains(t, string(content), `"""[MOCK en->zh-CN] Bank account model."""`)
+
+	// The converted file must still be valid input for the adapter
+	cmd := exec.Command(bin, "scan", "--file", "account.py", "--format", "json")
+	cmd.Dir = tempDir
+	out, err := cmd.Output()
+	require.NoError(t, err, string(out))
+
+	var result struct {
+		Comments []map[string]interface{} `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Len(t, result.Comments, 5)
+}
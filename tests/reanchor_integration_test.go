@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reanchorLedger = `package billing
+
+// CalculateBalance sums every entry of the ledger.
+func CalculateBalance() int {
+	return 0
+}
+
+// Reset clears all entries from the ledger.
+func Reset() {}
+`
+
+const reanchorUtil = `package billing
+
+// formatAmount renders an amount with two decimals.
+func formatAmount() string {
+	return ""
+}
+`
+
+func TestMapUpdateReanchorsRenamedAndEditedComments(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	tempDir := t.TempDir()
+
+	readMapping := func() map[string]map[string]string {
+		raw, err := os.ReadFile(filepath.Join(tempDir, ".codei18n", "mappings.json"))
+		require.NoError(t, err)
+		var m struct {
+			Comments map[string]map[string]string `json:"comments"`
+		}
+		require.NoError(t, json.Unmarshal(raw, &m))
+		return m.Comments
+	}
+
+	RunCommand(t, tempDir, "git", "init", "-q")
+	RunCommand(t, tempDir, "git", "config", "user.email", "test@example.com")
+	RunCommand(t, tempDir, "git", "config", "user.name", "test")
+	ledger := CreateFile(t, tempDir, "ledger.go", reanchorLedger)
+	CreateFile(t, tempDir, "util.go", reanchorUtil)
+
+	RunCLI(t, tempDir, "init")
+	RunCLI(t, tempDir, "translate", "--provider", "mock")
+	before := readMapping()
+	require.Len(t, before, 3)
+	RunCommand(t, tempDir, "git", "add", "-A")
+	RunCommand(t, tempDir, "git", "commit", "-q", "-m", "initial")
+
+	// Rename a function, fix a typo in a comment and move a file
+	edited := strings.Replace(reanchorLedger, "func CalculateBalance()", "func Balance()", 1)
+	edited = strings.Replace(edited, "clears all entries from", "clears every entry of", 1)
+	require.NoError(t, os.WriteFile(ledger, []byte(edited), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "format"), 0755))
+	RunCommand(t, tempDir, "git", "mv", "util.go", filepath.Join("format", "util.go"))
+	// A comment that never existed before still needs translation
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "format", "new.go"),
+		[]byte("package billing\n\n// Brand new helper with nothing in common.\nfunc helper() {}\n"), 0644))
+
+	out := RunCLI(t, tempDir, "map", "update")
+	assert.Contains(t, out, "迁移 3 条已有翻译")
+	assert.Contains(t, out, "1 条新注释待翻译")
+	assert.Contains(t, out, "文件重命名")
+	assert.Contains(t, out, "建议复核")
+
+	after := readMapping()
+	require.Len(t, after, 4, "old entries are replaced, not duplicated")
+
+	translations := make(map[string]string)
+	for _, entry := range after {
+		translations[entry["en"]] = entry["zh-CN"]
+	}
+	assert.Equal(t, "[MOCK en->zh-CN] // CalculateBalance sums every entry of the ledger.", translations["// CalculateBalance sums every entry of the ledger."])
+	assert.Equal(t, "[MOCK en->zh-CN] // formatAmount renders an amount with two decimals.", translations["// formatAmount renders an amount with two decimals."])
+	// The edited comment keeps its previous translation for review
+	assert.Equal(t, "[MOCK en->zh-CN] // Reset clears all entries from the ledger.", translations["// Reset clears every entry of the ledger."])
+	assert.Empty(t, translations["// Brand new helper with nothing in common."])
+}
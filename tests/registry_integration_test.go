@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanLanguageOverridesAndShebang(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+	for _, dir := range []string{".codei18n", "lib", "bin"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tempDir, dir), 0755))
+	}
+
+	CreateFile(t, tempDir, ".codei18n/config.json", `{
+  "sourceLanguage": "en",
+  "localLanguage": "zh-CN",
+  "translationProvider": "mock",
+  "languageOverrides": {".inc": "php", "Jenkinsfile": "groovy"}
+}`)
+	CreateFile(t, tempDir, "lib/db.inc", "<?php\n// Connects to the database\nfunction connect() {}\n")
+	CreateFile(t, tempDir, "Jenkinsfile", "// Build pipeline\npipeline {}\n")
+	CreateFile(t, tempDir, "bin/deploy", "#!/usr/bin/env bash\n# Deploys the service\ndeploy() { :; }\n")
+	CreateFile(t, tempDir, "notes.txt", "# not code\n")
+
+	cmd := exec.Command(bin, "scan", "--dir", ".", "--format", "json")
+	cmd.Dir = tempDir
+	output, err := cmd.Output()
+	require.NoError(t, err, "scan failed: %s", string(output))
+
+	var comments []map[string]interface{}
+	require.NoError(t, json.Unmarshal(output, &comments))
+	require.Len(t, comments, 3)
+
+	languages := make(map[string]string)
+	for _, c := range comments {
+		AssertValidComment(t, c)
+		languages[c["sourceText"].(string)] = c["language"].(string)
+	}
+	assert.Equal(t, "php", languages["// Connects to the database"])
+	assert.Equal(t, "groovy", languages["// Build pipeline"])
+	assert.Equal(t, "shell", languages["# Deploys the service"])
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaScan(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	CreateFile(t, tempDir, "billing.proto", LoadFixture(t, "billing.proto"))
+	CreateFile(t, tempDir, "schema.graphql", LoadFixture(t, "schema.graphql"))
+
+	cmd := exec.Command(bin, "scan", "--dir", ".", "--format", "json")
+	cmd.Dir = tempDir
+	output, err := cmd.Output()
+	require.NoError(t, err, "scan failed: %s", string(output))
+
+	var comments []map[string]interface{}
+	require.NoError(t, json.Unmarshal(output, &comments))
+	require.Len(t, comments, 6)
+
+	symbols := make(map[string]string)
+	for _, c := range comments {
+		AssertValidComment(t, c)
+		symbols[c["sourceText"].(string)] = c["symbol"].(string)
+	}
+
+	assert.Equal(t, "acme.billing.v1.Billing", symbols["// Billing operations for customer accounts"])
+	assert.Equal(t, "acme.billing.v1.Billing/Charge", symbols["// Charge a stored payment method"])
+	assert.Equal(t, "acme.billing.v1.ChargeRequest.amount", symbols["// Amount in cents"])
+	assert.Equal(t, "Customer", symbols[`"A registered customer"`])
+	assert.Equal(t, "Customer.name", symbols["\"\"\"\n  Display name shown in the dashboard\n  \"\"\""])
+	assert.Equal(t, "Customer.score", symbols["# Internal ranking, not exposed to clients"])
+}
+
+func TestSchemaConvertApply(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	protoFile := CreateFile(t, tempDir, "billing.proto", LoadFixture(t, "billing.proto"))
+	schemaFile := CreateFile(t, tempDir, "schema.graphql", LoadFixture(t, "schema.graphql"))
+
+	for _, args := range [][]string{
+		{"init"},
+		{"map", "update"},
+		{"translate", "--provider", "mock"},
+		{"convert", "--to", "zh-CN", "--file", "billing.proto"},
+		{"convert", "--to", "zh-CN", "--file", "schema.graphql"},
+	} {
+		cmd := exec.Command(bin, args...)
+		cmd.Dir = tempDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%v: %s", args, string(out))
+	}
+
+	content, err := os.ReadFile(protoFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "int64 amount = 1; // [MOCK en->zh-CN] // Amount in cents\n")
+
+	content, err = os.ReadFile(schemaFile)
+	require.NoError(t, err)
+	// Descriptions stay valid GraphQL strings
+	assert.Contains(t, string(content), "\"[MOCK en->zh-CN] \\\"A registered customer\\\"\"\ntype Customer {")
+	assert.Contains(t, string(content), "# [MOCK en->zh-CN] # Internal ranking, not exposed to clients\n")
+
+	// The converted schema must still scan to the same number of comments
+	cmd := exec.Command(bin, "scan", "--file", "schema.graphql", "--format", "json")
+	cmd.Dir = tempDir
+	out, err := cmd.Output()
+	require.NoError(t, err, string(out))
+
+	var result struct {
+		Comments []map[string]interface{} `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Len(t, result.Comments, 3)
+}
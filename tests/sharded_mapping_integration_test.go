@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const shardedSource = `package billing
+
+// Balance sums every entry of the ledger.
+func Balance() int {
+	return 0
+}
+`
+
+func TestShardedMappingStorage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	tempDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "billing"), 0755))
+	srcFile := CreateFile(t, tempDir, filepath.Join("billing", "ledger.go"), shardedSource)
+
+	RunCLI(t, tempDir, "init")
+	RunCLI(t, tempDir, "translate", "--provider", "mock")
+
+	out := RunCLI(t, tempDir, "map", "migrate", "--to", "sharded")
+	assert.Contains(t, out, "已将 1 条映射从 file 迁移到 sharded")
+	assert.NoFileExists(t, filepath.Join(tempDir, ".codei18n", "mappings.json"))
+
+	cfgRaw, err := os.ReadFile(filepath.Join(tempDir, ".codei18n", "config.json"))
+	require.NoError(t, err)
+	var cfg map[string]interface{}
+	require.NoError(t, json.Unmarshal(cfgRaw, &cfg))
+	assert.Equal(t, "sharded", cfg["mappingStorage"])
+	assert.Equal(t, "zh-CN", cfg["localLanguage"], "other config keys are kept")
+
+	// The shard holds the source text next to its translation
+	raw, err := os.ReadFile(filepath.Join(tempDir, ".codei18n", "mappings", "billing", "ledger.go.json"))
+	require.NoError(t, err)
+	var shard struct {
+		File     string `json:"file"`
+		Comments []struct {
+			Symbol string            `json:"symbol"`
+			Text   map[string]string `json:"text"`
+		} `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &shard))
+	assert.Equal(t, "billing/ledger.go", shard.File)
+	require.Len(t, shard.Comments, 1)
+	assert.Equal(t, "billing.Balance", shard.Comments[0].Symbol)
+	assert.Equal(t, "// Balance sums every entry of the ledger.", shard.Comments[0].Text["en"])
+	assert.Equal(t, "[MOCK en->zh-CN] // Balance sums every entry of the ledger.", shard.Comments[0].Text["zh-CN"])
+
+	// The other commands read the sharded storage
+	RunCLI(t, tempDir, "convert", "--to", "zh-CN", "--dir", ".")
+	converted, err := os.ReadFile(srcFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(converted), "[MOCK en->zh-CN]")
+
+	RunCLI(t, tempDir, "map", "migrate", "--to", "file")
+	assert.FileExists(t, filepath.Join(tempDir, ".codei18n", "mappings.json"))
+	assert.NoDirExists(t, filepath.Join(tempDir, ".codei18n", "mappings"))
+}
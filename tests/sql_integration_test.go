@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLScan(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	CreateFile(t, tempDir, "001_init.sql", LoadFixture(t, "migration.sql"))
+
+	cmd := exec.Command(bin, "scan", "--file", "001_init.sql", "--format", "json")
+	cmd.Dir = tempDir
+	output, err := cmd.Output()
+	require.NoError(t, err, "SQL scan failed: %s", string(output))
+
+	var result struct {
+		Comments []map[string]interface{} `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal(output, &result))
+
+	// COMMENT clauses are not extracted unless enabled in the config
+	require.Len(t, result.Comments, 2)
+	assert.Equal(t, "accounts", result.Comments[0]["symbol"])
+	assert.Equal(t, "accounts.id", result.Comments[1]["symbol"])
+}
+
+func TestSQLConvertCommentClauses(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	sqlFile := CreateFile(t, tempDir, "001_init.sql", LoadFixture(t, "migration.sql"))
+
+	cmd := exec.Command(bin, "init")
+	cmd.Dir = tempDir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	// Enable COMMENT clause translation
+	configPath := filepath.Join(tempDir, ".codei18n", "config.json")
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	var cfg map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &cfg))
+	cfg["sqlCommentClauses"] = true
+	data, err = json.Marshal(cfg)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(configPath, data, 0644))
+
+	for _, args := range [][]string{
+		{"map", "update"},
+		{"translate", "--provider", "mock"},
+		{"convert", "--to", "zh-CN", "--file", "001_init.sql"},
+	} {
+		cmd := exec.Command(bin, args...)
+		cmd.Dir = tempDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%v: %s", args, string(out))
+	}
+
+	content, err := os.ReadFile(sqlFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "-- [MOCK en->zh-CN] -- Customer accounts\n")
+	assert.Contains(t, string(content), "COMMENT '[MOCK en->zh-CN] ''Account owner'''\n")
+	assert.Contains(t, string(content), "IS '[MOCK en->zh-CN] ''Customer''''s accounts''';\n")
+}
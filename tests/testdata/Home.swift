@@ -0,0 +1,16 @@
+import UIKit
+
+/// Home screen controller
+class HomeViewController: UIViewController {
+    // MARK: - Lifecycle
+
+    override func viewDidLoad() {
+        super.viewDidLoad() // TODO: load data
+    }
+}
+
+extension HomeViewController {
+    /// Number of rows
+    /// - Returns: the row count
+    func numberOfRows() -> Int { 0 }
+}
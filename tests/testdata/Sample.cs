@@ -0,0 +1,14 @@
+namespace Acme.Billing
+{
+    // Account entity
+    public class Account
+    {
+        /// <summary>
+        /// Adds money to the <see cref="Account"/>.
+        /// </summary>
+        /// <param name="amount">Amount to add</param>
+        public void Deposit(int amount)
+        {
+        }
+    }
+}
@@ -0,0 +1,15 @@
+/// A simple counter
+class Counter {
+  int _value = 0;
+
+  /// Increments the value, see [reset]
+  void increment() {
+    // TODO(alice): clamp the value
+    _value++;
+  }
+}
+
+extension CounterReset on Counter {
+  // Resets to zero
+  void reset() {}
+}
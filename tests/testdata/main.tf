@@ -0,0 +1,6 @@
+# Log bucket
+resource "aws_s3_bucket" "logs" {
+  // Globally unique name
+  bucket        = "acme-logs"
+  force_destroy = true /* Allow cleanup */
+}
@@ -0,0 +1,7 @@
+-- Customer accounts
+CREATE TABLE accounts (
+  id BIGINT PRIMARY KEY, -- Surrogate key
+  owner VARCHAR(64) NOT NULL COMMENT 'Account owner'
+);
+
+COMMENT ON TABLE accounts IS 'Customer''s accounts';
@@ -0,0 +1,14 @@
+namespace net {
+
+/**
+ * @brief Network server.
+ * @param port Port to listen on
+ */
+class Server {
+public:
+    // Close all connections
+    void close();
+    int port; ///< Listening port
+};
+
+}
@@ -0,0 +1,3 @@
+;; Adds two numbers
+(defun add (a b)
+  (+ a b))
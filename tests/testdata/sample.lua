@@ -0,0 +1,12 @@
+local M = {}
+
+--- Add two numbers
+---@param a number
+function M.add(a, b)
+  return a + b
+end
+
+--[==[ Legacy helper ]==]
+local function helper() end
+
+return M
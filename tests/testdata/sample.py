@@ -0,0 +1,13 @@
+"""Utilities for account handling."""
+
+# Default account balance
+DEFAULT_BALANCE = 0
+
+
+class Account:
+    """Bank account model."""
+
+    def deposit(self, amount):
+        """Add money to the account."""
+        # Update the balance
+        self.balance += amount
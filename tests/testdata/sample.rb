@@ -0,0 +1,12 @@
+# frozen_string_literal: true
+
+module Billing
+  # Monthly invoice
+  class Invoice
+    # Sum of all line items
+    # @return [Integer] total in cents
+    def total
+      0
+    end
+  end
+end
@@ -0,0 +1,56 @@
+// Command sidecar is a minimal external adapter used by the integration tests.
+// It treats lines starting with ";;" as comments. When SIDECAR_LOG is set, the method of
+// every request is appended to that file.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	var req map[string]interface{}
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+		os.Exit(1)
+	}
+	out := json.NewEncoder(os.Stdout)
+	if path := os.Getenv("SIDECAR_LOG"); path != "" {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			os.Exit(1)
+		}
+		fmt.Fprintln(f, req["method"])
+		f.Close()
+	}
+
+	if req["method"] == "handshake" {
+		out.Encode(map[string]interface{}{
+			"protocolVersion": 1,
+			"name":            "lisp-sidecar",
+			"capabilities":    []string{"parse", "markers"},
+			"markers":         map[string]interface{}{"line": []string{";;"}},
+		})
+		return
+	}
+
+	var comments []map[string]interface{}
+	symbol := ""
+	lines := strings.Split(req["source"].(string), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := lines[i]
+		if strings.HasPrefix(line, "(defun ") {
+			symbol = strings.Fields(line)[1]
+		}
+		if strings.HasPrefix(line, ";;") {
+			comments = append([]map[string]interface{}{{
+				"symbol":     symbol,
+				"sourceText": line,
+				"type":       "line",
+				"range":      map[string]int{"startLine": i + 1, "startCol": 1, "endLine": i + 1, "endCol": len(line) + 1},
+			}}, comments...)
+		}
+	}
+	out.Encode(map[string]interface{}{"comments": comments})
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/studyzy/codei18n/core/domain"
+)
+
+func TestVueScan(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	CreateFile(t, tempDir, "App.vue", LoadFixture(t, "App.vue"))
+
+	// Directory scan must pick up .vue files
+	cmd := exec.Command(bin, "scan", "--dir", ".", "--format", "json")
+	cmd.Dir = tempDir
+	output, err := cmd.Output()
+	require.NoError(t, err, "Vue scan failed: %s", string(output))
+
+	var comments []map[string]interface{}
+	require.NoError(t, json.Unmarshal(output, &comments))
+	require.Len(t, comments, 3)
+
+	for _, c := range comments {
+		AssertValidComment(t, c)
+		assert.Equal(t, "vue", c["language"])
+	}
+	assert.Equal(t, "<!-- Page header -->", comments[0]["sourceText"])
+	assert.Equal(t, "// Page title", comments[1]["sourceText"])
+	assert.Equal(t, "/* Title color */", comments[2]["sourceText"])
+}
+
+func TestVueConvertApply(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	bin := GetBinaryPath(t)
+	tempDir := t.TempDir()
+
+	sampleFile := CreateFile(t, tempDir, "App.vue", LoadFixture(t, "App.vue"))
+
+	for _, args := range [][]string{
+		{"init"},
+		{"map", "update"},
+		{"translate", "--provider", "mock"},
+	} {
+		cmd := exec.Command(bin, args...)
+		cmd.Dir = tempDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%v: %s", args, string(out))
+	}
+
+	// The mock translation keeps the original markers; give the template comment a
+	// translation without them, as a real translator returns
+	mappingFile := filepath.Join(tempDir, ".codei18n", "mappings.json")
+	raw, err := os.ReadFile(mappingFile)
+	require.NoError(t, err)
+	var m domain.Mapping
+	require.NoError(t, json.Unmarshal(raw, &m))
+	for _, translations := range m.Comments {
+		if translations["en"] == "<!-- Page header -->" {
+			translations["zh-CN"] = "页面头部"
+		}
+	}
+	raw, err = json.Marshal(m)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(mappingFile, raw, 0644))
+	RunCLI(t, tempDir, "convert", "--to", "zh-CN", "--file", "App.vue")
+
+	content, err := os.ReadFile(sampleFile)
+	require.NoError(t, err)
+	// Template comments are wrapped in a single well-formed <!-- --> pair
+	assert.Contains(t, string(content), "  <!-- 页面头部 -->\n")
+	assert.Contains(t, string(content), "// [MOCK en->zh-CN] // Page title\n")
+	assert.Contains(t, string(content), "<h1>{{ title }}</h1>")
+}